@@ -0,0 +1,97 @@
+package alignment
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/frhorschig/kant-search-api/generated/go/models"
+	"github.com/frhorschig/kant-search-backend/api/alignment/internal/errors"
+	"github.com/frhorschig/kant-search-backend/api/alignment/internal/mapping"
+	"github.com/frhorschig/kant-search-backend/core/alignment"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	emptyCodeMsg         = "empty work code"
+	invalidPaginationMsg = "invalid cursor or limit: %v, %v"
+)
+
+const (
+	// defaultLimit is the default number of aligned paragraph pairs of a page
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+// nextCursorHeader is the same header as the one of the paginated read endpoints
+const nextCursorHeader = "X-Next-Cursor"
+
+type AlignmentHandler interface {
+	ReadAlignment(ctx echo.Context) error
+}
+
+type alignmentHandlerImpl struct {
+	alignmentProcessor alignment.AlignmentProcessor
+}
+
+func NewAlignmentHandler(alignmentProcessor alignment.AlignmentProcessor) AlignmentHandler {
+	return &alignmentHandlerImpl{alignmentProcessor: alignmentProcessor}
+}
+
+func (rec *alignmentHandlerImpl) ReadAlignment(ctx echo.Context) error {
+	workCode := ctx.Param("workCode")
+	if workCode == "" {
+		log.Error().Msg(emptyCodeMsg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, emptyCodeMsg)
+	}
+	cursor, limit, err := findPagination(ctx)
+	if err != nil {
+		msg := fmt.Sprintf(invalidPaginationMsg, ctx.QueryParam("cursor"), ctx.QueryParam("limit"))
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+
+	page, err := rec.alignmentProcessor.ProcessAlignment(ctx.Request().Context(), workCode, cursor, limit)
+	if err != nil {
+		log.Error().Err(err).Msgf("error reading alignment of work %s: %v", workCode, err)
+		return errors.InternalServerError(ctx)
+	}
+	if page == nil {
+		return errors.NotFound(ctx)
+	}
+	if page.NextCursor != nil {
+		ctx.Response().Header().Set(nextCursorHeader, strconv.FormatInt(int64(*page.NextCursor), 10))
+	}
+	return ctx.JSON(http.StatusOK, mapping.AlignmentToApiModel(*page))
+}
+
+// findPagination returns the cursor and the limit; the cursor is 0 if it is missing
+func findPagination(ctx echo.Context) (int32, int, error) {
+	var cursor int32
+	cursorParam := strings.TrimSpace(ctx.QueryParam("cursor"))
+	if cursorParam != "" {
+		num, err := strconv.ParseInt(cursorParam, 10, 32)
+		if err != nil {
+			return 0, 0, err
+		}
+		if num < 0 {
+			return 0, 0, fmt.Errorf("cursor %d is negative", num)
+		}
+		cursor = int32(num)
+	}
+
+	limitParam := strings.TrimSpace(ctx.QueryParam("limit"))
+	if limitParam == "" {
+		return cursor, defaultLimit, nil
+	}
+	limit, err := strconv.Atoi(limitParam)
+	if err != nil {
+		return 0, 0, err
+	}
+	if limit < 1 || limit > maxLimit {
+		return 0, 0, fmt.Errorf("limit %d is not between 1 and %d", limit, maxLimit)
+	}
+	return cursor, limit, nil
+}
@@ -0,0 +1,174 @@
+//go:build unit
+// +build unit
+
+package alignment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/frhorschig/kant-search-api/generated/go/models"
+	"github.com/frhorschig/kant-search-backend/api/alignment/internal/mapping"
+	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/frhorschig/kant-search-backend/core/alignment"
+	"github.com/frhorschig/kant-search-backend/core/alignment/mocks"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlignmentHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	alignmentProcessor := mocks.NewMockAlignmentProcessor(ctrl)
+	sut := &alignmentHandlerImpl{
+		alignmentProcessor: alignmentProcessor,
+	}
+
+	for scenario, fn := range map[string]func(*testing.T, *alignmentHandlerImpl, *mocks.MockAlignmentProcessor){
+		"Read alignment":                      testReadAlignment,
+		"Read alignment with pagination":      testReadAlignmentPagination,
+		"Read alignment with empty work code": testReadAlignmentEmptyCode,
+		"Read alignment with invalid cursor":  testReadAlignmentInvalidCursor,
+		"Read alignment with invalid limit":   testReadAlignmentInvalidLimit,
+		"Read alignment not found":            testReadAlignmentNotFound,
+		"Read alignment with error":           testReadAlignmentError,
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			fn(t, sut, alignmentProcessor)
+		})
+	}
+}
+
+func testReadAlignment(t *testing.T, sut *alignmentHandlerImpl, alignmentProcessor *mocks.MockAlignmentProcessor) {
+	page := alignment.AlignmentPage{
+		WorkCode:      "KRV_A",
+		OtherWorkCode: "KRV_B",
+		Paragraphs: []alignment.AlignedParagraphs{
+			{
+				Paragraph:      &model.Content{Ordinal: 2, FmtText: "<ks-fmt-emph>Vernunft</ks-fmt-emph>", FnRefs: []string{}},
+				OtherParagraph: &model.Content{Ordinal: 5, FmtText: "Vernunft", FnRefs: []string{}},
+				Similarity:     1,
+				Diff:           []alignment.DiffOp{{Type: alignment.Equal, Text: "Vernunft"}},
+			},
+			{
+				OtherParagraph: &model.Content{Ordinal: 6, FmtText: "neu", FnRefs: []string{}},
+				Diff:           []alignment.DiffOp{{Type: alignment.Insert, Text: "neu"}},
+			},
+		},
+	}
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/KRV_A/alignment", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithCode(req, res, "KRV_A")
+	alignmentProcessor.EXPECT().ProcessAlignment(gomock.Any(), "KRV_A", int32(0), defaultLimit).Return(&page, nil)
+	// WHEN
+	sut.ReadAlignment(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Empty(t, res.Header().Get(nextCursorHeader))
+	var body mapping.Alignment
+	assert.Nil(t, json.Unmarshal(res.Body.Bytes(), &body))
+	assert.Equal(t, mapping.Alignment{
+		WorkCode:      "KRV_A",
+		OtherWorkCode: "KRV_B",
+		Paragraphs: []mapping.AlignedParagraphs{
+			{
+				Paragraph:      &models.Paragraph{Ordinal: 2, Text: "<ks-fmt-emph>Vernunft</ks-fmt-emph>", FnRefs: []string{}},
+				OtherParagraph: &models.Paragraph{Ordinal: 5, Text: "Vernunft", FnRefs: []string{}},
+				Similarity:     1,
+				Diff:           []mapping.DiffOp{{Type: "equal", Text: "Vernunft"}},
+			},
+			{
+				OtherParagraph: &models.Paragraph{Ordinal: 6, Text: "neu", FnRefs: []string{}},
+				Diff:           []mapping.DiffOp{{Type: "insert", Text: "neu"}},
+			},
+		},
+	}, body)
+}
+
+func testReadAlignmentPagination(t *testing.T, sut *alignmentHandlerImpl, alignmentProcessor *mocks.MockAlignmentProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/KRV_B/alignment?cursor=20&limit=10", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithCode(req, res, "KRV_B")
+	alignmentProcessor.EXPECT().ProcessAlignment(gomock.Any(), "KRV_B", int32(20), 10).Return(&alignment.AlignmentPage{NextCursor: util.Int32Ptr(30)}, nil)
+	// WHEN
+	sut.ReadAlignment(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Equal(t, "30", res.Header().Get(nextCursorHeader))
+}
+
+func testReadAlignmentEmptyCode(t *testing.T, sut *alignmentHandlerImpl, alignmentProcessor *mocks.MockAlignmentProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works//alignment", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithCode(req, res, "")
+	// WHEN
+	sut.ReadAlignment(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), emptyCodeMsg)
+}
+
+func testReadAlignmentInvalidCursor(t *testing.T, sut *alignmentHandlerImpl, alignmentProcessor *mocks.MockAlignmentProcessor) {
+	for _, cursor := range []string{"-1", "abc"} {
+		// GIVEN
+		req := httptest.NewRequest(echo.GET, "/api/v1/works/KRV_A/alignment?cursor="+cursor, nil)
+		res := httptest.NewRecorder()
+		ctx := createCtxWithCode(req, res, "KRV_A")
+		// WHEN
+		sut.ReadAlignment(ctx)
+		// THEN
+		assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	}
+}
+
+func testReadAlignmentInvalidLimit(t *testing.T, sut *alignmentHandlerImpl, alignmentProcessor *mocks.MockAlignmentProcessor) {
+	for _, limit := range []string{"0", "1001", "abc"} {
+		// GIVEN
+		req := httptest.NewRequest(echo.GET, "/api/v1/works/KRV_A/alignment?limit="+limit, nil)
+		res := httptest.NewRecorder()
+		ctx := createCtxWithCode(req, res, "KRV_A")
+		// WHEN
+		sut.ReadAlignment(ctx)
+		// THEN
+		assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	}
+}
+
+func testReadAlignmentNotFound(t *testing.T, sut *alignmentHandlerImpl, alignmentProcessor *mocks.MockAlignmentProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/GMS/alignment", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithCode(req, res, "GMS")
+	alignmentProcessor.EXPECT().ProcessAlignment(gomock.Any(), "GMS", int32(0), defaultLimit).Return(nil, nil)
+	// WHEN
+	sut.ReadAlignment(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotFound, ctx.Response().Status)
+}
+
+func testReadAlignmentError(t *testing.T, sut *alignmentHandlerImpl, alignmentProcessor *mocks.MockAlignmentProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/KRV_A/alignment", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithCode(req, res, "KRV_A")
+	alignmentProcessor.EXPECT().ProcessAlignment(gomock.Any(), "KRV_A", int32(0), defaultLimit).Return(nil, errors.New("test error"))
+	// WHEN
+	sut.ReadAlignment(ctx)
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().Status)
+}
+
+func createCtxWithCode(req *http.Request, res *httptest.ResponseRecorder, workCode string) echo.Context {
+	ctx := echo.New().NewContext(req, res)
+	ctx.SetParamNames("workCode")
+	ctx.SetParamValues(workCode)
+	return ctx
+}
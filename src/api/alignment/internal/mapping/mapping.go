@@ -0,0 +1,41 @@
+package mapping
+
+import (
+	"github.com/frhorschig/kant-search-api/generated/go/models"
+	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/frhorschig/kant-search-backend/core/alignment"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+)
+
+func AlignmentToApiModel(in alignment.AlignmentPage) Alignment {
+	out := Alignment{
+		WorkCode:      in.WorkCode,
+		OtherWorkCode: in.OtherWorkCode,
+		Paragraphs:    []AlignedParagraphs{},
+	}
+	for _, p := range in.Paragraphs {
+		aligned := AlignedParagraphs{
+			Paragraph:      paragraphToApiModel(p.Paragraph),
+			OtherParagraph: paragraphToApiModel(p.OtherParagraph),
+			Similarity:     p.Similarity,
+			Diff:           []DiffOp{},
+		}
+		for _, op := range p.Diff {
+			aligned.Diff = append(aligned.Diff, DiffOp{Type: string(op.Type), Text: op.Text})
+		}
+		out.Paragraphs = append(out.Paragraphs, aligned)
+	}
+	return out
+}
+
+func paragraphToApiModel(c *model.Content) *models.Paragraph {
+	if c == nil {
+		return nil
+	}
+	return &models.Paragraph{
+		Ordinal:    c.Ordinal,
+		Text:       c.FmtText,
+		FnRefs:     c.FnRefs,
+		SummaryRef: util.StrVal(c.SummaryRef),
+	}
+}
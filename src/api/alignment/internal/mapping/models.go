@@ -0,0 +1,24 @@
+package mapping
+
+import "github.com/frhorschig/kant-search-api/generated/go/models"
+
+// The following types are not (yet) part of the generated API models.
+
+type Alignment struct {
+	WorkCode      string              `json:"workCode"`
+	OtherWorkCode string              `json:"otherWorkCode"`
+	Paragraphs    []AlignedParagraphs `json:"paragraphs"`
+}
+
+// AlignedParagraphs is a paragraph and the matching paragraph of the other work, one of them is missing if a paragraph has no match
+type AlignedParagraphs struct {
+	Paragraph      *models.Paragraph `json:"paragraph,omitempty"`
+	OtherParagraph *models.Paragraph `json:"otherParagraph,omitempty"`
+	Similarity     float64           `json:"similarity"`
+	Diff           []DiffOp          `json:"diff"` // word diff of the unformatted texts, from the paragraph to the other paragraph
+}
+
+type DiffOp struct {
+	Type string `json:"type"` // "equal", "insert" or "delete"
+	Text string `json:"text"`
+}
@@ -0,0 +1,128 @@
+package export
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/frhorschig/kant-search-api/generated/go/models"
+	"github.com/frhorschig/kant-search-backend/api/export/internal/errors"
+	"github.com/frhorschig/kant-search-backend/core/export"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	emptyCodeMsg        = "empty work code"
+	invalidVolumeMsg    = "invalid volume number: %v"
+	epubContentType     = "application/epub+zip"
+	teiContentType      = "application/tei+xml"
+	attachmentHeaderFmt = `attachment; filename="%s"`
+)
+
+type ExportHandler interface {
+	ExportWorkEpub(ctx echo.Context) error
+	ExportVolumeEpub(ctx echo.Context) error
+	ExportWorkTei(ctx echo.Context) error
+	ExportVolumeTei(ctx echo.Context) error
+}
+
+type exportHandlerImpl struct {
+	exportProcessor export.ExportProcessor
+}
+
+func NewExportHandler(exportProcessor export.ExportProcessor) ExportHandler {
+	return &exportHandlerImpl{exportProcessor: exportProcessor}
+}
+
+func (rec *exportHandlerImpl) ExportWorkEpub(ctx echo.Context) error {
+	workCode := ctx.Param("workCode")
+	if workCode == "" {
+		log.Error().Msg(emptyCodeMsg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, emptyCodeMsg)
+	}
+
+	data, err := rec.exportProcessor.ProcessWorkEpub(ctx.Request().Context(), workCode)
+	if err != nil {
+		log.Error().Err(err).Msgf("error exporting work %s as epub: %v", workCode, err)
+		return errors.InternalServerError(ctx)
+	}
+	if data == nil {
+		return errors.NotFound(ctx)
+	}
+	return sendFile(ctx, data, epubContentType, workCode+".epub")
+}
+
+func (rec *exportHandlerImpl) ExportVolumeEpub(ctx echo.Context) error {
+	volParam := ctx.Param("volumeNumber")
+	volumeNumber, err := findVolumeNumber(volParam)
+	if err != nil {
+		msg := fmt.Sprintf(invalidVolumeMsg, volParam)
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+
+	data, err := rec.exportProcessor.ProcessVolumeEpub(ctx.Request().Context(), volumeNumber)
+	if err != nil {
+		log.Error().Err(err).Msgf("error exporting volume %d as epub: %v", volumeNumber, err)
+		return errors.InternalServerError(ctx)
+	}
+	if data == nil {
+		return errors.NotFound(ctx)
+	}
+	return sendFile(ctx, data, epubContentType, fmt.Sprintf("volume-%d.epub", volumeNumber))
+}
+
+func (rec *exportHandlerImpl) ExportWorkTei(ctx echo.Context) error {
+	workCode := ctx.Param("workCode")
+	if workCode == "" {
+		log.Error().Msg(emptyCodeMsg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, emptyCodeMsg)
+	}
+
+	data, err := rec.exportProcessor.ProcessWorkTei(ctx.Request().Context(), workCode)
+	if err != nil {
+		log.Error().Err(err).Msgf("error exporting work %s as tei: %v", workCode, err)
+		return errors.InternalServerError(ctx)
+	}
+	if data == nil {
+		return errors.NotFound(ctx)
+	}
+	return sendFile(ctx, data, teiContentType, workCode+".xml")
+}
+
+func (rec *exportHandlerImpl) ExportVolumeTei(ctx echo.Context) error {
+	volParam := ctx.Param("volumeNumber")
+	volumeNumber, err := findVolumeNumber(volParam)
+	if err != nil {
+		msg := fmt.Sprintf(invalidVolumeMsg, volParam)
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+
+	data, err := rec.exportProcessor.ProcessVolumeTei(ctx.Request().Context(), volumeNumber)
+	if err != nil {
+		log.Error().Err(err).Msgf("error exporting volume %d as tei: %v", volumeNumber, err)
+		return errors.InternalServerError(ctx)
+	}
+	if data == nil {
+		return errors.NotFound(ctx)
+	}
+	return sendFile(ctx, data, teiContentType, fmt.Sprintf("volume-%d.xml", volumeNumber))
+}
+
+func findVolumeNumber(volParam string) (int32, error) {
+	num, err := strconv.ParseInt(volParam, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if num < 1 {
+		return 0, fmt.Errorf("%d is not a positive number", num)
+	}
+	return int32(num), nil
+}
+
+func sendFile(ctx echo.Context, data []byte, contentType string, fileName string) error {
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(attachmentHeaderFmt, fileName))
+	return ctx.Blob(http.StatusOK, contentType, data)
+}
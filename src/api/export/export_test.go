@@ -0,0 +1,205 @@
+//go:build unit
+// +build unit
+
+package export
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/frhorschig/kant-search-backend/core/export/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	exportProcessor := mocks.NewMockExportProcessor(ctrl)
+	sut := &exportHandlerImpl{
+		exportProcessor: exportProcessor,
+	}
+
+	for scenario, fn := range map[string]func(*testing.T, *exportHandlerImpl, *mocks.MockExportProcessor){
+		"Export work epub":                   testExportWorkEpub,
+		"Export work epub with empty code":   testExportWorkEpubEmptyCode,
+		"Export work epub not found":         testExportWorkEpubNotFound,
+		"Export work epub with error":        testExportWorkEpubError,
+		"Export volume epub":                 testExportVolumeEpub,
+		"Export volume epub with bad volume": testExportVolumeEpubBadVolume,
+		"Export volume epub not found":       testExportVolumeEpubNotFound,
+		"Export volume epub with error":      testExportVolumeEpubError,
+		"Export work tei":                    testExportWorkTei,
+		"Export work tei not found":          testExportWorkTeiNotFound,
+		"Export volume tei":                  testExportVolumeTei,
+		"Export volume tei with error":       testExportVolumeTeiError,
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			fn(t, sut, exportProcessor)
+		})
+	}
+}
+
+func testExportWorkEpub(t *testing.T, sut *exportHandlerImpl, exportProcessor *mocks.MockExportProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/GMS/export/epub", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "workCode", "GMS")
+	exportProcessor.EXPECT().ProcessWorkEpub(gomock.Any(), "GMS").Return([]byte("epub"), nil)
+	// WHEN
+	sut.ExportWorkEpub(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Equal(t, "application/epub+zip", res.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `attachment; filename="GMS.epub"`, res.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t, "epub", res.Body.String())
+}
+
+func testExportWorkEpubEmptyCode(t *testing.T, sut *exportHandlerImpl, exportProcessor *mocks.MockExportProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works//export/epub", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "workCode", "")
+	// WHEN
+	sut.ExportWorkEpub(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "empty work code")
+}
+
+func testExportWorkEpubNotFound(t *testing.T, sut *exportHandlerImpl, exportProcessor *mocks.MockExportProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/GMS/export/epub", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "workCode", "GMS")
+	exportProcessor.EXPECT().ProcessWorkEpub(gomock.Any(), "GMS").Return(nil, nil)
+	// WHEN
+	sut.ExportWorkEpub(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotFound, ctx.Response().Status)
+}
+
+func testExportWorkEpubError(t *testing.T, sut *exportHandlerImpl, exportProcessor *mocks.MockExportProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/GMS/export/epub", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "workCode", "GMS")
+	exportProcessor.EXPECT().ProcessWorkEpub(gomock.Any(), "GMS").Return(nil, errors.New("test error"))
+	// WHEN
+	sut.ExportWorkEpub(ctx)
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().Status)
+}
+
+func testExportVolumeEpub(t *testing.T, sut *exportHandlerImpl, exportProcessor *mocks.MockExportProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/4/export/epub", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "volumeNumber", "4")
+	exportProcessor.EXPECT().ProcessVolumeEpub(gomock.Any(), int32(4)).Return([]byte("epub"), nil)
+	// WHEN
+	sut.ExportVolumeEpub(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Equal(t, `attachment; filename="volume-4.epub"`, res.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t, "epub", res.Body.String())
+}
+
+func testExportVolumeEpubBadVolume(t *testing.T, sut *exportHandlerImpl, exportProcessor *mocks.MockExportProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/0/export/epub", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "volumeNumber", "0")
+	// WHEN
+	sut.ExportVolumeEpub(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "invalid volume number")
+}
+
+func testExportVolumeEpubNotFound(t *testing.T, sut *exportHandlerImpl, exportProcessor *mocks.MockExportProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/4/export/epub", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "volumeNumber", "4")
+	exportProcessor.EXPECT().ProcessVolumeEpub(gomock.Any(), int32(4)).Return(nil, nil)
+	// WHEN
+	sut.ExportVolumeEpub(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotFound, ctx.Response().Status)
+}
+
+func testExportVolumeEpubError(t *testing.T, sut *exportHandlerImpl, exportProcessor *mocks.MockExportProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/4/export/epub", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "volumeNumber", "4")
+	exportProcessor.EXPECT().ProcessVolumeEpub(gomock.Any(), int32(4)).Return(nil, errors.New("test error"))
+	// WHEN
+	sut.ExportVolumeEpub(ctx)
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().Status)
+}
+
+func testExportWorkTei(t *testing.T, sut *exportHandlerImpl, exportProcessor *mocks.MockExportProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/GMS/export/tei", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "workCode", "GMS")
+	exportProcessor.EXPECT().ProcessWorkTei(gomock.Any(), "GMS").Return([]byte("<TEI/>"), nil)
+	// WHEN
+	sut.ExportWorkTei(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Equal(t, "application/tei+xml", res.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `attachment; filename="GMS.xml"`, res.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t, "<TEI/>", res.Body.String())
+}
+
+func testExportWorkTeiNotFound(t *testing.T, sut *exportHandlerImpl, exportProcessor *mocks.MockExportProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/GMS/export/tei", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "workCode", "GMS")
+	exportProcessor.EXPECT().ProcessWorkTei(gomock.Any(), "GMS").Return(nil, nil)
+	// WHEN
+	sut.ExportWorkTei(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotFound, ctx.Response().Status)
+}
+
+func testExportVolumeTei(t *testing.T, sut *exportHandlerImpl, exportProcessor *mocks.MockExportProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/4/export/tei", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "volumeNumber", "4")
+	exportProcessor.EXPECT().ProcessVolumeTei(gomock.Any(), int32(4)).Return([]byte("<TEI/>"), nil)
+	// WHEN
+	sut.ExportVolumeTei(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Equal(t, `attachment; filename="volume-4.xml"`, res.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t, "<TEI/>", res.Body.String())
+}
+
+func testExportVolumeTeiError(t *testing.T, sut *exportHandlerImpl, exportProcessor *mocks.MockExportProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/4/export/tei", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "volumeNumber", "4")
+	exportProcessor.EXPECT().ProcessVolumeTei(gomock.Any(), int32(4)).Return(nil, errors.New("test error"))
+	// WHEN
+	sut.ExportVolumeTei(ctx)
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().Status)
+}
+
+func createCtxWithParam(req *http.Request, res *httptest.ResponseRecorder, name string, value string) echo.Context {
+	ctx := echo.New().NewContext(req, res)
+	ctx.SetParamNames(name)
+	ctx.SetParamValues(value)
+	return ctx
+}
@@ -0,0 +1,93 @@
+package image
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/frhorschig/kant-search-api/generated/go/models"
+	"github.com/frhorschig/kant-search-backend/api/image/internal/errors"
+	"github.com/frhorschig/kant-search-backend/core/image"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	emptyNameMsg      = "empty image name"
+	etagHeader        = "ETag"
+	ifNoneMatchHeader = "If-None-Match"
+	// images can be replaced by uploading them again, so cached images must be revalidated
+	cacheControl = "public, no-cache"
+	// uploaded SVG images may contain scripts, which must never be executed
+	contentSecurityPolicy = "default-src 'none'; style-src 'unsafe-inline'; sandbox"
+)
+
+type ImageHandler interface {
+	PostImage(ctx echo.Context) error
+	ReadImage(ctx echo.Context) error
+}
+
+type imageHandlerImpl struct {
+	imageProcessor image.ImageProcessor
+}
+
+func NewImageHandler(imageProcessor image.ImageProcessor) ImageHandler {
+	return &imageHandlerImpl{imageProcessor: imageProcessor}
+}
+
+func (rec *imageHandlerImpl) PostImage(ctx echo.Context) error {
+	name := ctx.Param("name")
+	if name == "" {
+		log.Error().Msg(emptyNameMsg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, emptyNameMsg)
+	}
+	data, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		msg := fmt.Sprintf("error reading request body: %v", err.Error())
+		log.Error().Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+
+	if err := rec.imageProcessor.ProcessUpload(ctx.Request().Context(), name, data); err.HasError {
+		if err.DomainError != nil {
+			msg := err.DomainError.Error()
+			log.Error().Msg(msg)
+			return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+		}
+		log.Error().Err(err.TechnicalError).Msgf("error storing image %s: %v", name, err.TechnicalError)
+		return errors.InternalServerError(ctx)
+	}
+	return ctx.NoContent(http.StatusCreated)
+}
+
+func (rec *imageHandlerImpl) ReadImage(ctx echo.Context) error {
+	name := ctx.Param("name")
+	if name == "" {
+		log.Error().Msg(emptyNameMsg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, emptyNameMsg)
+	}
+
+	img, err := rec.imageProcessor.ProcessImage(ctx.Request().Context(), name)
+	if err != nil {
+		log.Error().Err(err).Msgf("error reading image %s: %v", name, err)
+		return errors.InternalServerError(ctx)
+	}
+	if img == nil {
+		return errors.NotFound(ctx)
+	}
+
+	etag := fmt.Sprintf(`"%s"`, img.Version)
+	header := ctx.Response().Header()
+	header.Set(echo.HeaderCacheControl, cacheControl)
+	header.Set(etagHeader, etag)
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+	header.Set(echo.HeaderContentSecurityPolicy, contentSecurityPolicy)
+	for _, candidate := range strings.Split(ctx.Request().Header.Get(ifNoneMatchHeader), ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return ctx.NoContent(http.StatusNotModified)
+		}
+	}
+	return ctx.Blob(http.StatusOK, img.ContentType, img.Data)
+}
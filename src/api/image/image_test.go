@@ -0,0 +1,141 @@
+//go:build unit
+// +build unit
+
+package image
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/frhorschig/kant-search-backend/common/errs"
+	"github.com/frhorschig/kant-search-backend/core/image"
+	"github.com/frhorschig/kant-search-backend/core/image/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	imageProcessor := mocks.NewMockImageProcessor(ctrl)
+	sut := &imageHandlerImpl{
+		imageProcessor: imageProcessor,
+	}
+
+	for scenario, fn := range map[string]func(*testing.T, *imageHandlerImpl, *mocks.MockImageProcessor){
+		"Post image":                   testPostImage,
+		"Post image with domain error": testPostImageDomainError,
+		"Post image with error":        testPostImageError,
+		"Read image":                   testReadImage,
+		"Read image not modified":      testReadImageNotModified,
+		"Read image not found":         testReadImageNotFound,
+		"Read image with error":        testReadImageError,
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			fn(t, sut, imageProcessor)
+		})
+	}
+}
+
+func testPostImage(t *testing.T, sut *imageHandlerImpl, imageProcessor *mocks.MockImageProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.POST, "/api/v1/upload/images/fig.png", strings.NewReader("data"))
+	res := httptest.NewRecorder()
+	ctx := createCtxWithName(req, res, "fig.png")
+	imageProcessor.EXPECT().ProcessUpload(gomock.Any(), "fig.png", []byte("data")).Return(errs.Nil())
+	// WHEN
+	sut.PostImage(ctx)
+	// THEN
+	assert.Equal(t, http.StatusCreated, ctx.Response().Status)
+}
+
+func testPostImageDomainError(t *testing.T, sut *imageHandlerImpl, imageProcessor *mocks.MockImageProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.POST, "/api/v1/upload/images/fig.bmp", strings.NewReader("data"))
+	res := httptest.NewRecorder()
+	ctx := createCtxWithName(req, res, "fig.bmp")
+	imageProcessor.EXPECT().ProcessUpload(gomock.Any(), "fig.bmp", []byte("data")).Return(errs.New(errors.New("unsupported file extension"), nil))
+	// WHEN
+	sut.PostImage(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "unsupported file extension")
+}
+
+func testPostImageError(t *testing.T, sut *imageHandlerImpl, imageProcessor *mocks.MockImageProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.POST, "/api/v1/upload/images/fig.png", strings.NewReader("data"))
+	res := httptest.NewRecorder()
+	ctx := createCtxWithName(req, res, "fig.png")
+	imageProcessor.EXPECT().ProcessUpload(gomock.Any(), "fig.png", []byte("data")).Return(errs.New(nil, errors.New("test error")))
+	// WHEN
+	sut.PostImage(ctx)
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().Status)
+}
+
+func testReadImage(t *testing.T, sut *imageHandlerImpl, imageProcessor *mocks.MockImageProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/images/fig.png", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithName(req, res, "fig.png")
+	imageProcessor.EXPECT().ProcessImage(gomock.Any(), "fig.png").Return(&image.Image{Data: []byte("data"), ContentType: "image/png", Version: "abc"}, nil)
+	// WHEN
+	sut.ReadImage(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Equal(t, "image/png", res.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `"abc"`, res.Header().Get("ETag"))
+	assert.Equal(t, "public, no-cache", res.Header().Get(echo.HeaderCacheControl))
+	assert.Equal(t, "nosniff", res.Header().Get(echo.HeaderXContentTypeOptions))
+	assert.Equal(t, "data", res.Body.String())
+}
+
+func testReadImageNotModified(t *testing.T, sut *imageHandlerImpl, imageProcessor *mocks.MockImageProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/images/fig.png", nil)
+	req.Header.Set("If-None-Match", `"old", W/"abc"`)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithName(req, res, "fig.png")
+	imageProcessor.EXPECT().ProcessImage(gomock.Any(), "fig.png").Return(&image.Image{Data: []byte("data"), ContentType: "image/png", Version: "abc"}, nil)
+	// WHEN
+	sut.ReadImage(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotModified, ctx.Response().Status)
+	assert.Empty(t, res.Body.String())
+}
+
+func testReadImageNotFound(t *testing.T, sut *imageHandlerImpl, imageProcessor *mocks.MockImageProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/images/fig.png", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithName(req, res, "fig.png")
+	imageProcessor.EXPECT().ProcessImage(gomock.Any(), "fig.png").Return(nil, nil)
+	// WHEN
+	sut.ReadImage(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotFound, ctx.Response().Status)
+}
+
+func testReadImageError(t *testing.T, sut *imageHandlerImpl, imageProcessor *mocks.MockImageProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/images/fig.png", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithName(req, res, "fig.png")
+	imageProcessor.EXPECT().ProcessImage(gomock.Any(), "fig.png").Return(nil, errors.New("test error"))
+	// WHEN
+	sut.ReadImage(ctx)
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().Status)
+}
+
+func createCtxWithName(req *http.Request, res *httptest.ResponseRecorder, name string) echo.Context {
+	ctx := echo.New().NewContext(req, res)
+	ctx.SetParamNames("name")
+	ctx.SetParamValues(name)
+	return ctx
+}
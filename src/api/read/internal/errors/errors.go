@@ -7,6 +7,9 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// not (yet) part of the generated API models
+const BadRequestInvalidCitation models.ErrorMessage = "BAD_REQUEST_INVALID_CITATION"
+
 func BadRequest(ctx echo.Context, msg models.ErrorMessage, params ...string) error {
 	return ctx.JSON(http.StatusBadRequest, models.HttpError{
 		Code:    http.StatusBadRequest,
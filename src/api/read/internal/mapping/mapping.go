@@ -1,8 +1,11 @@
 package mapping
 
 import (
+	"strconv"
+
 	"github.com/frhorschig/kant-search-api/generated/go/models"
 	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/frhorschig/kant-search-backend/core/read"
 	"github.com/frhorschig/kant-search-backend/dataaccess/model"
 )
 
@@ -32,6 +35,52 @@ func VolumesToApiModels(in []model.Volume) []models.Volume {
 	return out
 }
 
+func VolumeMetadataToApiModel(in read.VolumeMetadata) VolumeMetadata {
+	out := VolumeMetadata{
+		VolumeNumber: in.Volume.VolumeNumber,
+		Title:        in.Volume.Title,
+		Works:        []WorkMetadata{},
+	}
+	for _, w := range in.Volume.Works {
+		work := workMetadataToApiModel(w, in.Counts[w.Code])
+		out.Works = append(out.Works, work)
+		out.Counts.Headings += work.Counts.Headings
+		out.Counts.Paragraphs += work.Counts.Paragraphs
+		out.Counts.Footnotes += work.Counts.Footnotes
+		out.Counts.Summaries += work.Counts.Summaries
+	}
+	return out
+}
+
+func WorkMetadataToApiModel(in read.WorkMetadata) WorkDetails {
+	paragraphs := in.Work.Paragraphs
+	if paragraphs == nil {
+		paragraphs = []int32{}
+	}
+	return WorkDetails{
+		WorkMetadata: workMetadataToApiModel(in.Work, in.Counts),
+		VolumeNumber: in.VolumeNumber,
+		Paragraphs:   paragraphs,
+		Sections:     mapSectionsWithMetadata(in.Work.Sections),
+	}
+}
+
+func workMetadataToApiModel(w model.Work, counts model.ContentCounts) WorkMetadata {
+	return WorkMetadata{
+		Ordinal: w.Ordinal,
+		Code:    w.Code,
+		Siglum:  util.StrVal(w.Siglum),
+		Title:   w.Title,
+		Year:    w.Year,
+		Counts: ContentCounts{
+			Headings:   counts[model.Heading],
+			Paragraphs: counts[model.Paragraph],
+			Footnotes:  counts[model.Footnote],
+			Summaries:  counts[model.Summary],
+		},
+	}
+}
+
 func mapSections(in []model.Section) []models.Section {
 	out := []models.Section{}
 	for _, sIn := range in {
@@ -44,40 +93,106 @@ func mapSections(in []model.Section) []models.Section {
 	return out
 }
 
+func mapSectionsWithMetadata(in []model.Section) []Section {
+	out := []Section{}
+	for _, sIn := range in {
+		sOut := Section{
+			Heading:    sIn.Heading,
+			Paragraphs: sIn.Paragraphs,
+			Sections:   mapSectionsWithMetadata(sIn.Sections),
+		}
+		if sIn.Letter != nil {
+			sOut.Letter = &Letter{
+				Number:    sIn.Letter.Number,
+				Sender:    sIn.Letter.Sender,
+				Recipient: sIn.Letter.Recipient,
+				Date:      sIn.Letter.Date,
+			}
+		}
+		if sIn.Reflection != nil {
+			sOut.Reflection = &Reflection{
+				Number: sIn.Reflection.Number,
+				Dating: sIn.Reflection.Dating,
+			}
+		}
+		out = append(out, sOut)
+	}
+	return out
+}
+
 func FootnotesToApiModels(in []model.Content) []models.Footnote {
 	out := []models.Footnote{}
 	for _, c := range in {
-		out = append(out, models.Footnote{
-			Ordinal: c.Ordinal,
-			Ref:     util.StrVal(c.Ref),
-			Text:    c.FmtText,
-		})
+		out = append(out, footnoteToApiModel(c))
 	}
 	return out
 }
 
+func footnoteToApiModel(c model.Content) models.Footnote {
+	return models.Footnote{
+		Ordinal: c.Ordinal,
+		Ref:     util.StrVal(c.Ref),
+		Text:    c.FmtText,
+	}
+}
+
 func HeadingsToApiModels(in []model.Content) []models.Heading {
 	out := []models.Heading{}
 	for _, c := range in {
-		out = append(out, models.Heading{
-			Ordinal: c.Ordinal,
-			Text:    c.FmtText,
-			TocText: util.StrVal(c.TocText),
-			Pages:   c.Pages,
-			FnRefs:  c.FnRefs,
-		})
+		out = append(out, headingToApiModel(c))
 	}
 	return out
 }
 
+func headingToApiModel(c model.Content) models.Heading {
+	return models.Heading{
+		Ordinal: c.Ordinal,
+		Text:    c.FmtText,
+		TocText: util.StrVal(c.TocText),
+		Pages:   c.Pages,
+		FnRefs:  c.FnRefs,
+	}
+}
+
 func ParagraphsToApiModels(in []model.Content) []models.Paragraph {
 	out := []models.Paragraph{}
 	for _, c := range in {
-		out = append(out, models.Paragraph{
-			Ordinal:    c.Ordinal,
-			Text:       c.FmtText,
-			FnRefs:     c.FnRefs,
-			SummaryRef: util.StrVal(c.SummaryRef),
+		out = append(out, paragraphToApiModel(c))
+	}
+	return out
+}
+
+func paragraphToApiModel(c model.Content) models.Paragraph {
+	return models.Paragraph{
+		Ordinal:    c.Ordinal,
+		Text:       c.FmtText,
+		FnRefs:     c.FnRefs,
+		SummaryRef: util.StrVal(c.SummaryRef),
+	}
+}
+
+func EmbeddedParagraphsToApiModels(in []read.EmbeddedContent) []EmbeddedParagraph {
+	out := []EmbeddedParagraph{}
+	for _, e := range in {
+		p := EmbeddedParagraph{
+			Paragraph: paragraphToApiModel(e.Content),
+			Footnotes: FootnotesToApiModels(e.Footnotes),
+		}
+		if e.Summary != nil {
+			summ := summaryToApiModel(*e.Summary)
+			p.Summary = &summ
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+func EmbeddedHeadingsToApiModels(in []read.EmbeddedContent) []EmbeddedHeading {
+	out := []EmbeddedHeading{}
+	for _, e := range in {
+		out = append(out, EmbeddedHeading{
+			Heading:   headingToApiModel(e.Content),
+			Footnotes: FootnotesToApiModels(e.Footnotes),
 		})
 	}
 	return out
@@ -86,12 +201,137 @@ func ParagraphsToApiModels(in []model.Content) []models.Paragraph {
 func SummariesToApiModels(in []model.Content) []models.Summary {
 	out := []models.Summary{}
 	for _, c := range in {
-		out = append(out, models.Summary{
-			Ordinal: c.Ordinal,
-			Ref:     util.StrVal(c.Ref),
-			Text:    c.FmtText,
-			FnRefs:  c.FnRefs,
+		out = append(out, summaryToApiModel(c))
+	}
+	return out
+}
+
+func summaryToApiModel(c model.Content) models.Summary {
+	return models.Summary{
+		Ordinal: c.Ordinal,
+		Ref:     util.StrVal(c.Ref),
+		Text:    c.FmtText,
+		FnRefs:  c.FnRefs,
+	}
+}
+
+func WorkTextToApiModel(in read.WorkText) WorkText {
+	out := WorkText{
+		Code:     in.Work.Code,
+		Title:    in.Work.Title,
+		Contents: []TextContent{},
+	}
+	for _, item := range in.Items {
+		c := TextContent{
+			Type:      string(item.Content.Type),
+			Level:     item.Level,
+			Footnotes: FootnotesToApiModels(item.Footnotes),
+		}
+		if item.Content.Type == model.Heading {
+			heading := headingToApiModel(item.Content)
+			c.Heading = &heading
+		} else {
+			paragraph := paragraphToApiModel(item.Content)
+			c.Paragraph = &paragraph
+		}
+		if item.Summary != nil {
+			summary := summaryToApiModel(*item.Summary)
+			c.Summary = &summary
+		}
+		out.Contents = append(out.Contents, c)
+	}
+	return out
+}
+
+func PageToApiModel(in read.Page) PageText {
+	out := PageText{
+		VolumeNumber: in.VolumeNumber,
+		Page:         in.Page,
+		Contents:     []PageContent{},
+		Prev:         in.Prev,
+		Next:         in.Next,
+	}
+	for _, c := range in.Contents {
+		out.Contents = append(out.Contents, PageContent{
+			Type:         string(c.Type),
+			WorkCode:     c.WorkCode,
+			Ordinal:      c.Ordinal,
+			Text:         c.FmtText,
+			PageByIndex:  mapIndexNumberPairs(c.PageByIndex),
+			LineByIndex:  mapIndexNumberPairs(c.LineByIndex),
+			WordIndexMap: mapWordIndexMap(c.WordIndexMap),
 		})
 	}
 	return out
 }
+
+// mapIndexNumberPairs returns nil if there are no pairs
+func mapIndexNumberPairs(in []model.IndexNumberPair) []models.IndexNumberPair {
+	if len(in) == 0 {
+		return nil
+	}
+	out := []models.IndexNumberPair{}
+	for _, pair := range in {
+		out = append(out, models.IndexNumberPair{I: pair.I, Num: pair.Num})
+	}
+	return out
+}
+
+// mapWordIndexMap returns nil if the map is empty, the keys are strings in JSON
+func mapWordIndexMap(in map[int32]int32) map[string]int32 {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]int32)
+	for k, v := range in {
+		out[strconv.Itoa(int(k))] = v
+	}
+	return out
+}
+
+func CitationToApiModel(in read.ResolvedCitation) ResolvedCitation {
+	out := ResolvedCitation{
+		WorkCode: in.WorkCode,
+		Ordinals: in.Ordinals,
+	}
+	if in.LineOffset != nil {
+		out.LineOrdinal = &in.LineOffset.Ordinal
+		out.LineOffset = &in.LineOffset.Index
+	}
+	return out
+}
+
+func NavigationToApiModel(in read.Navigation) Navigation {
+	return Navigation{
+		PrevHeading:   locationToApiModel(in.PrevHeading),
+		PrevParagraph: locationToApiModel(in.PrevParagraph),
+		NextHeading:   locationToApiModel(in.NextHeading),
+		NextParagraph: locationToApiModel(in.NextParagraph),
+	}
+}
+
+func PersonsToApiModels(in []read.Person) []Person {
+	result := []Person{}
+	for _, p := range in {
+		occurrences := []PersonOccurrence{}
+		for _, o := range p.Occurrences {
+			occurrences = append(occurrences, PersonOccurrence{
+				ContentLocation: *locationToApiModel(&o.Location),
+				Type:            string(o.Type),
+			})
+		}
+		result = append(result, Person{Name: p.Name, Occurrences: occurrences})
+	}
+	return result
+}
+
+func locationToApiModel(in *read.Location) *ContentLocation {
+	if in == nil {
+		return nil
+	}
+	return &ContentLocation{
+		VolumeNumber: in.VolumeNumber,
+		WorkCode:     in.WorkCode,
+		Ordinal:      in.Ordinal,
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/frhorschig/kant-search-api/generated/go/models"
 	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/frhorschig/kant-search-backend/core/read"
 	"github.com/frhorschig/kant-search-backend/dataaccess/model"
 )
 
@@ -61,6 +62,70 @@ func TestVolumesToApiModels(t *testing.T) {
 	}
 }
 
+func TestVolumeMetadataToApiModel(t *testing.T) {
+	in := read.VolumeMetadata{
+		Volume: model.Volume{
+			VolumeNumber: 4,
+			Title:        "Volume Four",
+			Works: []model.Work{
+				{Ordinal: 1, Code: "C1", Siglum: util.StrPtr("abbr"), Title: "The Work", Year: "2024", Sections: []model.Section{{Heading: 1}}},
+				{Ordinal: 2, Code: "C2", Title: "Another Work", Year: "2025"},
+			},
+		},
+		Counts: map[string]model.ContentCounts{
+			"C1": {model.Heading: 1, model.Paragraph: 5, model.Footnote: 2},
+			"C2": {model.Paragraph: 3, model.Summary: 1},
+		},
+	}
+	expected := VolumeMetadata{
+		VolumeNumber: 4,
+		Title:        "Volume Four",
+		Works: []WorkMetadata{
+			{Ordinal: 1, Code: "C1", Siglum: "abbr", Title: "The Work", Year: "2024", Counts: ContentCounts{Headings: 1, Paragraphs: 5, Footnotes: 2}},
+			{Ordinal: 2, Code: "C2", Siglum: "", Title: "Another Work", Year: "2025", Counts: ContentCounts{Paragraphs: 3, Summaries: 1}},
+		},
+		Counts: ContentCounts{Headings: 1, Paragraphs: 8, Footnotes: 2, Summaries: 1},
+	}
+
+	out := VolumeMetadataToApiModel(in)
+	if !reflect.DeepEqual(out, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, out)
+	}
+}
+
+func TestWorkMetadataToApiModel(t *testing.T) {
+	in := read.WorkMetadata{
+		VolumeNumber: 4,
+		Work: model.Work{
+			Ordinal: 1,
+			Code:    "C1",
+			Title:   "The Work",
+			Year:    "2024",
+			Sections: []model.Section{
+				{Heading: 1, Paragraphs: []int32{2, 3}},
+				{Heading: 4, Letter: &model.Letter{Number: "12", Sender: "Kant", Recipient: "Lambert", Date: "1765-12-31"}},
+				{Heading: 5, Reflection: &model.Reflection{Number: "4275", Dating: "1770-71"}},
+			},
+		},
+		Counts: model.ContentCounts{model.Heading: 3, model.Paragraph: 2},
+	}
+	expected := WorkDetails{
+		WorkMetadata: WorkMetadata{Ordinal: 1, Code: "C1", Title: "The Work", Year: "2024", Counts: ContentCounts{Headings: 3, Paragraphs: 2}},
+		VolumeNumber: 4,
+		Paragraphs:   []int32{},
+		Sections: []Section{
+			{Heading: 1, Paragraphs: []int32{2, 3}, Sections: []Section{}},
+			{Heading: 4, Sections: []Section{}, Letter: &Letter{Number: "12", Sender: "Kant", Recipient: "Lambert", Date: "1765-12-31"}},
+			{Heading: 5, Sections: []Section{}, Reflection: &Reflection{Number: "4275", Dating: "1770-71"}},
+		},
+	}
+
+	out := WorkMetadataToApiModel(in)
+	if !reflect.DeepEqual(out, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, out)
+	}
+}
+
 func TestFootnotesToApiModels(t *testing.T) {
 	in := []model.Content{
 		{Ordinal: 1, Ref: util.StrPtr("ref1"), FmtText: "Footnote text"},
@@ -103,6 +168,37 @@ func TestParagraphsToApiModels(t *testing.T) {
 	}
 }
 
+func TestEmbeddedParagraphsToApiModels(t *testing.T) {
+	summ := model.Content{Ordinal: 1, Ref: util.StrPtr("1.2"), FmtText: "summary"}
+	in := []read.EmbeddedContent{
+		{
+			Content:   model.Content{Ordinal: 2, FmtText: "text", FnRefs: []string{"1.1"}, SummaryRef: util.StrPtr("1.2")},
+			Summary:   &summ,
+			Footnotes: []model.Content{{Ordinal: 3, Ref: util.StrPtr("1.1"), FmtText: "footnote"}},
+		},
+		{
+			Content:   model.Content{Ordinal: 4, FmtText: "text 2"},
+			Footnotes: []model.Content{},
+		},
+	}
+	expected := []EmbeddedParagraph{
+		{
+			Paragraph: models.Paragraph{Ordinal: 2, Text: "text", FnRefs: []string{"1.1"}, SummaryRef: "1.2"},
+			Summary:   &models.Summary{Ordinal: 1, Ref: "1.2", Text: "summary"},
+			Footnotes: []models.Footnote{{Ordinal: 3, Ref: "1.1", Text: "footnote"}},
+		},
+		{
+			Paragraph: models.Paragraph{Ordinal: 4, Text: "text 2"},
+			Footnotes: []models.Footnote{},
+		},
+	}
+
+	out := EmbeddedParagraphsToApiModels(in)
+	if !reflect.DeepEqual(out, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, out)
+	}
+}
+
 func TestSummariesToApiModels(t *testing.T) {
 	in := []model.Content{
 		{Ordinal: 1, Ref: util.StrPtr("ref1"), FmtText: "Summary text", FnRefs: []string{"fn1"}},
@@ -116,3 +212,135 @@ func TestSummariesToApiModels(t *testing.T) {
 		t.Errorf("Expected %+v, got %+v", expected, out)
 	}
 }
+
+func TestWorkTextToApiModel(t *testing.T) {
+	in := read.WorkText{
+		Work: model.Work{Code: "C1", Title: "The Work"},
+		Items: []read.TextItem{
+			{
+				Content: model.Content{Type: model.Heading, Ordinal: 1, FmtText: "Heading text", TocText: util.StrPtr("toc text"), Pages: []int32{34}},
+				Level:   1,
+			},
+			{
+				Content:   model.Content{Type: model.Paragraph, Ordinal: 3, FmtText: "Paragraph text", FnRefs: []string{"fn1"}, SummaryRef: util.StrPtr("s1")},
+				Level:     1,
+				Summary:   &model.Content{Type: model.Summary, Ordinal: 2, Ref: util.StrPtr("s1"), FmtText: "Summary text"},
+				Footnotes: []model.Content{{Type: model.Footnote, Ordinal: 4, Ref: util.StrPtr("fn1"), FmtText: "Footnote text"}},
+			},
+		},
+	}
+	expected := WorkText{
+		Code:  "C1",
+		Title: "The Work",
+		Contents: []TextContent{
+			{
+				Type:      "heading",
+				Level:     1,
+				Heading:   &models.Heading{Ordinal: 1, Text: "Heading text", TocText: "toc text", Pages: []int32{34}},
+				Footnotes: []models.Footnote{},
+			},
+			{
+				Type:      "paragraph",
+				Level:     1,
+				Paragraph: &models.Paragraph{Ordinal: 3, Text: "Paragraph text", FnRefs: []string{"fn1"}, SummaryRef: "s1"},
+				Summary:   &models.Summary{Ordinal: 2, Ref: "s1", Text: "Summary text"},
+				Footnotes: []models.Footnote{{Ordinal: 4, Ref: "fn1", Text: "Footnote text"}},
+			},
+		},
+	}
+
+	out := WorkTextToApiModel(in)
+	if !reflect.DeepEqual(out, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, out)
+	}
+}
+
+func TestPageToApiModel(t *testing.T) {
+	prev := int32(1)
+	in := read.Page{
+		VolumeNumber: 4,
+		Page:         2,
+		Contents: []model.Content{
+			{
+				Type: model.Paragraph, WorkCode: "C1", Ordinal: 3, FmtText: "Paragraph <ks-meta-line>2</ks-meta-line>text", SearchText: "Paragraph text",
+				PageByIndex: []model.IndexNumberPair{}, LineByIndex: []model.IndexNumberPair{{I: 10, Num: 2}}, WordIndexMap: map[int32]int32{0: 0, 10: 40},
+			},
+			{Type: model.Footnote, WorkCode: "C1", Ordinal: 4, FmtText: "Footnote text", SearchText: "Footnote text"},
+		},
+		Prev: &prev,
+	}
+	expected := PageText{
+		VolumeNumber: 4,
+		Page:         2,
+		Contents: []PageContent{
+			{
+				Type: "paragraph", WorkCode: "C1", Ordinal: 3, Text: "Paragraph <ks-meta-line>2</ks-meta-line>text",
+				LineByIndex: []models.IndexNumberPair{{I: 10, Num: 2}}, WordIndexMap: map[string]int32{"0": 0, "10": 40},
+			},
+			{Type: "footnote", WorkCode: "C1", Ordinal: 4, Text: "Footnote text"},
+		},
+		Prev: &prev,
+	}
+
+	out := PageToApiModel(in)
+	if !reflect.DeepEqual(out, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, out)
+	}
+}
+
+func TestCitationToApiModel(t *testing.T) {
+	in := read.ResolvedCitation{
+		WorkCode:   "C1",
+		Ordinals:   []int32{3, 4},
+		LineOffset: &read.LineOffset{Ordinal: 4, Index: 120},
+	}
+	expected := ResolvedCitation{
+		WorkCode:    "C1",
+		Ordinals:    []int32{3, 4},
+		LineOrdinal: util.Int32Ptr(4),
+		LineOffset:  util.Int32Ptr(120),
+	}
+
+	out := CitationToApiModel(in)
+	if !reflect.DeepEqual(out, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, out)
+	}
+}
+
+func TestNavigationToApiModel(t *testing.T) {
+	in := read.Navigation{
+		PrevHeading:   &read.Location{VolumeNumber: 4, WorkCode: "C1", Ordinal: 1},
+		NextParagraph: &read.Location{VolumeNumber: 5, WorkCode: "C2", Ordinal: 2},
+	}
+	expected := Navigation{
+		PrevHeading:   &ContentLocation{VolumeNumber: 4, WorkCode: "C1", Ordinal: 1},
+		NextParagraph: &ContentLocation{VolumeNumber: 5, WorkCode: "C2", Ordinal: 2},
+	}
+
+	out := NavigationToApiModel(in)
+	if !reflect.DeepEqual(out, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, out)
+	}
+}
+
+func TestPersonsToApiModels(t *testing.T) {
+	in := []read.Person{{
+		Name: "Leibniz",
+		Occurrences: []read.Occurrence{
+			{Location: read.Location{VolumeNumber: 4, WorkCode: "C1", Ordinal: 1}, Type: model.Heading},
+			{Location: read.Location{VolumeNumber: 5, WorkCode: "C2", Ordinal: 2}, Type: model.Footnote},
+		},
+	}}
+	expected := []Person{{
+		Name: "Leibniz",
+		Occurrences: []PersonOccurrence{
+			{ContentLocation: ContentLocation{VolumeNumber: 4, WorkCode: "C1", Ordinal: 1}, Type: "heading"},
+			{ContentLocation: ContentLocation{VolumeNumber: 5, WorkCode: "C2", Ordinal: 2}, Type: "footnote"},
+		},
+	}}
+
+	out := PersonsToApiModels(in)
+	if !reflect.DeepEqual(out, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, out)
+	}
+}
@@ -0,0 +1,137 @@
+package mapping
+
+import "github.com/frhorschig/kant-search-api/generated/go/models"
+
+// The following types are not (yet) part of the generated API models.
+
+// VolumeMetadata contains the works of a volume without their section trees
+type VolumeMetadata struct {
+	VolumeNumber int32          `json:"volumeNumber"`
+	Title        string         `json:"title"`
+	Works        []WorkMetadata `json:"works"`
+	Counts       ContentCounts  `json:"counts"` // sum of the counts of the works
+}
+
+type WorkMetadata struct {
+	Ordinal int32         `json:"ordinal"`
+	Code    string        `json:"code"`
+	Siglum  string        `json:"siglum"`
+	Title   string        `json:"title"`
+	Year    string        `json:"year"`
+	Counts  ContentCounts `json:"counts"`
+}
+
+// WorkDetails is the metadata of a work with its volume number and section tree
+type WorkDetails struct {
+	WorkMetadata
+	VolumeNumber int32     `json:"volumeNumber"`
+	Paragraphs   []int32   `json:"paragraphs"`
+	Sections     []Section `json:"sections"`
+}
+
+// Section is models.Section with the metadata of letters and Reflexionen
+type Section struct {
+	Heading    int32       `json:"heading"`
+	Paragraphs []int32     `json:"paragraphs"`
+	Sections   []Section   `json:"sections"`
+	Letter     *Letter     `json:"letter,omitempty"`
+	Reflection *Reflection `json:"reflection,omitempty"`
+}
+
+type Letter struct {
+	Number    string `json:"number"`
+	Sender    string `json:"sender"`
+	Recipient string `json:"recipient"`
+	Date      string `json:"date"`
+}
+
+type Reflection struct {
+	Number string `json:"number"`
+	Dating string `json:"dating"`
+}
+
+type ContentCounts struct {
+	Headings   int32 `json:"headings"`
+	Paragraphs int32 `json:"paragraphs"`
+	Footnotes  int32 `json:"footnotes"`
+	Summaries  int32 `json:"summaries"`
+}
+
+// EmbeddedParagraph is a paragraph with the summary and footnotes it references
+type EmbeddedParagraph struct {
+	models.Paragraph
+	Summary   *models.Summary   `json:"summary,omitempty"`
+	Footnotes []models.Footnote `json:"footnotes,omitempty"` // footnotes of the summary and of the paragraph itself
+}
+
+// EmbeddedHeading is a heading with the footnotes it references
+type EmbeddedHeading struct {
+	models.Heading
+	Footnotes []models.Footnote `json:"footnotes,omitempty"`
+}
+
+type WorkText struct {
+	Code     string        `json:"code"`
+	Title    string        `json:"title"`
+	Contents []TextContent `json:"contents"`
+}
+
+// TextContent is either a heading or a paragraph, depending on the type
+type TextContent struct {
+	Type      string            `json:"type"`
+	Level     int32             `json:"level"`
+	Heading   *models.Heading   `json:"heading,omitempty"`
+	Paragraph *models.Paragraph `json:"paragraph,omitempty"`
+	Summary   *models.Summary   `json:"summary,omitempty"`
+	Footnotes []models.Footnote `json:"footnotes"`
+}
+
+type PageText struct {
+	VolumeNumber int32         `json:"volumeNumber"`
+	Page         int32         `json:"page"`
+	Contents     []PageContent `json:"contents"`
+	Prev         *int32        `json:"prev,omitempty"`
+	Next         *int32        `json:"next,omitempty"`
+}
+
+// PageContent is the part of a heading, paragraph, footnote or summary that is on the page; the indices of the maps are indices of the text of the page
+type PageContent struct {
+	Type         string                   `json:"type"`
+	WorkCode     string                   `json:"workCode"`
+	Ordinal      int32                    `json:"ordinal"`
+	Text         string                   `json:"text"`
+	PageByIndex  []models.IndexNumberPair `json:"pageByIndex,omitempty"`
+	LineByIndex  []models.IndexNumberPair `json:"lineByIndex,omitempty"`
+	WordIndexMap map[string]int32         `json:"wordIndexMap,omitempty"`
+}
+
+// Navigation contains the locations of the headings and paragraphs before and after a content, they are missing at the beginning and the end of all works
+type Navigation struct {
+	PrevHeading   *ContentLocation `json:"prevHeading,omitempty"`
+	PrevParagraph *ContentLocation `json:"prevParagraph,omitempty"`
+	NextHeading   *ContentLocation `json:"nextHeading,omitempty"`
+	NextParagraph *ContentLocation `json:"nextParagraph,omitempty"`
+}
+
+type ContentLocation struct {
+	VolumeNumber int32  `json:"volumeNumber"`
+	WorkCode     string `json:"workCode"`
+	Ordinal      int32  `json:"ordinal"`
+}
+
+type Person struct {
+	Name        string             `json:"name"`
+	Occurrences []PersonOccurrence `json:"occurrences"`
+}
+
+type PersonOccurrence struct {
+	ContentLocation
+	Type string `json:"type"`
+}
+
+type ResolvedCitation struct {
+	WorkCode    string  `json:"workCode"`
+	Ordinals    []int32 `json:"ordinals"`
+	LineOrdinal *int32  `json:"lineOrdinal,omitempty"` // ordinal of the content with the cited line
+	LineOffset  *int32  `json:"lineOffset,omitempty"`  // text index (in runes) of the cited line in this content
+}
@@ -2,29 +2,69 @@ package read
 
 import (
 	"fmt"
+	"hash/fnv"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/frhorschig/kant-search-api/generated/go/models"
 	"github.com/frhorschig/kant-search-backend/api/read/internal/errors"
 	"github.com/frhorschig/kant-search-backend/api/read/internal/mapping"
+	"github.com/frhorschig/kant-search-backend/common/render"
 	"github.com/frhorschig/kant-search-backend/core/read"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog/log"
 )
 
 const (
-	emptyCodeMsg      = "empty work code"
-	invalidOrdinalMsg = "invalid ordinal values: %v"
+	emptyCodeMsg         = "empty work code"
+	invalidOrdinalMsg    = "invalid ordinal values: %v"
+	invalidRangeMsg      = "invalid ordinal range: from=%v, to=%v"
+	invalidPageMsg       = "invalid volume number or page: %v, %v"
+	invalidVolumeMsg     = "invalid volume number: %v"
+	emptyCitationMsg     = "empty citation"
+	invalidPaginationMsg = "invalid cursor or limit: %v, %v"
+	invalidFormatMsg     = "invalid format options: %v"
+	invalidEmbedMsg      = "invalid embed values: %v"
+	invalidNavOrdinalMsg = "invalid ordinal: %v"
+)
+
+// NextCursorHeader is set on paginated responses if there is a next page; its value is passed as the cursor query parameter to read the next page
+const NextCursorHeader = "X-Next-Cursor"
+
+// ETagHeader is set on responses that can be cached, the client sends its value in the If-None-Match header to revalidate the cached response
+const ETagHeader = "ETag"
+
+const (
+	ifNoneMatchHeader = "If-None-Match"
+	// cached responses must be revalidated, because the texts change when a volume is uploaded again
+	cacheControl = "public, no-cache"
+)
+
+// maxLimit is the maximum number of contents of a paginated response; without a limit all contents after the cursor are returned in one response
+const maxLimit = 5000
+
+// the persons are always paginated, because the occurrences of all persons are too large for a single response
+const (
+	defaultPersonLimit = 100
+	maxPersonLimit     = 1000
 )
 
 type ReadHandler interface {
 	ReadVolumes(ctx echo.Context) error
+	ReadVolume(ctx echo.Context) error
+	ReadWork(ctx echo.Context) error
 	ReadFootnotes(ctx echo.Context) error
 	ReadHeadings(ctx echo.Context) error
 	ReadParagraphs(ctx echo.Context) error
 	ReadSummaries(ctx echo.Context) error
+	ReadWorkText(ctx echo.Context) error
+	ReadPage(ctx echo.Context) error
+	ResolveCitation(ctx echo.Context) error
+	ReadNavigation(ctx echo.Context) error
+	ReadPersons(ctx echo.Context) error
 }
 
 type readHandlerImpl struct {
@@ -36,6 +76,13 @@ func NewReadHandler(readProcessor read.ReadProcessor) ReadHandler {
 }
 
 func (rec *readHandlerImpl) ReadVolumes(ctx echo.Context) error {
+	if notModified, err := rec.dataNotModified(ctx); err != nil {
+		log.Error().Err(err).Msgf("error reading version of volumes: %v", err)
+		return errors.InternalServerError(ctx)
+	} else if notModified {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
 	volumes, err := rec.readProcessor.ProcessVolumes(ctx.Request().Context())
 	if err != nil {
 		log.Error().Err(err).Msgf("error reading volumes: %v", err)
@@ -49,6 +96,58 @@ func (rec *readHandlerImpl) ReadVolumes(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, apiVolumes)
 }
 
+func (rec *readHandlerImpl) ReadVolume(ctx echo.Context) error {
+	volParam := ctx.Param("volumeNumber")
+	volumeNumber, err := findPositiveNumber(volParam)
+	if err != nil {
+		msg := fmt.Sprintf(invalidVolumeMsg, volParam)
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+
+	if notModified, err := rec.volumeNotModified(ctx, volumeNumber); err != nil {
+		log.Error().Err(err).Msgf("error reading version of volume %d: %v", volumeNumber, err)
+		return errors.InternalServerError(ctx)
+	} else if notModified {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	volume, err := rec.readProcessor.ProcessVolume(ctx.Request().Context(), volumeNumber)
+	if err != nil {
+		log.Error().Err(err).Msgf("error reading volume %d: %v", volumeNumber, err)
+		return errors.InternalServerError(ctx)
+	}
+	if volume == nil {
+		return errors.NotFound(ctx)
+	}
+	return ctx.JSON(http.StatusOK, mapping.VolumeMetadataToApiModel(*volume))
+}
+
+func (rec *readHandlerImpl) ReadWork(ctx echo.Context) error {
+	workCode := ctx.Param("workCode")
+	if workCode == "" {
+		log.Error().Msg(emptyCodeMsg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, emptyCodeMsg)
+	}
+
+	if notModified, err := rec.workNotModified(ctx, workCode); err != nil {
+		log.Error().Err(err).Msgf("error reading version of work %s: %v", workCode, err)
+		return errors.InternalServerError(ctx)
+	} else if notModified {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	work, err := rec.readProcessor.ProcessWork(ctx.Request().Context(), workCode)
+	if err != nil {
+		log.Error().Err(err).Msgf("error reading work %s: %v", workCode, err)
+		return errors.InternalServerError(ctx)
+	}
+	if work == nil {
+		return errors.NotFound(ctx)
+	}
+	return ctx.JSON(http.StatusOK, mapping.WorkMetadataToApiModel(*work))
+}
+
 func (rec *readHandlerImpl) ReadFootnotes(ctx echo.Context) error {
 	workCode := ctx.Param("workCode")
 	if workCode == "" {
@@ -63,13 +162,34 @@ func (rec *readHandlerImpl) ReadFootnotes(ctx echo.Context) error {
 		log.Error().Err(err).Msg(msg)
 		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
 	}
-	footnotes, err := rec.readProcessor.ProcessFootnotes(ctx.Request().Context(), workCode, ordinals)
+	cursor, limit, err := findPagination(ctx)
+	if err != nil {
+		msg := fmt.Sprintf(invalidPaginationMsg, ctx.QueryParam("cursor"), ctx.QueryParam("limit"))
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+	renderOpts, err := render.ParseOptions(ctx.QueryParams())
+	if err != nil {
+		msg := fmt.Sprintf(invalidFormatMsg, ctx.QueryParam("format"))
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+	if notModified, err := rec.workNotModified(ctx, workCode); err != nil {
+		log.Error().Err(err).Msgf("error reading version of work %s: %v", workCode, err)
+		return errors.InternalServerError(ctx)
+	} else if notModified {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	footnotes, err := rec.readProcessor.ProcessFootnotes(ctx.Request().Context(), workCode, ordinals, cursor, limit)
 	if err != nil {
 		log.Error().Err(err).Msgf("error reading footnotes: %v", err)
 		return errors.InternalServerError(ctx)
 	}
 
-	apiFootnotes := mapping.FootnotesToApiModels(footnotes)
+	setNextCursor(ctx, footnotes.NextCursor)
+	renderContents(footnotes.Contents, renderOpts)
+	apiFootnotes := mapping.FootnotesToApiModels(footnotes.Contents)
 	return ctx.JSON(http.StatusOK, apiFootnotes)
 }
 
@@ -87,13 +207,49 @@ func (rec *readHandlerImpl) ReadHeadings(ctx echo.Context) error {
 		log.Error().Err(err).Msg(msg)
 		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
 	}
-	headings, err := rec.readProcessor.ProcessHeadings(ctx.Request().Context(), workCode, ordinals)
+	cursor, limit, err := findPagination(ctx)
+	if err != nil {
+		msg := fmt.Sprintf(invalidPaginationMsg, ctx.QueryParam("cursor"), ctx.QueryParam("limit"))
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+	renderOpts, err := render.ParseOptions(ctx.QueryParams())
+	if err != nil {
+		msg := fmt.Sprintf(invalidFormatMsg, ctx.QueryParam("format"))
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+	embed, err := findEmbed(ctx.QueryParam("embed"))
+	if err != nil {
+		msg := fmt.Sprintf(invalidEmbedMsg, ctx.QueryParam("embed"))
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+	if notModified, err := rec.workNotModified(ctx, workCode); err != nil {
+		log.Error().Err(err).Msgf("error reading version of work %s: %v", workCode, err)
+		return errors.InternalServerError(ctx)
+	} else if notModified {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	headings, err := rec.readProcessor.ProcessHeadings(ctx.Request().Context(), workCode, ordinals, cursor, limit)
 	if err != nil {
 		log.Error().Err(err).Msgf("error reading headings: %v", err)
 		return errors.InternalServerError(ctx)
 	}
 
-	apiHeadings := mapping.HeadingsToApiModels(headings)
+	setNextCursor(ctx, headings.NextCursor)
+	if embed != nil {
+		embedded, err := rec.readProcessor.ProcessEmbedded(ctx.Request().Context(), workCode, headings.Contents, *embed)
+		if err != nil {
+			log.Error().Err(err).Msgf("error reading embedded contents of headings: %v", err)
+			return errors.InternalServerError(ctx)
+		}
+		renderEmbedded(embedded, renderOpts)
+		return ctx.JSON(http.StatusOK, mapping.EmbeddedHeadingsToApiModels(embedded))
+	}
+	renderContents(headings.Contents, renderOpts)
+	apiHeadings := mapping.HeadingsToApiModels(headings.Contents)
 	return ctx.JSON(http.StatusOK, apiHeadings)
 }
 
@@ -111,13 +267,49 @@ func (rec *readHandlerImpl) ReadParagraphs(ctx echo.Context) error {
 		log.Error().Err(err).Msg(msg)
 		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
 	}
-	paragraphs, err := rec.readProcessor.ProcessParagraphs(ctx.Request().Context(), workCode, ordinals)
+	cursor, limit, err := findPagination(ctx)
+	if err != nil {
+		msg := fmt.Sprintf(invalidPaginationMsg, ctx.QueryParam("cursor"), ctx.QueryParam("limit"))
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+	renderOpts, err := render.ParseOptions(ctx.QueryParams())
+	if err != nil {
+		msg := fmt.Sprintf(invalidFormatMsg, ctx.QueryParam("format"))
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+	embed, err := findEmbed(ctx.QueryParam("embed"))
+	if err != nil {
+		msg := fmt.Sprintf(invalidEmbedMsg, ctx.QueryParam("embed"))
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+	if notModified, err := rec.workNotModified(ctx, workCode); err != nil {
+		log.Error().Err(err).Msgf("error reading version of work %s: %v", workCode, err)
+		return errors.InternalServerError(ctx)
+	} else if notModified {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	paragraphs, err := rec.readProcessor.ProcessParagraphs(ctx.Request().Context(), workCode, ordinals, cursor, limit)
 	if err != nil {
 		log.Error().Err(err).Msgf("error reading paragraphs: %v", err)
 		return errors.InternalServerError(ctx)
 	}
 
-	apiParagraphs := mapping.ParagraphsToApiModels(paragraphs)
+	setNextCursor(ctx, paragraphs.NextCursor)
+	if embed != nil {
+		embedded, err := rec.readProcessor.ProcessEmbedded(ctx.Request().Context(), workCode, paragraphs.Contents, *embed)
+		if err != nil {
+			log.Error().Err(err).Msgf("error reading embedded contents of paragraphs: %v", err)
+			return errors.InternalServerError(ctx)
+		}
+		renderEmbedded(embedded, renderOpts)
+		return ctx.JSON(http.StatusOK, mapping.EmbeddedParagraphsToApiModels(embedded))
+	}
+	renderContents(paragraphs.Contents, renderOpts)
+	apiParagraphs := mapping.ParagraphsToApiModels(paragraphs.Contents)
 	return ctx.JSON(http.StatusOK, apiParagraphs)
 }
 
@@ -135,29 +327,445 @@ func (rec *readHandlerImpl) ReadSummaries(ctx echo.Context) error {
 		log.Error().Err(err).Msg(msg)
 		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
 	}
-	summaries, err := rec.readProcessor.ProcessSummaries(ctx.Request().Context(), workCode, ordinals)
+	cursor, limit, err := findPagination(ctx)
+	if err != nil {
+		msg := fmt.Sprintf(invalidPaginationMsg, ctx.QueryParam("cursor"), ctx.QueryParam("limit"))
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+	renderOpts, err := render.ParseOptions(ctx.QueryParams())
+	if err != nil {
+		msg := fmt.Sprintf(invalidFormatMsg, ctx.QueryParam("format"))
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+	if notModified, err := rec.workNotModified(ctx, workCode); err != nil {
+		log.Error().Err(err).Msgf("error reading version of work %s: %v", workCode, err)
+		return errors.InternalServerError(ctx)
+	} else if notModified {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	summaries, err := rec.readProcessor.ProcessSummaries(ctx.Request().Context(), workCode, ordinals, cursor, limit)
 	if err != nil {
 		log.Error().Err(err).Msgf("error reading summaries: %v", err)
 		return errors.InternalServerError(ctx)
 	}
 
-	apiSummaries := mapping.SummariesToApiModels(summaries)
+	setNextCursor(ctx, summaries.NextCursor)
+	renderContents(summaries.Contents, renderOpts)
+	apiSummaries := mapping.SummariesToApiModels(summaries.Contents)
 	return ctx.JSON(http.StatusOK, apiSummaries)
 }
 
-func findOrdinals(ordsParam string) ([]int32, error) {
-	ords := []int32{}
+func (rec *readHandlerImpl) ReadWorkText(ctx echo.Context) error {
+	workCode := ctx.Param("workCode")
+	if workCode == "" {
+		log.Error().Msg(emptyCodeMsg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, emptyCodeMsg)
+	}
+
+	fromParam := ctx.QueryParam("from")
+	toParam := ctx.QueryParam("to")
+	from, to, err := findOrdinalRange(fromParam, toParam)
+	if err != nil {
+		msg := fmt.Sprintf(invalidRangeMsg, fromParam, toParam)
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+	renderOpts, err := render.ParseOptions(ctx.QueryParams())
+	if err != nil {
+		msg := fmt.Sprintf(invalidFormatMsg, ctx.QueryParam("format"))
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+	if notModified, err := rec.workNotModified(ctx, workCode); err != nil {
+		log.Error().Err(err).Msgf("error reading version of work %s: %v", workCode, err)
+		return errors.InternalServerError(ctx)
+	} else if notModified {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	text, err := rec.readProcessor.ProcessWorkText(ctx.Request().Context(), workCode, from, to)
+	if err != nil {
+		log.Error().Err(err).Msgf("error reading work text: %v", err)
+		return errors.InternalServerError(ctx)
+	}
+	if text == nil {
+		return errors.NotFound(ctx)
+	}
+
+	renderWorkText(text, renderOpts)
+	apiText := mapping.WorkTextToApiModel(*text)
+	return ctx.JSON(http.StatusOK, apiText)
+}
+
+func (rec *readHandlerImpl) ReadPage(ctx echo.Context) error {
+	volParam := ctx.Param("volumeNumber")
+	pageParam := ctx.Param("page")
+	volumeNumber, page, err := findVolumePage(volParam, pageParam)
+	if err != nil {
+		msg := fmt.Sprintf(invalidPageMsg, volParam, pageParam)
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+	renderOpts, err := render.ParseOptions(ctx.QueryParams())
+	if err != nil {
+		msg := fmt.Sprintf(invalidFormatMsg, ctx.QueryParam("format"))
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+
+	if notModified, err := rec.volumeNotModified(ctx, volumeNumber); err != nil {
+		log.Error().Err(err).Msgf("error reading version of volume %d: %v", volumeNumber, err)
+		return errors.InternalServerError(ctx)
+	} else if notModified {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	result, err := rec.readProcessor.ProcessPage(ctx.Request().Context(), volumeNumber, page)
+	if err != nil {
+		log.Error().Err(err).Msgf("error reading page: %v", err)
+		return errors.InternalServerError(ctx)
+	}
+	if result == nil {
+		return errors.NotFound(ctx)
+	}
+
+	renderContents(result.Contents, renderOpts)
+	apiPage := mapping.PageToApiModel(*result)
+	return ctx.JSON(http.StatusOK, apiPage)
+}
+
+func (rec *readHandlerImpl) ResolveCitation(ctx echo.Context) error {
+	citation := ctx.QueryParam("citation")
+	if strings.TrimSpace(citation) == "" {
+		log.Error().Msg(emptyCitationMsg)
+		return errors.BadRequest(ctx, errors.BadRequestInvalidCitation, emptyCitationMsg)
+	}
+
+	if notModified, err := rec.dataNotModified(ctx); err != nil {
+		log.Error().Err(err).Msgf("error reading version of volumes: %v", err)
+		return errors.InternalServerError(ctx)
+	} else if notModified {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	resolved, err := rec.readProcessor.ProcessCitation(ctx.Request().Context(), citation)
+	if citErr, ok := err.(*read.CitationError); ok {
+		log.Error().Err(err).Msgf("invalid citation '%s'", citation)
+		return errors.BadRequest(ctx, errors.BadRequestInvalidCitation, citErr.Msg)
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("error resolving citation: %v", err)
+		return errors.InternalServerError(ctx)
+	}
+	if resolved == nil {
+		return errors.NotFound(ctx)
+	}
+
+	apiCitation := mapping.CitationToApiModel(*resolved)
+	return ctx.JSON(http.StatusOK, apiCitation)
+}
+
+func (rec *readHandlerImpl) ReadNavigation(ctx echo.Context) error {
+	workCode := ctx.Param("workCode")
+	if workCode == "" {
+		log.Error().Msg(emptyCodeMsg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, emptyCodeMsg)
+	}
+	ordParam := ctx.Param("ordinal")
+	ordinal, err := findPositiveNumber(ordParam)
+	if err != nil {
+		msg := fmt.Sprintf(invalidNavOrdinalMsg, ordParam)
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+
+	// the navigation crosses the boundaries of works and volumes, so it depends on the data of all volumes
+	if notModified, err := rec.dataNotModified(ctx); err != nil {
+		log.Error().Err(err).Msgf("error reading version of volumes: %v", err)
+		return errors.InternalServerError(ctx)
+	} else if notModified {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	nav, err := rec.readProcessor.ProcessNavigation(ctx.Request().Context(), workCode, ordinal)
+	if err != nil {
+		log.Error().Err(err).Msgf("error reading navigation of work %s: %v", workCode, err)
+		return errors.InternalServerError(ctx)
+	}
+	if nav == nil {
+		return errors.NotFound(ctx)
+	}
+	return ctx.JSON(http.StatusOK, mapping.NavigationToApiModel(*nav))
+}
+
+func (rec *readHandlerImpl) ReadPersons(ctx echo.Context) error {
+	cursor, limit, err := findPersonPagination(ctx)
+	if err != nil {
+		msg := fmt.Sprintf(invalidPaginationMsg, ctx.QueryParam("cursor"), ctx.QueryParam("limit"))
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+
+	if notModified, err := rec.dataNotModified(ctx); err != nil {
+		log.Error().Err(err).Msgf("error reading version of volumes: %v", err)
+		return errors.InternalServerError(ctx)
+	} else if notModified {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	persons, err := rec.readProcessor.ProcessPersons(ctx.Request().Context(), cursor, limit)
+	if err != nil {
+		log.Error().Err(err).Msgf("error reading persons: %v", err)
+		return errors.InternalServerError(ctx)
+	}
+	if persons.NextCursor != nil {
+		// names may contain non-ASCII characters, which are not allowed in header values
+		ctx.Response().Header().Set(NextCursorHeader, url.QueryEscape(*persons.NextCursor))
+	}
+	return ctx.JSON(http.StatusOK, mapping.PersonsToApiModels(persons.Persons))
+}
+
+func (rec *readHandlerImpl) dataNotModified(ctx echo.Context) (bool, error) {
+	version, err := rec.readProcessor.ProcessVersion(ctx.Request().Context())
+	if err != nil {
+		return false, err
+	}
+	return checkETag(ctx, &version), nil
+}
+
+func (rec *readHandlerImpl) volumeNotModified(ctx echo.Context, volumeNumber int32) (bool, error) {
+	version, err := rec.readProcessor.ProcessVolumeVersion(ctx.Request().Context(), volumeNumber)
+	if err != nil {
+		return false, err
+	}
+	return checkETag(ctx, version), nil
+}
+
+func (rec *readHandlerImpl) workNotModified(ctx echo.Context, workCode string) (bool, error) {
+	version, err := rec.readProcessor.ProcessWorkVersion(ctx.Request().Context(), workCode)
+	if err != nil {
+		return false, err
+	}
+	return checkETag(ctx, version), nil
+}
+
+// checkETag sets the caching headers of a response that is based on data with the given version and returns true if the response cached by the client is still valid; responses based on data without a version are not cached
+func checkETag(ctx echo.Context, version *string) bool {
+	if version == nil || *version == "" {
+		return false
+	}
+	// the same data is returned in different shapes depending on the path and query parameters
+	hash := fnv.New64a()
+	hash.Write([]byte(ctx.Request().URL.RequestURI()))
+	// weak, because the compression middleware may change the encoding of the response
+	etag := fmt.Sprintf(`W/"%s-%x"`, *version, hash.Sum64())
+
+	header := ctx.Response().Header()
+	header.Set(echo.HeaderCacheControl, cacheControl)
+	header.Set(ETagHeader, etag)
+	for _, candidate := range strings.Split(ctx.Request().Header.Get(ifNoneMatchHeader), ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+func findVolumePage(volParam string, pageParam string) (int32, int32, error) {
+	volumeNumber, err := findPositiveNumber(volParam)
+	if err != nil {
+		return 0, 0, err
+	}
+	page, err := findPositiveNumber(pageParam)
+	if err != nil {
+		return 0, 0, err
+	}
+	return volumeNumber, page, nil
+}
+
+func findPositiveNumber(param string) (int32, error) {
+	num, err := strconv.ParseInt(param, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if num < 1 {
+		return 0, fmt.Errorf("%d is not a positive number", num)
+	}
+	return int32(num), nil
+}
+
+func findOrdinalRange(fromParam string, toParam string) (*int32, *int32, error) {
+	from, err := findOptionalOrdinal(fromParam)
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := findOptionalOrdinal(toParam)
+	if err != nil {
+		return nil, nil, err
+	}
+	if from != nil && to != nil && *from > *to {
+		return nil, nil, fmt.Errorf("lower bound %d is greater than upper bound %d", *from, *to)
+	}
+	return from, to, nil
+}
+
+func findOptionalOrdinal(param string) (*int32, error) {
+	param = strings.TrimSpace(param)
+	if param == "" {
+		return nil, nil
+	}
+	ord, err := strconv.ParseInt(param, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	result := int32(ord)
+	return &result, nil
+}
+
+// findOrdinals parses comma separated single ordinals and inclusive ranges, e.g. "1,5,10-50,200-"; a range without a lower or upper bound is open on this side
+func findOrdinals(ordsParam string) ([]model.OrdinalRange, error) {
+	ords := []model.OrdinalRange{}
 	parts := strings.Split(ordsParam, ",")
 	for _, part := range parts {
 		ordStr := strings.TrimSpace(part)
 		if ordStr == "" {
 			continue
 		}
-		ord, err := strconv.ParseInt(ordStr, 10, 32)
+		fromStr, toStr, isRange := strings.Cut(ordStr, "-")
+		if !isRange {
+			ord, err := findOptionalOrdinal(ordStr)
+			if err != nil {
+				return nil, err
+			}
+			ords = append(ords, model.OrdinalRange{From: ord, To: ord})
+			continue
+		}
+		if strings.TrimSpace(fromStr) == "" && strings.TrimSpace(toStr) == "" {
+			return nil, fmt.Errorf("range without bounds: %s", ordStr)
+		}
+		from, err := findOptionalOrdinal(fromStr)
+		if err != nil {
+			return nil, err
+		}
+		to, err := findOptionalOrdinal(toStr)
 		if err != nil {
 			return nil, err
 		}
-		ords = append(ords, int32(ord))
+		if from != nil && to != nil && *from > *to {
+			return nil, fmt.Errorf("range with lower bound greater than upper bound: %s", ordStr)
+		}
+		ords = append(ords, model.OrdinalRange{From: from, To: to})
 	}
 	return ords, nil
 }
+
+func findPagination(ctx echo.Context) (*int32, int, error) {
+	cursor, err := findOptionalOrdinal(ctx.QueryParam("cursor"))
+	if err != nil {
+		return nil, 0, err
+	}
+	limitParam := strings.TrimSpace(ctx.QueryParam("limit"))
+	if limitParam == "" {
+		return cursor, read.NoLimit, nil
+	}
+	limit, err := strconv.Atoi(limitParam)
+	if err != nil {
+		return nil, 0, err
+	}
+	if limit < 1 || limit > maxLimit {
+		return nil, 0, fmt.Errorf("limit %d is not between 1 and %d", limit, maxLimit)
+	}
+	return cursor, limit, nil
+}
+
+// findPersonPagination returns the name after which the persons start (nil for the first page) and the number of persons, which defaults to defaultPersonLimit
+func findPersonPagination(ctx echo.Context) (*string, int, error) {
+	var cursor *string
+	if c := strings.TrimSpace(ctx.QueryParam("cursor")); c != "" {
+		cursor = &c
+	}
+	limitParam := strings.TrimSpace(ctx.QueryParam("limit"))
+	if limitParam == "" {
+		return cursor, defaultPersonLimit, nil
+	}
+	limit, err := strconv.Atoi(limitParam)
+	if err != nil {
+		return nil, 0, err
+	}
+	if limit < 1 || limit > maxPersonLimit {
+		return nil, 0, fmt.Errorf("limit %d is not between 1 and %d", limit, maxPersonLimit)
+	}
+	return cursor, limit, nil
+}
+
+func setNextCursor(ctx echo.Context, nextCursor *int32) {
+	if nextCursor != nil {
+		ctx.Response().Header().Set(NextCursorHeader, strconv.FormatInt(int64(*nextCursor), 10))
+	}
+}
+
+// findEmbed returns nil if nothing is embedded; the values are comma separated, e.g. "footnotes,summaries"
+func findEmbed(embedParam string) (*read.Embed, error) {
+	if strings.TrimSpace(embedParam) == "" {
+		return nil, nil
+	}
+	embed := read.Embed{}
+	for _, value := range strings.Split(embedParam, ",") {
+		switch strings.TrimSpace(value) {
+		case "footnotes":
+			embed.Footnotes = true
+		case "summaries":
+			embed.Summaries = true
+		default:
+			return nil, fmt.Errorf("unknown embed value '%s'", value)
+		}
+	}
+	return &embed, nil
+}
+
+// renderContents converts the formatted texts to the requested format, it does nothing if no format is requested; the index based fields (e.g. the word index map) are removed from converted contents, because they only refer to the unconverted texts
+func renderContents(contents []model.Content, opts *render.Options) {
+	for i := range contents {
+		renderContent(&contents[i], opts)
+	}
+}
+
+func renderContent(c *model.Content, opts *render.Options) {
+	if opts != nil {
+		c.FmtText = render.Render(c.FmtText, *opts)
+		c.PageByIndex = nil
+		c.LineByIndex = nil
+		c.WordIndexMap = nil
+	}
+}
+
+func renderEmbedded(embedded []read.EmbeddedContent, opts *render.Options) {
+	for i := range embedded {
+		item := &embedded[i]
+		renderContent(&item.Content, opts)
+		renderContents(item.Footnotes, opts)
+		if item.Summary != nil {
+			summary := *item.Summary
+			renderContent(&summary, opts)
+			item.Summary = &summary
+		}
+	}
+}
+
+func renderWorkText(text *read.WorkText, opts *render.Options) {
+	for i := range text.Items {
+		item := &text.Items[i]
+		renderContent(&item.Content, opts)
+		renderContents(item.Footnotes, opts)
+		if item.Summary != nil {
+			// the summary is copied, because it may be shared with other items
+			summary := *item.Summary
+			renderContent(&summary, opts)
+			item.Summary = &summary
+		}
+	}
+}
@@ -7,9 +7,11 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/frhorschig/kant-search-backend/common/util"
+	coreread "github.com/frhorschig/kant-search-backend/core/read"
 	"github.com/frhorschig/kant-search-backend/core/read/mocks"
 	"github.com/frhorschig/kant-search-backend/dataaccess/model"
 	"github.com/golang/mock/gomock"
@@ -24,23 +26,66 @@ func TestReadHandler(t *testing.T) {
 	sut := &readHandlerImpl{
 		readProcessor: readProcessor,
 	}
+	// the caching is tested separately
+	readProcessor.EXPECT().ProcessVersion(gomock.Any()).Return("", nil).AnyTimes()
+	readProcessor.EXPECT().ProcessVolumeVersion(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	readProcessor.EXPECT().ProcessWorkVersion(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 
 	for scenario, fn := range map[string]func(*testing.T, *readHandlerImpl, *mocks.MockReadProcessor){
-		"Read volumes":                    testReadVolumes,
-		"Read volumes with error":         testReadVolumesError,
-		"Read footnotes":                  testReadFootnotes,
-		"Read footnotes with empty code":  testReadFootnotesEmptyCode,
-		"Read footnotes with error":       testReadFootnotesError,
-		"Read headings":                   testReadHeadings,
-		"Read headings with empty code":   testReadHeadingsEmptyCode,
-		"Read headings with error":        testReadHeadingsError,
-		"Read paragraphs":                 testReadParagraphs,
-		"Read paragraphs with ordinals":   testReadParagraphsWithOrdinals,
-		"Read paragraphs with empty code": testReadParagraphsEmptyCode,
-		"Read paragraphs with error":      testReadParagraphsError,
-		"Read summaries":                  testReadSummaries,
-		"Read summaries with empty code":  testReadSummariesEmptyCode,
-		"Read summaries with error":       testReadSummariesError,
+		"Read volumes":                     testReadVolumes,
+		"Read volumes with error":          testReadVolumesError,
+		"Read volume":                      testReadVolume,
+		"Read volume with bad number":      testReadVolumeBadNumber,
+		"Read volume not found":            testReadVolumeNotFound,
+		"Read volume with error":           testReadVolumeError,
+		"Read work":                        testReadWork,
+		"Read work with empty code":        testReadWorkEmptyCode,
+		"Read work not found":              testReadWorkNotFound,
+		"Read work with error":             testReadWorkError,
+		"Read footnotes":                   testReadFootnotes,
+		"Read footnotes with empty code":   testReadFootnotesEmptyCode,
+		"Read footnotes with error":        testReadFootnotesError,
+		"Read headings":                    testReadHeadings,
+		"Read headings with empty code":    testReadHeadingsEmptyCode,
+		"Read headings with error":         testReadHeadingsError,
+		"Read paragraphs":                  testReadParagraphs,
+		"Read paragraphs with ordinals":    testReadParagraphsWithOrdinals,
+		"Read paragraphs with format":      testReadParagraphsWithFormat,
+		"Read paragraphs with bad format":  testReadParagraphsBadFormat,
+		"Read paragraphs with embed":       testReadParagraphsWithEmbed,
+		"Read paragraphs with bad embed":   testReadParagraphsBadEmbed,
+		"Read headings with embed":         testReadHeadingsWithEmbed,
+		"Read paragraphs with bad range":   testReadParagraphsBadRange,
+		"Read paragraphs with bad limit":   testReadParagraphsBadLimit,
+		"Read paragraphs without limit":    testReadParagraphsWithoutLimit,
+		"Read paragraphs with empty code":  testReadParagraphsEmptyCode,
+		"Read paragraphs with error":       testReadParagraphsError,
+		"Read summaries":                   testReadSummaries,
+		"Read summaries with empty code":   testReadSummariesEmptyCode,
+		"Read summaries with error":        testReadSummariesError,
+		"Read work text":                   testReadWorkText,
+		"Read work text with range":        testReadWorkTextWithRange,
+		"Read work text with bad range":    testReadWorkTextBadRange,
+		"Read work text not found":         testReadWorkTextNotFound,
+		"Read work text with error":        testReadWorkTextError,
+		"Read page":                        testReadPage,
+		"Read page with format":            testReadPageWithFormat,
+		"Read page with bad params":        testReadPageBadParams,
+		"Read page not found":              testReadPageNotFound,
+		"Read page with error":             testReadPageError,
+		"Resolve citation":                 testResolveCitation,
+		"Resolve empty citation":           testResolveEmptyCitation,
+		"Resolve invalid citation":         testResolveInvalidCitation,
+		"Resolve unknown citation":         testResolveUnknownCitation,
+		"Resolve citation with error":      testResolveCitationError,
+		"Read navigation":                  testReadNavigation,
+		"Read navigation with bad ordinal": testReadNavigationBadOrdinal,
+		"Read navigation not found":        testReadNavigationNotFound,
+		"Read navigation with error":       testReadNavigationError,
+		"Read persons":                     testReadPersons,
+		"Read persons with cursor":         testReadPersonsWithCursor,
+		"Read persons with bad limit":      testReadPersonsBadLimit,
+		"Read persons with error":          testReadPersonsError,
 	} {
 		t.Run(scenario, func(t *testing.T) {
 			fn(t, sut, readProcessor)
@@ -84,6 +129,122 @@ func testReadVolumesError(t *testing.T, sut *readHandlerImpl, readProcessor *moc
 
 }
 
+func testReadVolume(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	vol := coreread.VolumeMetadata{
+		Volume: model.Volume{
+			VolumeNumber: 4,
+			Title:        "volume title",
+			Works:        []model.Work{{Code: "A123", Title: "work title"}},
+		},
+		Counts: map[string]model.ContentCounts{"A123": {model.Paragraph: 12}},
+	}
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/4", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithVolume(req, res, "4")
+	readProcessor.EXPECT().ProcessVolume(gomock.Any(), int32(4)).Return(&vol, nil)
+	// WHEN
+	sut.ReadVolume(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "volume title")
+	assert.Contains(t, res.Body.String(), `"paragraphs":12`)
+	assert.NotContains(t, res.Body.String(), "sections")
+}
+
+func testReadVolumeBadNumber(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/x", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithVolume(req, res, "x")
+	// WHEN
+	sut.ReadVolume(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "invalid volume number")
+}
+
+func testReadVolumeNotFound(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/4", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithVolume(req, res, "4")
+	readProcessor.EXPECT().ProcessVolume(gomock.Any(), int32(4)).Return(nil, nil)
+	// WHEN
+	sut.ReadVolume(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotFound, ctx.Response().Status)
+}
+
+func testReadVolumeError(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/4", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithVolume(req, res, "4")
+	readProcessor.EXPECT().ProcessVolume(gomock.Any(), int32(4)).Return(nil, errors.New("test error"))
+	// WHEN
+	sut.ReadVolume(ctx)
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().Status)
+}
+
+func testReadWork(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	workCode := "A123"
+	work := coreread.WorkMetadata{
+		VolumeNumber: 4,
+		Work:         model.Work{Code: workCode, Title: "work title", Sections: []model.Section{{Heading: 1}}},
+		Counts:       model.ContentCounts{model.Heading: 1},
+	}
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode, nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, workCode)
+	readProcessor.EXPECT().ProcessWork(gomock.Any(), workCode).Return(&work, nil)
+	// WHEN
+	sut.ReadWork(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), `"volumeNumber":4`)
+	assert.Contains(t, res.Body.String(), `"headings":1`)
+	assert.Contains(t, res.Body.String(), `"sections":[{"heading":1`)
+}
+
+func testReadWorkEmptyCode(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, "")
+	// WHEN
+	sut.ReadWork(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "empty work code")
+}
+
+func testReadWorkNotFound(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/A123", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, "A123")
+	readProcessor.EXPECT().ProcessWork(gomock.Any(), "A123").Return(nil, nil)
+	// WHEN
+	sut.ReadWork(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotFound, ctx.Response().Status)
+}
+
+func testReadWorkError(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/A123", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, "A123")
+	readProcessor.EXPECT().ProcessWork(gomock.Any(), "A123").Return(nil, errors.New("test error"))
+	// WHEN
+	sut.ReadWork(ctx)
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().Status)
+}
+
 func testReadFootnotes(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
 	workCode := "A123"
 	fn := model.Content{
@@ -99,8 +260,8 @@ func testReadFootnotes(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.
 	res := httptest.NewRecorder()
 	ctx := createCtxWithWorkCode(req, res, workCode)
 	readProcessor.EXPECT().
-		ProcessFootnotes(gomock.Any(), workCode, []int32{}).
-		Return([]model.Content{fn}, nil)
+		ProcessFootnotes(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), coreread.NoLimit).
+		Return(&model.ContentPage{Contents: []model.Content{fn}}, nil)
 	// WHEN
 	sut.ReadFootnotes(ctx)
 	// THEN
@@ -129,7 +290,7 @@ func testReadFootnotesError(t *testing.T, sut *readHandlerImpl, readProcessor *m
 	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/footnotes", nil)
 	res := httptest.NewRecorder()
 	ctx := createCtxWithWorkCode(req, res, workCode)
-	readProcessor.EXPECT().ProcessFootnotes(gomock.Any(), workCode, []int32{}).Return(nil, e)
+	readProcessor.EXPECT().ProcessFootnotes(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), coreread.NoLimit).Return(nil, e)
 	// WHEN
 	sut.ReadFootnotes(ctx)
 	// THEN
@@ -152,8 +313,8 @@ func testReadHeadings(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.M
 	res := httptest.NewRecorder()
 	ctx := createCtxWithWorkCode(req, res, workCode)
 	readProcessor.EXPECT().
-		ProcessHeadings(gomock.Any(), workCode, []int32{}).
-		Return([]model.Content{head}, nil)
+		ProcessHeadings(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), coreread.NoLimit).
+		Return(&model.ContentPage{Contents: []model.Content{head}}, nil)
 	// WHEN
 	sut.ReadHeadings(ctx)
 	// THEN
@@ -182,7 +343,7 @@ func testReadHeadingsError(t *testing.T, sut *readHandlerImpl, readProcessor *mo
 	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/headings", nil)
 	res := httptest.NewRecorder()
 	ctx := createCtxWithWorkCode(req, res, workCode)
-	readProcessor.EXPECT().ProcessHeadings(gomock.Any(), workCode, []int32{}).Return(nil, e)
+	readProcessor.EXPECT().ProcessHeadings(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), coreread.NoLimit).Return(nil, e)
 	// WHEN
 	sut.ReadHeadings(ctx)
 	// THEN
@@ -206,8 +367,8 @@ func testReadParagraphs(t *testing.T, sut *readHandlerImpl, readProcessor *mocks
 	res := httptest.NewRecorder()
 	ctx := createCtxWithWorkCode(req, res, workCode)
 	readProcessor.EXPECT().
-		ProcessParagraphs(gomock.Any(), workCode, []int32{}).
-		Return([]model.Content{par}, nil)
+		ProcessParagraphs(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), coreread.NoLimit).
+		Return(&model.ContentPage{Contents: []model.Content{par}}, nil)
 	// WHEN
 	sut.ReadParagraphs(ctx)
 	// THEN
@@ -227,17 +388,164 @@ func testReadParagraphsWithOrdinals(t *testing.T, sut *readHandlerImpl, readProc
 		WorkCode:   workCode,
 	}
 	// GIVEN
-	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/paragraphs"+"?ordinals=2,99,485", nil)
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/paragraphs"+"?ordinals=2,10-50,485-&cursor=12&limit=20", nil)
 	res := httptest.NewRecorder()
 	ctx := createCtxWithWorkCode(req, res, workCode)
 	readProcessor.EXPECT().
-		ProcessParagraphs(gomock.Any(), workCode, []int32{2, 99, 485}).
-		Return([]model.Content{par}, nil)
+		ProcessParagraphs(gomock.Any(), workCode, []model.OrdinalRange{
+			{From: util.Int32Ptr(2), To: util.Int32Ptr(2)},
+			{From: util.Int32Ptr(10), To: util.Int32Ptr(50)},
+			{From: util.Int32Ptr(485), To: nil},
+		}, util.Int32Ptr(12), 20).
+		Return(&model.ContentPage{Contents: []model.Content{par}, NextCursor: util.Int32Ptr(31)}, nil)
 	// WHEN
 	sut.ReadParagraphs(ctx)
 	// THEN
 	assert.Equal(t, http.StatusOK, ctx.Response().Status)
 	assert.Contains(t, res.Body.String(), par.FmtText)
+	assert.Equal(t, "31", res.Header().Get(NextCursorHeader))
+}
+
+func testReadParagraphsWithFormat(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	workCode := "A123"
+	par := model.Content{
+		Type:     model.Paragraph,
+		FmtText:  "<ks-fmt-emph>formatted</ks-fmt-emph> text<ks-meta-fnref>4.1</ks-meta-fnref>",
+		WorkCode: workCode,
+	}
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/paragraphs"+"?format=markdown&showFnRefs=false", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, workCode)
+	readProcessor.EXPECT().
+		ProcessParagraphs(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), coreread.NoLimit).
+		Return(&model.ContentPage{Contents: []model.Content{par}}, nil)
+	// WHEN
+	sut.ReadParagraphs(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "*formatted* text")
+	assert.NotContains(t, res.Body.String(), "4.1")
+}
+
+func testReadParagraphsBadFormat(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	workCode := "A123"
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/paragraphs"+"?format=pdf", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, workCode)
+	// WHEN
+	sut.ReadParagraphs(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "invalid format options")
+}
+
+func testReadParagraphsWithEmbed(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	workCode := "A123"
+	par := model.Content{Type: model.Paragraph, Ordinal: 3, FmtText: "paragraph text", FnRefs: []string{"4.1"}, SummaryRef: util.StrPtr("4.2"), WorkCode: workCode}
+	summ := model.Content{Type: model.Summary, Ordinal: 2, Ref: util.StrPtr("4.2"), FmtText: "<ks-fmt-emph>summary</ks-fmt-emph> text", WorkCode: workCode}
+	fn := model.Content{Type: model.Footnote, Ordinal: 4, Ref: util.StrPtr("4.1"), FmtText: "footnote text", WorkCode: workCode}
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/paragraphs"+"?embed=footnotes,summaries&format=markdown", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, workCode)
+	readProcessor.EXPECT().
+		ProcessParagraphs(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), coreread.NoLimit).
+		Return(&model.ContentPage{Contents: []model.Content{par}}, nil)
+	readProcessor.EXPECT().
+		ProcessEmbedded(gomock.Any(), workCode, []model.Content{par}, coreread.Embed{Footnotes: true, Summaries: true}).
+		Return([]coreread.EmbeddedContent{{Content: par, Summary: &summ, Footnotes: []model.Content{fn}}}, nil)
+	// WHEN
+	sut.ReadParagraphs(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), `"summaryRef":"4.2","summary":{"ordinal":2,"ref":"4.2","text":"*summary* text"`)
+	assert.Contains(t, res.Body.String(), `"footnotes":[{"ordinal":4,"ref":"4.1","text":"footnote text"}]`)
+}
+
+func testReadParagraphsBadEmbed(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	workCode := "A123"
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/paragraphs"+"?embed=footnotes,headings", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, workCode)
+	// WHEN
+	sut.ReadParagraphs(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "invalid embed values")
+}
+
+func testReadHeadingsWithEmbed(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	workCode := "A123"
+	head := model.Content{Type: model.Heading, Ordinal: 1, FmtText: "heading text", FnRefs: []string{"4.1"}, WorkCode: workCode}
+	fn := model.Content{Type: model.Footnote, Ordinal: 4, Ref: util.StrPtr("4.1"), FmtText: "footnote text", WorkCode: workCode}
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/headings"+"?embed=footnotes", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, workCode)
+	readProcessor.EXPECT().
+		ProcessHeadings(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), coreread.NoLimit).
+		Return(&model.ContentPage{Contents: []model.Content{head}}, nil)
+	readProcessor.EXPECT().
+		ProcessEmbedded(gomock.Any(), workCode, []model.Content{head}, coreread.Embed{Footnotes: true}).
+		Return([]coreread.EmbeddedContent{{Content: head, Footnotes: []model.Content{fn}}}, nil)
+	// WHEN
+	sut.ReadHeadings(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), `"footnotes":[{"ordinal":4,"ref":"4.1","text":"footnote text"}]`)
+}
+
+func testReadParagraphsBadRange(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	workCode := "A123"
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/paragraphs"+"?ordinals=2,50-10", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, workCode)
+	// WHEN
+	sut.ReadParagraphs(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "invalid ordinal values")
+}
+
+func testReadParagraphsBadLimit(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	workCode := "A123"
+	for _, limit := range []string{"0", "5001"} {
+		// GIVEN
+		req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/paragraphs"+"?limit="+limit, nil)
+		res := httptest.NewRecorder()
+		ctx := createCtxWithWorkCode(req, res, workCode)
+		// WHEN
+		sut.ReadParagraphs(ctx)
+		// THEN
+		assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+		assert.Contains(t, res.Body.String(), "invalid cursor or limit")
+	}
+}
+
+func testReadParagraphsWithoutLimit(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	workCode := "A123"
+	par := model.Content{
+		Type:     model.Paragraph,
+		FmtText:  "formatted text",
+		WorkCode: workCode,
+	}
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/paragraphs"+"?cursor=12", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, workCode)
+	readProcessor.EXPECT().
+		ProcessParagraphs(gomock.Any(), workCode, []model.OrdinalRange{}, util.Int32Ptr(12), coreread.NoLimit).
+		Return(&model.ContentPage{Contents: []model.Content{par}}, nil)
+	// WHEN
+	sut.ReadParagraphs(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), par.FmtText)
+	assert.Empty(t, res.Header().Get(NextCursorHeader))
 }
 
 func testReadParagraphsEmptyCode(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
@@ -261,7 +569,7 @@ func testReadParagraphsError(t *testing.T, sut *readHandlerImpl, readProcessor *
 	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/paragraphs", nil)
 	res := httptest.NewRecorder()
 	ctx := createCtxWithWorkCode(req, res, workCode)
-	readProcessor.EXPECT().ProcessParagraphs(gomock.Any(), workCode, []int32{}).Return(nil, e)
+	readProcessor.EXPECT().ProcessParagraphs(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), coreread.NoLimit).Return(nil, e)
 	// WHEN
 	sut.ReadParagraphs(ctx)
 	// THEN
@@ -284,8 +592,8 @@ func testReadSummaries(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.
 	res := httptest.NewRecorder()
 	ctx := createCtxWithWorkCode(req, res, workCode)
 	readProcessor.EXPECT().
-		ProcessSummaries(gomock.Any(), workCode, []int32{}).
-		Return([]model.Content{summ}, nil)
+		ProcessSummaries(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), coreread.NoLimit).
+		Return(&model.ContentPage{Contents: []model.Content{summ}}, nil)
 	// WHEN
 	sut.ReadSummaries(ctx)
 	// THEN
@@ -314,7 +622,7 @@ func testReadSummariesError(t *testing.T, sut *readHandlerImpl, readProcessor *m
 	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/summaries", nil)
 	res := httptest.NewRecorder()
 	ctx := createCtxWithWorkCode(req, res, workCode)
-	readProcessor.EXPECT().ProcessSummaries(gomock.Any(), workCode, []int32{}).Return(nil, e)
+	readProcessor.EXPECT().ProcessSummaries(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), coreread.NoLimit).Return(nil, e)
 	// WHEN
 	sut.ReadSummaries(ctx)
 	// THEN
@@ -322,9 +630,611 @@ func testReadSummariesError(t *testing.T, sut *readHandlerImpl, readProcessor *m
 	assert.Contains(t, res.Body.String(), "message")
 }
 
+func testReadWorkText(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	workCode := "A123"
+	text := coreread.WorkText{
+		Work: model.Work{Code: workCode, Title: "work title"},
+		Items: []coreread.TextItem{{
+			Content:   model.Content{Type: model.Paragraph, Ordinal: 1, FmtText: "formatted text 1", WorkCode: workCode},
+			Footnotes: []model.Content{{Type: model.Footnote, Ordinal: 2, FmtText: "formatted footnote 2", WorkCode: workCode}},
+		}},
+	}
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/text", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, workCode)
+	readProcessor.EXPECT().
+		ProcessWorkText(gomock.Any(), workCode, gomock.Nil(), gomock.Nil()).
+		Return(&text, nil)
+	// WHEN
+	sut.ReadWorkText(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "formatted text 1")
+	assert.Contains(t, res.Body.String(), "formatted footnote 2")
+}
+
+func testReadWorkTextWithRange(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	workCode := "A123"
+	from := int32(10)
+	to := int32(50)
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/text?from=10&to=50", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, workCode)
+	readProcessor.EXPECT().
+		ProcessWorkText(gomock.Any(), workCode, &from, &to).
+		Return(&coreread.WorkText{Work: model.Work{Code: workCode}}, nil)
+	// WHEN
+	sut.ReadWorkText(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+}
+
+func testReadWorkTextBadRange(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	workCode := "A123"
+	for _, query := range []string{"?from=abc", "?to=1.5", "?from=50&to=10"} {
+		// GIVEN
+		req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/text"+query, nil)
+		res := httptest.NewRecorder()
+		ctx := createCtxWithWorkCode(req, res, workCode)
+		// WHEN
+		sut.ReadWorkText(ctx)
+		// THEN
+		assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+		assert.Contains(t, res.Body.String(), "invalid ordinal range")
+	}
+}
+
+func testReadWorkTextNotFound(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	workCode := "A123"
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/text", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, workCode)
+	readProcessor.EXPECT().
+		ProcessWorkText(gomock.Any(), workCode, gomock.Nil(), gomock.Nil()).
+		Return(nil, nil)
+	// WHEN
+	sut.ReadWorkText(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotFound, ctx.Response().Status)
+}
+
+func testReadWorkTextError(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	workCode := "A123"
+	e := errors.New("test error")
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/"+workCode+"/text", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, workCode)
+	readProcessor.EXPECT().
+		ProcessWorkText(gomock.Any(), workCode, gomock.Nil(), gomock.Nil()).
+		Return(nil, e)
+	// WHEN
+	sut.ReadWorkText(ctx)
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "message")
+}
+
+func testReadPage(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	next := int32(144)
+	page := coreread.Page{
+		VolumeNumber: 5,
+		Page:         143,
+		Contents: []model.Content{{
+			Type:     model.Paragraph,
+			Ordinal:  3,
+			FmtText:  "<ks-meta-page>143</ks-meta-page>formatted text",
+			WorkCode: "A123",
+		}},
+		Next: &next,
+	}
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/5/pages/143", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithVolumePage(req, res, "5", "143")
+	readProcessor.EXPECT().ProcessPage(gomock.Any(), int32(5), int32(143)).Return(&page, nil)
+	// WHEN
+	sut.ReadPage(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "formatted text")
+	assert.Contains(t, res.Body.String(), `"next":144`)
+	assert.NotContains(t, res.Body.String(), `"prev"`)
+}
+
+func testReadPageWithFormat(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	page := coreread.Page{
+		VolumeNumber: 5,
+		Page:         143,
+		Contents: []model.Content{{
+			Type:         model.Paragraph,
+			Ordinal:      3,
+			FmtText:      "<ks-meta-page>143</ks-meta-page><ks-fmt-emph>formatted</ks-fmt-emph> text",
+			PageByIndex:  []model.IndexNumberPair{{I: 0, Num: 143}},
+			LineByIndex:  []model.IndexNumberPair{{I: 32, Num: 1}},
+			WordIndexMap: map[int32]int32{0: 0},
+			WorkCode:     "A123",
+		}},
+	}
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/5/pages/143?format=markdown", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithVolumePage(req, res, "5", "143")
+	readProcessor.EXPECT().ProcessPage(gomock.Any(), int32(5), int32(143)).Return(&page, nil)
+	// WHEN
+	sut.ReadPage(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "*formatted* text")
+	assert.NotContains(t, res.Body.String(), "pageByIndex")
+	assert.NotContains(t, res.Body.String(), "lineByIndex")
+	assert.NotContains(t, res.Body.String(), "wordIndexMap")
+}
+
+func testReadPageBadParams(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	for _, params := range [][]string{{"V", "143"}, {"5", ""}, {"0", "143"}, {"5", "-1"}} {
+		// GIVEN
+		req := httptest.NewRequest(echo.GET, "/api/v1/volumes/"+params[0]+"/pages/"+params[1], nil)
+		res := httptest.NewRecorder()
+		ctx := createCtxWithVolumePage(req, res, params[0], params[1])
+		// WHEN
+		sut.ReadPage(ctx)
+		// THEN
+		assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+		assert.Contains(t, res.Body.String(), "invalid volume number or page")
+	}
+}
+
+func testReadPageNotFound(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/5/pages/999", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithVolumePage(req, res, "5", "999")
+	readProcessor.EXPECT().ProcessPage(gomock.Any(), int32(5), int32(999)).Return(nil, nil)
+	// WHEN
+	sut.ReadPage(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotFound, ctx.Response().Status)
+}
+
+func testReadPageError(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	e := errors.New("test error")
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/5/pages/143", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithVolumePage(req, res, "5", "143")
+	readProcessor.EXPECT().ProcessPage(gomock.Any(), int32(5), int32(143)).Return(nil, e)
+	// WHEN
+	sut.ReadPage(ctx)
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "message")
+}
+
+func testResolveCitation(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	citation := "AA IV 421.12"
+	resolved := coreread.ResolvedCitation{
+		WorkCode:   "GMS",
+		Ordinals:   []int32{54, 55},
+		LineOffset: &coreread.LineOffset{Ordinal: 55, Index: 230},
+	}
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/citations/resolve?citation="+url.QueryEscape(citation), nil)
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	readProcessor.EXPECT().ProcessCitation(gomock.Any(), citation).Return(&resolved, nil)
+	// WHEN
+	sut.ResolveCitation(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), `"workCode":"GMS"`)
+	assert.Contains(t, res.Body.String(), `"lineOffset":230`)
+}
+
+func testResolveEmptyCitation(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/citations/resolve?citation=%20", nil)
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	// WHEN
+	sut.ResolveCitation(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "BAD_REQUEST_INVALID_CITATION")
+}
+
+func testResolveInvalidCitation(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	citation := "KrV B 132"
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/citations/resolve?citation="+url.QueryEscape(citation), nil)
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	readProcessor.EXPECT().
+		ProcessCitation(gomock.Any(), citation).
+		Return(nil, &coreread.CitationError{Msg: "not supported"})
+	// WHEN
+	sut.ResolveCitation(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "BAD_REQUEST_INVALID_CITATION")
+	assert.Contains(t, res.Body.String(), "not supported")
+}
+
+func testResolveUnknownCitation(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	citation := "GMS 4:999"
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/citations/resolve?citation="+url.QueryEscape(citation), nil)
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	readProcessor.EXPECT().ProcessCitation(gomock.Any(), citation).Return(nil, nil)
+	// WHEN
+	sut.ResolveCitation(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotFound, ctx.Response().Status)
+}
+
+func testResolveCitationError(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	citation := "GMS 4:421"
+	e := errors.New("test error")
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/citations/resolve?citation="+url.QueryEscape(citation), nil)
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	readProcessor.EXPECT().ProcessCitation(gomock.Any(), citation).Return(nil, e)
+	// WHEN
+	sut.ResolveCitation(ctx)
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().Status)
+}
+
+func testReadNavigation(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	nav := coreread.Navigation{
+		PrevParagraph: &coreread.Location{VolumeNumber: 4, WorkCode: "GMS", Ordinal: 11},
+		NextHeading:   &coreread.Location{VolumeNumber: 5, WorkCode: "KPV", Ordinal: 1},
+	}
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/GMS/navigation/12", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCodeOrdinal(req, res, "GMS", "12")
+	readProcessor.EXPECT().ProcessNavigation(gomock.Any(), "GMS", int32(12)).Return(&nav, nil)
+	// WHEN
+	sut.ReadNavigation(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), `"prevParagraph":{"volumeNumber":4,"workCode":"GMS","ordinal":11}`)
+	assert.Contains(t, res.Body.String(), `"nextHeading":{"volumeNumber":5,"workCode":"KPV","ordinal":1}`)
+	assert.NotContains(t, res.Body.String(), `"prevHeading"`)
+}
+
+func testReadNavigationBadOrdinal(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	for _, ordinal := range []string{"0", "abc"} {
+		// GIVEN
+		req := httptest.NewRequest(echo.GET, "/api/v1/works/GMS/navigation/"+ordinal, nil)
+		res := httptest.NewRecorder()
+		ctx := createCtxWithWorkCodeOrdinal(req, res, "GMS", ordinal)
+		// WHEN
+		sut.ReadNavigation(ctx)
+		// THEN
+		assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	}
+}
+
+func testReadNavigationNotFound(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/XYZ/navigation/1", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCodeOrdinal(req, res, "XYZ", "1")
+	readProcessor.EXPECT().ProcessNavigation(gomock.Any(), "XYZ", int32(1)).Return(nil, nil)
+	// WHEN
+	sut.ReadNavigation(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotFound, ctx.Response().Status)
+}
+
+func testReadNavigationError(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/GMS/navigation/1", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCodeOrdinal(req, res, "GMS", "1")
+	readProcessor.EXPECT().ProcessNavigation(gomock.Any(), "GMS", int32(1)).Return(nil, errors.New("test error"))
+	// WHEN
+	sut.ReadNavigation(ctx)
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().Status)
+}
+
+func testReadPersons(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	persons := []coreread.Person{{
+		Name: "Leibniz",
+		Occurrences: []coreread.Occurrence{
+			{Location: coreread.Location{VolumeNumber: 4, WorkCode: "GMS", Ordinal: 11}, Type: model.Paragraph},
+		},
+	}}
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/persons", nil)
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	readProcessor.EXPECT().ProcessPersons(gomock.Any(), gomock.Nil(), defaultPersonLimit).Return(&coreread.PersonPage{Persons: persons}, nil)
+	// WHEN
+	sut.ReadPersons(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), `{"name":"Leibniz","occurrences":[{"volumeNumber":4,"workCode":"GMS","ordinal":11,"type":"paragraph"}]}`)
+	assert.Empty(t, res.Header().Get(NextCursorHeader))
+}
+
+func testReadPersonsWithCursor(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	next := "Müller"
+	persons := []coreread.Person{{
+		Name: "Müller",
+		Occurrences: []coreread.Occurrence{
+			{Location: coreread.Location{VolumeNumber: 4, WorkCode: "GMS", Ordinal: 11}, Type: model.Paragraph},
+		},
+	}}
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/persons?cursor=Leibniz&limit=1", nil)
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	cursor := "Leibniz"
+	readProcessor.EXPECT().ProcessPersons(gomock.Any(), &cursor, 1).Return(&coreread.PersonPage{Persons: persons, NextCursor: &next}, nil)
+	// WHEN
+	sut.ReadPersons(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), `"name":"Müller"`)
+	assert.Equal(t, "M%C3%BCller", res.Header().Get(NextCursorHeader))
+}
+
+func testReadPersonsBadLimit(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	for _, limit := range []string{"0", "1001", "abc"} {
+		// GIVEN
+		req := httptest.NewRequest(echo.GET, "/api/v1/persons?limit="+limit, nil)
+		res := httptest.NewRecorder()
+		ctx := echo.New().NewContext(req, res)
+		// WHEN
+		sut.ReadPersons(ctx)
+		// THEN
+		assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	}
+}
+
+func testReadPersonsError(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/persons", nil)
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	readProcessor.EXPECT().ProcessPersons(gomock.Any(), gomock.Nil(), defaultPersonLimit).Return(nil, errors.New("test error"))
+	// WHEN
+	sut.ReadPersons(ctx)
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().Status)
+}
+
+func TestReadHandlerCaching(t *testing.T) {
+	for scenario, fn := range map[string]func(*testing.T, *readHandlerImpl, *mocks.MockReadProcessor){
+		"Read paragraphs with etag":          testReadParagraphsWithETag,
+		"Read paragraphs not modified":       testReadParagraphsNotModified,
+		"Read paragraphs with outdated etag": testReadParagraphsOutdatedETag,
+		"Read paragraphs with version error": testReadParagraphsVersionError,
+		"Read page not modified":             testReadPageNotModified,
+		"Read volumes not modified":          testReadVolumesNotModified,
+		"Read volumes without version":       testReadVolumesWithoutVersion,
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			readProcessor := mocks.NewMockReadProcessor(ctrl)
+			sut := &readHandlerImpl{readProcessor: readProcessor}
+			fn(t, sut, readProcessor)
+		})
+	}
+}
+
+func testReadParagraphsWithETag(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/A123/paragraphs", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, "A123")
+	readProcessor.EXPECT().ProcessWorkVersion(gomock.Any(), "A123").Return(util.StrPtr("v1"), nil)
+	readProcessor.EXPECT().
+		ProcessParagraphs(gomock.Any(), "A123", []model.OrdinalRange{}, gomock.Nil(), coreread.NoLimit).
+		Return(&model.ContentPage{Contents: []model.Content{}}, nil)
+	// WHEN
+	sut.ReadParagraphs(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Regexp(t, `^W/"v1-[0-9a-f]+"$`, res.Header().Get(ETagHeader))
+	assert.Equal(t, "public, no-cache", res.Header().Get(echo.HeaderCacheControl))
+}
+
+func testReadParagraphsNotModified(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	etag := readETag(t, sut, readProcessor, "/api/v1/works/A123/paragraphs?ordinals=1-5")
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/A123/paragraphs?ordinals=1-5", nil)
+	req.Header.Set("If-None-Match", `"other", `+etag)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, "A123")
+	readProcessor.EXPECT().ProcessWorkVersion(gomock.Any(), "A123").Return(util.StrPtr("v1"), nil)
+	// WHEN
+	sut.ReadParagraphs(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotModified, ctx.Response().Status)
+	assert.Equal(t, etag, res.Header().Get(ETagHeader))
+	assert.Empty(t, res.Body.String())
+}
+
+func testReadParagraphsOutdatedETag(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	etag := readETag(t, sut, readProcessor, "/api/v1/works/A123/paragraphs")
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/A123/paragraphs", nil)
+	req.Header.Set("If-None-Match", etag)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, "A123")
+	readProcessor.EXPECT().ProcessWorkVersion(gomock.Any(), "A123").Return(util.StrPtr("v2"), nil)
+	readProcessor.EXPECT().
+		ProcessParagraphs(gomock.Any(), "A123", []model.OrdinalRange{}, gomock.Nil(), coreread.NoLimit).
+		Return(&model.ContentPage{Contents: []model.Content{}}, nil)
+	// WHEN
+	sut.ReadParagraphs(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.NotEqual(t, etag, res.Header().Get(ETagHeader))
+}
+
+func testReadParagraphsVersionError(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/A123/paragraphs", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, "A123")
+	readProcessor.EXPECT().ProcessWorkVersion(gomock.Any(), "A123").Return(nil, errors.New("test error"))
+	// WHEN
+	sut.ReadParagraphs(ctx)
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().Status)
+}
+
+func testReadPageNotModified(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/4/pages/387", nil)
+	req.Header.Set("If-None-Match", "*")
+	res := httptest.NewRecorder()
+	ctx := createCtxWithVolumePage(req, res, "4", "387")
+	readProcessor.EXPECT().ProcessVolumeVersion(gomock.Any(), int32(4)).Return(util.StrPtr("v1"), nil)
+	// WHEN
+	sut.ReadPage(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotModified, ctx.Response().Status)
+}
+
+func testReadVolumesNotModified(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes", nil)
+	req.Header.Set("If-None-Match", "*")
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	readProcessor.EXPECT().ProcessVersion(gomock.Any()).Return("v1", nil)
+	// WHEN
+	sut.ReadVolumes(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotModified, ctx.Response().Status)
+}
+
+func testReadVolumesWithoutVersion(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes", nil)
+	req.Header.Set("If-None-Match", "*")
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	readProcessor.EXPECT().ProcessVersion(gomock.Any()).Return("", nil)
+	readProcessor.EXPECT().ProcessVolumes(gomock.Any()).Return([]model.Volume{{VolumeNumber: 1}}, nil)
+	// WHEN
+	sut.ReadVolumes(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Empty(t, res.Header().Get(ETagHeader))
+	assert.Empty(t, res.Header().Get(echo.HeaderCacheControl))
+}
+
+func readETag(t *testing.T, sut *readHandlerImpl, readProcessor *mocks.MockReadProcessor, url string) string {
+	req := httptest.NewRequest(echo.GET, url, nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithWorkCode(req, res, "A123")
+	readProcessor.EXPECT().ProcessWorkVersion(gomock.Any(), "A123").Return(util.StrPtr("v1"), nil)
+	readProcessor.EXPECT().ProcessParagraphs(gomock.Any(), "A123", gomock.Any(), gomock.Nil(), coreread.NoLimit).
+		Return(&model.ContentPage{Contents: []model.Content{}}, nil)
+	sut.ReadParagraphs(ctx)
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	return res.Header().Get(ETagHeader)
+}
+
 func createCtxWithWorkCode(req *http.Request, res *httptest.ResponseRecorder, workCode string) echo.Context {
 	ctx := echo.New().NewContext(req, res)
 	ctx.SetParamNames("workCode")
 	ctx.SetParamValues(workCode)
 	return ctx
 }
+
+func createCtxWithWorkCodeOrdinal(req *http.Request, res *httptest.ResponseRecorder, workCode string, ordinal string) echo.Context {
+	ctx := echo.New().NewContext(req, res)
+	ctx.SetParamNames("workCode", "ordinal")
+	ctx.SetParamValues(workCode, ordinal)
+	return ctx
+}
+
+func createCtxWithVolume(req *http.Request, res *httptest.ResponseRecorder, volumeNumber string) echo.Context {
+	ctx := echo.New().NewContext(req, res)
+	ctx.SetParamNames("volumeNumber")
+	ctx.SetParamValues(volumeNumber)
+	return ctx
+}
+
+func createCtxWithVolumePage(req *http.Request, res *httptest.ResponseRecorder, volumeNumber string, page string) echo.Context {
+	ctx := echo.New().NewContext(req, res)
+	ctx.SetParamNames("volumeNumber", "page")
+	ctx.SetParamValues(volumeNumber, page)
+	return ctx
+}
+
+func TestFindOrdinals(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []model.OrdinalRange
+		hasError bool
+	}{
+		{
+			name:     "empty",
+			input:    "",
+			expected: []model.OrdinalRange{},
+		},
+		{
+			name:  "single values",
+			input: "1, 5",
+			expected: []model.OrdinalRange{
+				{From: util.Int32Ptr(1), To: util.Int32Ptr(1)},
+				{From: util.Int32Ptr(5), To: util.Int32Ptr(5)},
+			},
+		},
+		{
+			name:  "ranges and single values",
+			input: "3,10-50,-2,200-",
+			expected: []model.OrdinalRange{
+				{From: util.Int32Ptr(3), To: util.Int32Ptr(3)},
+				{From: util.Int32Ptr(10), To: util.Int32Ptr(50)},
+				{From: nil, To: util.Int32Ptr(2)},
+				{From: util.Int32Ptr(200), To: nil},
+			},
+		},
+		{
+			name:     "range without bounds",
+			input:    "1,-",
+			hasError: true,
+		},
+		{
+			name:     "range with lower bound greater than upper bound",
+			input:    "50-10",
+			hasError: true,
+		},
+		{
+			name:     "non numeric value",
+			input:    "1,a-5",
+			hasError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// WHEN
+			ords, err := findOrdinals(tc.input)
+			// THEN
+			if tc.hasError {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, ords)
+		})
+	}
+}
@@ -10,17 +10,35 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// error messages for query limit violations, they are not yet part of the generated API models
+const (
+	badRequestSyntaxTooManyTokens  models.ErrorMessage = "BAD_REQUEST_SYNTAX_TOO_MANY_TOKENS"
+	badRequestSyntaxNestingTooDeep models.ErrorMessage = "BAD_REQUEST_SYNTAX_NESTING_TOO_DEEP"
+	badRequestSyntaxTooManyPhrases models.ErrorMessage = "BAD_REQUEST_SYNTAX_TOO_MANY_PHRASES"
+)
+
+// not (yet) part of the generated API models
+const BadRequestInvalidFormat models.ErrorMessage = "BAD_REQUEST_INVALID_FORMAT"
+
 func SyntaxErrorToApiError(ctx echo.Context, err *errors.SyntaxError) error {
-	msg, e := mapSyntaxEnum(err.Msg)
+	apiErr, e := SyntaxErrorToApiModel(err)
 	if e != nil {
 		log.Error().Err(e).Msgf("error mapping validation error: %v", err)
 		return InternalServerError(ctx)
 	}
-	return ctx.JSON(http.StatusBadRequest, models.HttpError{
+	return ctx.JSON(http.StatusBadRequest, apiErr)
+}
+
+func SyntaxErrorToApiModel(err *errors.SyntaxError) (models.HttpError, error) {
+	msg, e := mapSyntaxEnum(err.Msg)
+	if e != nil {
+		return models.HttpError{}, e
+	}
+	return models.HttpError{
 		Code:    http.StatusBadRequest,
 		Message: msg,
 		Params:  err.Params,
-	})
+	}, nil
 }
 
 func BadRequest(ctx echo.Context, msg models.ErrorMessage) error {
@@ -51,6 +69,12 @@ func mapSyntaxEnum(err errors.ErrMsg) (models.ErrorMessage, error) {
 		return models.BAD_REQUEST_SYNTAX_MISSING_CLOSING_PARENTHESIS, nil
 	case errors.UnterminatedDoubleQuote:
 		return models.BAD_REQUEST_SYNTAX_UNTERMINATED_DOUBLE_QUOTE, nil
+	case errors.TooManyTokens:
+		return badRequestSyntaxTooManyTokens, nil
+	case errors.NestingTooDeep:
+		return badRequestSyntaxNestingTooDeep, nil
+	case errors.TooManyPhrases:
+		return badRequestSyntaxTooManyPhrases, nil
 	}
 	return "", fmt.Errorf("unknown enum \"%s\"", err)
 }
@@ -2,19 +2,79 @@ package mapping
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/frhorschig/kant-search-api/generated/go/models"
+	"github.com/frhorschig/kant-search-backend/api/search/internal/errors"
+	"github.com/frhorschig/kant-search-backend/core/search"
 	"github.com/frhorschig/kant-search-backend/dataaccess/model"
 )
 
-func CriteriaToCoreModel(in *models.SearchCriteria) (string, model.SearchOptions) {
-	return in.SearchTerms, model.SearchOptions{
-		IncludeHeadings:   in.Options.IncludeHeadings,
-		IncludeFootnotes:  in.Options.IncludeFootnotes,
-		IncludeParagraphs: in.Options.IncludeParagraphs,
-		WithStemming:      in.Options.WithStemming,
-		WorkCodes:         in.Options.WorkCodes,
+func CriteriaToCoreModel(in *SearchCriteria) (string, model.SearchOptions) {
+	return in.SearchTerms, optionsToCoreModel(in.Options)
+}
+
+func BatchCriteriaToCoreModel(in *BatchSearchCriteria) ([]string, model.SearchOptions, bool) {
+	return in.SearchTerms, optionsToCoreModel(in.Options), in.CountOnly
+}
+
+func optionsToCoreModel(in SearchOptions) model.SearchOptions {
+	return model.SearchOptions{
+		IncludeHeadings:   in.IncludeHeadings,
+		IncludeFootnotes:  in.IncludeFootnotes,
+		IncludeParagraphs: in.IncludeParagraphs,
+		WithStemming:      in.WithStemming,
+		Folded:            in.Folded,
+		CaseSensitive:     in.CaseSensitive,
+		WorkCodes:         in.WorkCodes,
+		Languages:         languagesToCoreModel(in.Languages),
+		WithinLanguages:   in.WithinLanguages,
+		Kinds:             kindsToCoreModel(in.Kinds),
+	}
+}
+
+// languagesToCoreModel normalizes the languages like the upload does
+func languagesToCoreModel(in []string) []string {
+	out := []string{}
+	for _, l := range in {
+		l = strings.ToLower(strings.TrimSpace(l))
+		if l != "" {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+func kindsToCoreModel(in []string) []model.Kind {
+	out := []model.Kind{}
+	for _, k := range in {
+		out = append(out, model.Kind(strings.TrimSpace(k)))
+	}
+	return out
+}
+
+func BatchResultsToApiModels(in []search.BatchResult) ([]BatchSearchResult, error) {
+	out := []BatchSearchResult{}
+	for _, r := range in {
+		res := BatchSearchResult{
+			SearchTerms: r.SearchString,
+			Count:       r.Count,
+			Results:     HitsToApiModels(r.Results),
+			Truncated:   r.Truncated,
+		}
+		if r.SyntaxError != nil {
+			apiErr, err := errors.SyntaxErrorToApiModel(r.SyntaxError)
+			if err != nil {
+				return nil, err
+			}
+			res.Error = &apiErr
+		} else if r.Error != nil {
+			res.Error = &models.HttpError{Code: http.StatusInternalServerError, Message: ""}
+		}
+		out = append(out, res)
 	}
+	return out, nil
 }
 
 func HitsToApiModels(hits []model.SearchResult) []models.SearchResult {
@@ -13,14 +13,21 @@ import (
 )
 
 func TestCriteriaToCoreModel(t *testing.T) {
-	criteria := models.SearchCriteria{
+	criteria := SearchCriteria{
 		SearchTerms: "search terms",
-		Options: models.SearchOptions{
-			IncludeHeadings:   false,
-			IncludeFootnotes:  true,
-			IncludeParagraphs: false,
-			WithStemming:      true,
-			WorkCodes:         []string{"id1", "id2"},
+		Options: SearchOptions{
+			SearchOptions: models.SearchOptions{
+				IncludeHeadings:   false,
+				IncludeFootnotes:  true,
+				IncludeParagraphs: false,
+				WithStemming:      true,
+				WorkCodes:         []string{"id1", "id2"},
+			},
+			Folded:          true,
+			CaseSensitive:   true,
+			Languages:       []string{" Lat ", "", "gr"},
+			WithinLanguages: true,
+			Kinds:           []string{"letter", " reflection "},
 		},
 	}
 
@@ -31,6 +38,11 @@ func TestCriteriaToCoreModel(t *testing.T) {
 	assert.Equal(t, opts.IncludeHeadings, criteria.Options.IncludeHeadings)
 	assert.Equal(t, opts.IncludeFootnotes, criteria.Options.IncludeFootnotes)
 	assert.Equal(t, opts.IncludeParagraphs, criteria.Options.IncludeParagraphs)
+	assert.Equal(t, opts.Folded, criteria.Options.Folded)
+	assert.Equal(t, opts.CaseSensitive, criteria.Options.CaseSensitive)
+	assert.Equal(t, opts.Languages, []string{"lat", "gr"})
+	assert.Equal(t, opts.WithinLanguages, criteria.Options.WithinLanguages)
+	assert.Equal(t, opts.Kinds, []model.Kind{model.LetterKind, model.ReflectionKind})
 }
 
 func TestHitsToApiModels(t *testing.T) {
@@ -0,0 +1,35 @@
+package mapping
+
+import "github.com/frhorschig/kant-search-api/generated/go/models"
+
+// The following types are not (yet) part of the generated API models.
+
+// SearchCriteria is models.SearchCriteria with the extended SearchOptions
+type SearchCriteria struct {
+	SearchTerms string        `json:"searchTerms"`
+	Options     SearchOptions `json:"options"`
+}
+
+// SearchOptions extends models.SearchOptions with options that are not part of the generated API models
+type SearchOptions struct {
+	models.SearchOptions
+	Folded          bool     `json:"folded"`
+	CaseSensitive   bool     `json:"caseSensitive"`
+	Languages       []string `json:"languages"`       // only texts with passages in these languages, e.g. "lat" or "gr"
+	WithinLanguages bool     `json:"withinLanguages"` // search only within the passages of the languages
+	Kinds           []string `json:"kinds"`           // only texts of letters or Reflexionen, "letter" or "reflection"
+}
+
+type BatchSearchCriteria struct {
+	SearchTerms []string      `json:"searchTerms"`
+	Options     SearchOptions `json:"options"`
+	CountOnly   bool          `json:"countOnly"`
+}
+
+type BatchSearchResult struct {
+	SearchTerms string                `json:"searchTerms"`
+	Count       int32                 `json:"count"`
+	Results     []models.SearchResult `json:"results"`
+	Truncated   bool                  `json:"truncated"` // true if results contains fewer hits than count
+	Error       *models.HttpError     `json:"error,omitempty"`
+}
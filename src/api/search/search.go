@@ -6,13 +6,21 @@ import (
 	"github.com/frhorschig/kant-search-api/generated/go/models"
 	"github.com/frhorschig/kant-search-backend/api/search/internal/errors"
 	"github.com/frhorschig/kant-search-backend/api/search/internal/mapping"
+	"github.com/frhorschig/kant-search-backend/common/render"
 	"github.com/frhorschig/kant-search-backend/core/search"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog/log"
 )
 
+const maxBatchSize = 100
+
+// the results of larger batches are limited to the count, because the hits of all search strings are shared by the size of a single search
+const maxBatchSizeWithResults = 10
+
 type SearchHandler interface {
 	Search(ctx echo.Context) error
+	SearchBatch(ctx echo.Context) error
 }
 
 type searchHandlerImpl struct {
@@ -24,13 +32,19 @@ func NewSearchHandler(searchProcessor search.SearchProcessor) SearchHandler {
 }
 
 func (rec *searchHandlerImpl) Search(ctx echo.Context) error {
-	criteria := new(models.SearchCriteria)
+	criteria := new(mapping.SearchCriteria)
 	err := ctx.Bind(criteria)
 	if err != nil {
 		log.Error().Err(err).Msgf("error parsing search criteria: %v", err)
 		return errors.BadRequest(ctx, models.BAD_REQUEST_INVALID_SEARCH_CRITERIA)
 	}
 
+	renderOpts, err := render.ParseOptions(ctx.QueryParams())
+	if err != nil {
+		log.Error().Err(err).Msgf("error parsing format options: %v", err)
+		return errors.BadRequest(ctx, errors.BadRequestInvalidFormat)
+	}
+
 	searchTerms, options := mapping.CriteriaToCoreModel(criteria)
 	if len(strings.TrimSpace(searchTerms)) == 0 {
 		log.Error().Err(err).Msg("empty search terms")
@@ -53,5 +67,71 @@ func (rec *searchHandlerImpl) Search(ctx echo.Context) error {
 		}
 	}
 
+	renderResults(results, renderOpts)
 	return ctx.JSON(200, mapping.HitsToApiModels(results))
 }
+
+func (rec *searchHandlerImpl) SearchBatch(ctx echo.Context) error {
+	criteria := new(mapping.BatchSearchCriteria)
+	err := ctx.Bind(criteria)
+	if err != nil {
+		log.Error().Err(err).Msgf("error parsing batch search criteria: %v", err)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_INVALID_SEARCH_CRITERIA)
+	}
+
+	renderOpts, err := render.ParseOptions(ctx.QueryParams())
+	if err != nil {
+		log.Error().Err(err).Msgf("error parsing format options: %v", err)
+		return errors.BadRequest(ctx, errors.BadRequestInvalidFormat)
+	}
+
+	searchTerms, options, countOnly := mapping.BatchCriteriaToCoreModel(criteria)
+	if len(searchTerms) == 0 || len(searchTerms) > maxBatchSize {
+		log.Error().Msgf("invalid number of search terms in batch: %d", len(searchTerms))
+		return errors.BadRequest(ctx, models.BAD_REQUEST_INVALID_SEARCH_CRITERIA)
+	}
+	if !countOnly && len(searchTerms) > maxBatchSizeWithResults {
+		log.Error().Msgf("batch of %d search terms without count only", len(searchTerms))
+		return errors.BadRequest(ctx, models.BAD_REQUEST_INVALID_SEARCH_CRITERIA)
+	}
+	for _, terms := range searchTerms {
+		if len(strings.TrimSpace(terms)) == 0 {
+			log.Error().Msg("empty search terms in batch")
+			return errors.BadRequest(ctx, models.BAD_REQUEST_EMPTY_SEARCH_TERMS)
+		}
+	}
+	if len(options.WorkCodes) == 0 {
+		log.Error().Msg("empty work selection")
+		return errors.BadRequest(ctx, models.BAD_REQUEST_EMPTY_WORKS_SELECTION)
+	}
+
+	results, err := rec.searchProcessor.SearchBatch(ctx.Request().Context(), searchTerms, options, countOnly)
+	if err != nil {
+		log.Error().Err(err).Msgf("error while searching for batch matches: %v", err)
+		return errors.InternalServerError(ctx)
+	}
+
+	for _, r := range results {
+		if r.Error != nil {
+			log.Error().Err(r.Error).Msgf("error while searching for batch matches of \"%s\": %v", r.SearchString, r.Error)
+		}
+		renderResults(r.Results, renderOpts)
+	}
+	apiResults, err := mapping.BatchResultsToApiModels(results)
+	if err != nil {
+		log.Error().Err(err).Msgf("error mapping batch search results: %v", err)
+		return errors.InternalServerError(ctx)
+	}
+	return ctx.JSON(200, apiResults)
+}
+
+// renderResults converts the formatted and the highlighted texts to the requested format, it does nothing if no format is requested; note that the index based fields (e.g. the word index map) still refer to the unconverted texts
+func renderResults(results []model.SearchResult, opts *render.Options) {
+	if opts == nil {
+		return
+	}
+	for i := range results {
+		results[i].FmtText = render.Render(results[i].FmtText, *opts)
+		results[i].HighlightText = render.Render(results[i].HighlightText, *opts)
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"testing"
 
 	"github.com/frhorschig/kant-search-api/generated/go/models"
+	"github.com/frhorschig/kant-search-backend/api/search/internal/mapping"
+	"github.com/frhorschig/kant-search-backend/core/search"
 	"github.com/frhorschig/kant-search-backend/core/search/errors"
 	"github.com/frhorschig/kant-search-backend/core/search/mocks"
 	"github.com/frhorschig/kant-search-backend/dataaccess/model"
@@ -28,11 +30,18 @@ func TestSearchHandler(t *testing.T) {
 	sut := NewSearchHandler(searchProcessor).(*searchHandlerImpl)
 
 	for scenario, fn := range map[string]func(t *testing.T, sut *searchHandlerImpl, searchProcessor *mocks.MockSearchProcessor){
-		"Search empty search string": testSearchEmptySearchTerms,
-		"Search empty workCodes":     testSearchEmptyWorkCodes,
-		"Search database error":      testSearchDatabaseError,
-		"Search no result":           testSearchNotFound,
-		"Search success":             testSearchSuccess,
+		"Search empty search string":  testSearchEmptySearchTerms,
+		"Search empty workCodes":      testSearchEmptyWorkCodes,
+		"Search database error":       testSearchDatabaseError,
+		"Search no result":            testSearchNotFound,
+		"Search success":              testSearchSuccess,
+		"Search with format":          testSearchWithFormat,
+		"Search with invalid format":  testSearchInvalidFormat,
+		"Search batch empty batch":    testSearchBatchEmptyBatch,
+		"Search batch too large":      testSearchBatchTooLarge,
+		"Search batch database error": testSearchBatchDatabaseError,
+		"Search batch success":        testSearchBatchSuccess,
+		"Search batch truncated":      testSearchBatchTruncated,
 	} {
 		t.Run(scenario, func(t *testing.T) {
 			fn(t, sut, searchProcessor)
@@ -150,6 +159,168 @@ func testSearchSuccess(t *testing.T, sut *searchHandlerImpl, searchProcessor *mo
 	assert.Contains(t, res.Body.String(), "1")
 }
 
+func testSearchWithFormat(t *testing.T, sut *searchHandlerImpl, searchProcessor *mocks.MockSearchProcessor) {
+	body, err := json.Marshal(models.SearchCriteria{
+		SearchTerms: "text",
+		Options: models.SearchOptions{
+			WorkCodes: []string{"workCode"},
+		}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matches := []model.SearchResult{{
+		HighlightText: "bold <ks-meta-hit>text</ks-meta-hit>",
+		FmtText:       "<ks-fmt-bold>bold</ks-fmt-bold> <ks-meta-page>3</ks-meta-page>text",
+		Pages:         []int32{3},
+		Ordinal:       1,
+		WorkCode:      "workCode",
+	}}
+	// GIVEN
+	req := httptest.NewRequest(echo.POST, "/api/v1/search?format=plain&showPages=false", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	searchProcessor.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any()).Return(matches, errors.Nil())
+	// WHEN
+	sut.Search(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "bold text")
+	assert.NotContains(t, res.Body.String(), "ks-fmt-bold")
+	assert.NotContains(t, res.Body.String(), "ks-meta-hit")
+}
+
+func testSearchInvalidFormat(t *testing.T, sut *searchHandlerImpl, searchProcessor *mocks.MockSearchProcessor) {
+	body, err := json.Marshal(models.SearchCriteria{
+		SearchTerms: "text",
+		Options: models.SearchOptions{
+			WorkCodes: []string{"workCode"},
+		}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// GIVEN
+	req := httptest.NewRequest(echo.POST, "/api/v1/search?format=pdf", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	// WHEN
+	sut.Search(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assertErrorResponse(t, res, "BAD_REQUEST_INVALID_FORMAT")
+}
+
+func testSearchBatchEmptyBatch(t *testing.T, sut *searchHandlerImpl, searchProcessor *mocks.MockSearchProcessor) {
+	body, err := json.Marshal(mapping.BatchSearchCriteria{SearchTerms: []string{}, Options: mapping.SearchOptions{SearchOptions: models.SearchOptions{WorkCodes: []string{"code"}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// GIVEN
+	req := httptest.NewRequest(echo.POST, "/api/v1/search/batch", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	// WHEN
+	sut.SearchBatch(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assertErrorResponse(t, res, string(models.BAD_REQUEST_INVALID_SEARCH_CRITERIA))
+}
+
+func testSearchBatchTooLarge(t *testing.T, sut *searchHandlerImpl, searchProcessor *mocks.MockSearchProcessor) {
+	terms := []string{}
+	for i := 0; i <= maxBatchSizeWithResults; i++ {
+		terms = append(terms, fmt.Sprintf("term%d", i))
+	}
+	body, err := json.Marshal(mapping.BatchSearchCriteria{SearchTerms: terms, Options: mapping.SearchOptions{SearchOptions: models.SearchOptions{WorkCodes: []string{"code"}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// GIVEN
+	req := httptest.NewRequest(echo.POST, "/api/v1/search/batch", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	// WHEN
+	sut.SearchBatch(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assertErrorResponse(t, res, string(models.BAD_REQUEST_INVALID_SEARCH_CRITERIA))
+}
+
+func testSearchBatchDatabaseError(t *testing.T, sut *searchHandlerImpl, searchProcessor *mocks.MockSearchProcessor) {
+	body, err := json.Marshal(mapping.BatchSearchCriteria{SearchTerms: []string{"test"}, Options: mapping.SearchOptions{SearchOptions: models.SearchOptions{WorkCodes: []string{"code"}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// GIVEN
+	req := httptest.NewRequest(echo.POST, "/api/v1/search/batch", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	searchProcessor.EXPECT().SearchBatch(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(nil, fmt.Errorf("database error"))
+	// WHEN
+	sut.SearchBatch(ctx)
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().Status)
+	assertErrorResponse(t, res, "")
+}
+
+func testSearchBatchSuccess(t *testing.T, sut *searchHandlerImpl, searchProcessor *mocks.MockSearchProcessor) {
+	body, err := json.Marshal(mapping.BatchSearchCriteria{
+		SearchTerms: []string{"test", "& wrong", "failing"},
+		Options:     mapping.SearchOptions{SearchOptions: models.SearchOptions{WorkCodes: []string{"workCode"}}},
+		CountOnly:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := []search.BatchResult{
+		{SearchString: "test", Count: 3},
+		{SearchString: "& wrong", SyntaxError: &errors.SyntaxError{Msg: errors.WrongStartingChar, Params: []string{"&"}}},
+		{SearchString: "failing", Error: fmt.Errorf("too many clauses")},
+	}
+	// GIVEN
+	req := httptest.NewRequest(echo.POST, "/api/v1/search/batch", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	searchProcessor.EXPECT().SearchBatch(gomock.Any(), []string{"test", "& wrong", "failing"}, gomock.Any(), true).Return(results, nil)
+	// WHEN
+	sut.SearchBatch(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), `"count":3`)
+	assert.Contains(t, res.Body.String(), string(models.BAD_REQUEST_SYNTAX_WRONG_STARTING_CHAR))
+	assert.Contains(t, res.Body.String(), `"error":{"code":500`)
+}
+
+func testSearchBatchTruncated(t *testing.T, sut *searchHandlerImpl, searchProcessor *mocks.MockSearchProcessor) {
+	body, err := json.Marshal(mapping.BatchSearchCriteria{
+		SearchTerms: []string{"test"},
+		Options:     mapping.SearchOptions{SearchOptions: models.SearchOptions{WorkCodes: []string{"workCode"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := []search.BatchResult{
+		{SearchString: "test", Count: 3, Results: []model.SearchResult{{FmtText: "test", WorkCode: "workCode"}}, Truncated: true},
+	}
+	// GIVEN
+	req := httptest.NewRequest(echo.POST, "/api/v1/search/batch", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, res)
+	searchProcessor.EXPECT().SearchBatch(gomock.Any(), []string{"test"}, gomock.Any(), false).Return(results, nil)
+	// WHEN
+	sut.SearchBatch(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), `"count":3`)
+	assert.Contains(t, res.Body.String(), `"truncated":true`)
+}
+
 func assertErrorResponse(t *testing.T, res *httptest.ResponseRecorder, expectedMsg string) {
 	assert.Contains(t, res.Body.String(), "code")
 	assert.Contains(t, res.Body.String(), "message")
@@ -0,0 +1,16 @@
+package mapping
+
+import "github.com/frhorschig/kant-search-backend/core/stats"
+
+// WordStatsToApiModel returns the statistics with at most limit frequencies
+func WordStatsToApiModel(in stats.WordStats, limit int) WordStats {
+	out := WordStats{
+		TotalWords:    in.TotalWords,
+		DistinctWords: in.DistinctWords,
+		Frequencies:   []WordFrequency{},
+	}
+	for _, f := range in.Frequencies[:min(limit, len(in.Frequencies))] {
+		out.Frequencies = append(out.Frequencies, WordFrequency{Word: f.Word, Count: f.Count})
+	}
+	return out
+}
@@ -0,0 +1,14 @@
+package mapping
+
+// The following types are not (yet) part of the generated API models.
+
+type WordStats struct {
+	TotalWords    int32           `json:"totalWords"`
+	DistinctWords int32           `json:"distinctWords"`
+	Frequencies   []WordFrequency `json:"frequencies"` // the most frequent words, sorted by descending count
+}
+
+type WordFrequency struct {
+	Word  string `json:"word"`
+	Count int32  `json:"count"`
+}
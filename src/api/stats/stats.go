@@ -0,0 +1,136 @@
+package stats
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/frhorschig/kant-search-api/generated/go/models"
+	"github.com/frhorschig/kant-search-backend/api/stats/internal/errors"
+	"github.com/frhorschig/kant-search-backend/api/stats/internal/mapping"
+	"github.com/frhorschig/kant-search-backend/core/stats"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	emptyCodeMsg     = "empty work code"
+	invalidVolumeMsg = "invalid volume number: %v"
+	invalidOptionMsg = "invalid statistics options: lemmatize=%v, filterStopWords=%v, limit=%v"
+)
+
+const (
+	// defaultLimit is the default number of words of the frequency list
+	defaultLimit = 100
+	maxLimit     = 10000
+)
+
+type StatsHandler interface {
+	ReadWorkStats(ctx echo.Context) error
+	ReadVolumeStats(ctx echo.Context) error
+}
+
+type statsHandlerImpl struct {
+	statsProcessor stats.StatsProcessor
+}
+
+func NewStatsHandler(statsProcessor stats.StatsProcessor) StatsHandler {
+	return &statsHandlerImpl{statsProcessor: statsProcessor}
+}
+
+func (rec *statsHandlerImpl) ReadWorkStats(ctx echo.Context) error {
+	workCode := ctx.Param("workCode")
+	if workCode == "" {
+		log.Error().Msg(emptyCodeMsg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, emptyCodeMsg)
+	}
+	options, limit, err := findOptions(ctx)
+	if err != nil {
+		msg := fmt.Sprintf(invalidOptionMsg, ctx.QueryParam("lemmatize"), ctx.QueryParam("filterStopWords"), ctx.QueryParam("limit"))
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+
+	wordStats, err := rec.statsProcessor.ProcessWorkStats(ctx.Request().Context(), workCode, options)
+	if err != nil {
+		log.Error().Err(err).Msgf("error reading word statistics of work %s: %v", workCode, err)
+		return errors.InternalServerError(ctx)
+	}
+	if wordStats == nil {
+		return errors.NotFound(ctx)
+	}
+	return ctx.JSON(http.StatusOK, mapping.WordStatsToApiModel(*wordStats, limit))
+}
+
+func (rec *statsHandlerImpl) ReadVolumeStats(ctx echo.Context) error {
+	volParam := ctx.Param("volumeNumber")
+	volumeNumber, err := findVolumeNumber(volParam)
+	if err != nil {
+		msg := fmt.Sprintf(invalidVolumeMsg, volParam)
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+	options, limit, err := findOptions(ctx)
+	if err != nil {
+		msg := fmt.Sprintf(invalidOptionMsg, ctx.QueryParam("lemmatize"), ctx.QueryParam("filterStopWords"), ctx.QueryParam("limit"))
+		log.Error().Err(err).Msg(msg)
+		return errors.BadRequest(ctx, models.BAD_REQUEST_GENERIC, msg)
+	}
+
+	wordStats, err := rec.statsProcessor.ProcessVolumeStats(ctx.Request().Context(), volumeNumber, options)
+	if err != nil {
+		log.Error().Err(err).Msgf("error reading word statistics of volume %d: %v", volumeNumber, err)
+		return errors.InternalServerError(ctx)
+	}
+	if wordStats == nil {
+		return errors.NotFound(ctx)
+	}
+	return ctx.JSON(http.StatusOK, mapping.WordStatsToApiModel(*wordStats, limit))
+}
+
+func findVolumeNumber(volParam string) (int32, error) {
+	num, err := strconv.ParseInt(volParam, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if num < 1 {
+		return 0, fmt.Errorf("%d is not a positive number", num)
+	}
+	return int32(num), nil
+}
+
+// findOptions returns the statistics options and the maximum number of words of the frequency list; the options are false if they are missing
+func findOptions(ctx echo.Context) (stats.Options, int, error) {
+	options := stats.Options{}
+	var err error
+	options.Lemmatize, err = parseBool(ctx.QueryParam("lemmatize"))
+	if err != nil {
+		return options, 0, err
+	}
+	options.FilterStopWords, err = parseBool(ctx.QueryParam("filterStopWords"))
+	if err != nil {
+		return options, 0, err
+	}
+
+	limitParam := strings.TrimSpace(ctx.QueryParam("limit"))
+	if limitParam == "" {
+		return options, defaultLimit, nil
+	}
+	limit, err := strconv.Atoi(limitParam)
+	if err != nil {
+		return options, 0, err
+	}
+	if limit < 1 || limit > maxLimit {
+		return options, 0, fmt.Errorf("limit %d is not between 1 and %d", limit, maxLimit)
+	}
+	return options, limit, nil
+}
+
+func parseBool(param string) (bool, error) {
+	param = strings.TrimSpace(param)
+	if param == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(param)
+}
@@ -0,0 +1,185 @@
+//go:build unit
+// +build unit
+
+package stats
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/frhorschig/kant-search-backend/core/stats"
+	"github.com/frhorschig/kant-search-backend/core/stats/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	statsProcessor := mocks.NewMockStatsProcessor(ctrl)
+	sut := &statsHandlerImpl{
+		statsProcessor: statsProcessor,
+	}
+
+	for scenario, fn := range map[string]func(*testing.T, *statsHandlerImpl, *mocks.MockStatsProcessor){
+		"Read work stats":                  testReadWorkStats,
+		"Read work stats with options":     testReadWorkStatsWithOptions,
+		"Read work stats with empty code":  testReadWorkStatsEmptyCode,
+		"Read work stats with bad options": testReadWorkStatsBadOptions,
+		"Read work stats with bad limit":   testReadWorkStatsBadLimit,
+		"Read work stats not found":        testReadWorkStatsNotFound,
+		"Read work stats with error":       testReadWorkStatsError,
+		"Read volume stats":                testReadVolumeStats,
+		"Read volume stats with bad value": testReadVolumeStatsBadVolume,
+		"Read volume stats not found":      testReadVolumeStatsNotFound,
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			fn(t, sut, statsProcessor)
+		})
+	}
+}
+
+func testReadWorkStats(t *testing.T, sut *statsHandlerImpl, statsProcessor *mocks.MockStatsProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/GMS/stats", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "workCode", "GMS")
+	statsProcessor.EXPECT().ProcessWorkStats(gomock.Any(), "GMS", stats.Options{}).Return(createStats(), nil)
+	// WHEN
+	sut.ReadWorkStats(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.JSONEq(t, `{"totalWords":5,"distinctWords":3,"frequencies":[{"word":"die","count":3},{"word":"natur","count":1},{"word":"vernunft","count":1}]}`, res.Body.String())
+}
+
+func testReadWorkStatsWithOptions(t *testing.T, sut *statsHandlerImpl, statsProcessor *mocks.MockStatsProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/GMS/stats?lemmatize=true&filterStopWords=true&limit=2", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "workCode", "GMS")
+	statsProcessor.EXPECT().ProcessWorkStats(gomock.Any(), "GMS", stats.Options{Lemmatize: true, FilterStopWords: true}).Return(createStats(), nil)
+	// WHEN
+	sut.ReadWorkStats(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.JSONEq(t, `{"totalWords":5,"distinctWords":3,"frequencies":[{"word":"die","count":3},{"word":"natur","count":1}]}`, res.Body.String())
+}
+
+func testReadWorkStatsEmptyCode(t *testing.T, sut *statsHandlerImpl, statsProcessor *mocks.MockStatsProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works//stats", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "workCode", "")
+	// WHEN
+	sut.ReadWorkStats(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "empty work code")
+}
+
+func testReadWorkStatsBadOptions(t *testing.T, sut *statsHandlerImpl, statsProcessor *mocks.MockStatsProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/GMS/stats?lemmatize=yes", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "workCode", "GMS")
+	// WHEN
+	sut.ReadWorkStats(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "invalid statistics options")
+}
+
+func testReadWorkStatsBadLimit(t *testing.T, sut *statsHandlerImpl, statsProcessor *mocks.MockStatsProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/GMS/stats?limit=0", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "workCode", "GMS")
+	// WHEN
+	sut.ReadWorkStats(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "invalid statistics options")
+}
+
+func testReadWorkStatsNotFound(t *testing.T, sut *statsHandlerImpl, statsProcessor *mocks.MockStatsProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/GMS/stats", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "workCode", "GMS")
+	statsProcessor.EXPECT().ProcessWorkStats(gomock.Any(), "GMS", stats.Options{}).Return(nil, nil)
+	// WHEN
+	sut.ReadWorkStats(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotFound, ctx.Response().Status)
+}
+
+func testReadWorkStatsError(t *testing.T, sut *statsHandlerImpl, statsProcessor *mocks.MockStatsProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/works/GMS/stats", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "workCode", "GMS")
+	statsProcessor.EXPECT().ProcessWorkStats(gomock.Any(), "GMS", stats.Options{}).Return(nil, errors.New("test error"))
+	// WHEN
+	sut.ReadWorkStats(ctx)
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().Status)
+}
+
+func testReadVolumeStats(t *testing.T, sut *statsHandlerImpl, statsProcessor *mocks.MockStatsProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/4/stats?lemmatize=true", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "volumeNumber", "4")
+	statsProcessor.EXPECT().ProcessVolumeStats(gomock.Any(), int32(4), stats.Options{Lemmatize: true}).Return(createStats(), nil)
+	// WHEN
+	sut.ReadVolumeStats(ctx)
+	// THEN
+	assert.Equal(t, http.StatusOK, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), `"totalWords":5`)
+}
+
+func testReadVolumeStatsBadVolume(t *testing.T, sut *statsHandlerImpl, statsProcessor *mocks.MockStatsProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/0/stats", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "volumeNumber", "0")
+	// WHEN
+	sut.ReadVolumeStats(ctx)
+	// THEN
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+	assert.Contains(t, res.Body.String(), "invalid volume number")
+}
+
+func testReadVolumeStatsNotFound(t *testing.T, sut *statsHandlerImpl, statsProcessor *mocks.MockStatsProcessor) {
+	// GIVEN
+	req := httptest.NewRequest(echo.GET, "/api/v1/volumes/4/stats", nil)
+	res := httptest.NewRecorder()
+	ctx := createCtxWithParam(req, res, "volumeNumber", "4")
+	statsProcessor.EXPECT().ProcessVolumeStats(gomock.Any(), int32(4), stats.Options{}).Return(nil, nil)
+	// WHEN
+	sut.ReadVolumeStats(ctx)
+	// THEN
+	assert.Equal(t, http.StatusNotFound, ctx.Response().Status)
+}
+
+func createStats() *stats.WordStats {
+	return &stats.WordStats{
+		TotalWords:    5,
+		DistinctWords: 3,
+		Frequencies: []stats.WordFrequency{
+			{Word: "die", Count: 3},
+			{Word: "natur", Count: 1},
+			{Word: "vernunft", Count: 1},
+		},
+	}
+}
+
+func createCtxWithParam(req *http.Request, res *httptest.ResponseRecorder, name string, value string) echo.Context {
+	ctx := echo.New().NewContext(req, res)
+	ctx.SetParamNames(name)
+	ctx.SetParamValues(value)
+	return ctx
+}
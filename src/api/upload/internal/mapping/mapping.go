@@ -0,0 +1,113 @@
+package mapping
+
+import (
+	"net/http"
+
+	"github.com/frhorschig/kant-search-backend/api/upload/errors"
+	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/frhorschig/kant-search-backend/core/upload"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+)
+
+func JobToApiModel(in upload.Job) UploadJob {
+	out := UploadJob{
+		ID:           in.ID,
+		VolumeNumber: in.VolumeNumber,
+		Status:       string(in.Status),
+		Phase:        string(in.Phase),
+		Progress:     in.Progress,
+		Warnings:     in.Warnings,
+		Created:      in.Created,
+		Finished:     in.Finished,
+	}
+	if out.Warnings == nil {
+		out.Warnings = []string{}
+	}
+	if in.Error != nil {
+		// like for synchronous errors, the details of technical errors are only logged
+		msg := "error processing XML data"
+		code := http.StatusInternalServerError
+		if in.Error.IsDomainError {
+			msg += ": " + in.Error.Message
+			code = http.StatusBadRequest
+		}
+		out.Error = &errors.HttpError{Code: int32(code), Message: msg}
+	}
+	return out
+}
+
+func PreviewToApiModel(in upload.Preview) UploadPreview {
+	out := UploadPreview{
+		VolumeNumber: in.Volume.VolumeNumber,
+		Title:        in.Volume.Title,
+		Version:      in.Volume.Version,
+		Works:        []WorkPreview{},
+		Warnings:     in.Warnings,
+	}
+	if out.Warnings == nil {
+		out.Warnings = []string{}
+	}
+	for _, w := range in.Volume.Works {
+		counts := countsToApiModel(in.Counts[w.Code])
+		paragraphs := w.Paragraphs
+		if paragraphs == nil {
+			paragraphs = []int32{}
+		}
+		out.Works = append(out.Works, WorkPreview{
+			Ordinal:    w.Ordinal,
+			Code:       w.Code,
+			Siglum:     util.StrVal(w.Siglum),
+			Title:      w.Title,
+			Year:       w.Year,
+			Counts:     counts,
+			Paragraphs: paragraphs,
+			Sections:   sectionsToApiModels(w.Sections, in.TocTexts[w.Code]),
+		})
+		out.Counts.Headings += counts.Headings
+		out.Counts.Paragraphs += counts.Paragraphs
+		out.Counts.Footnotes += counts.Footnotes
+		out.Counts.Summaries += counts.Summaries
+	}
+	return out
+}
+
+func countsToApiModel(counts model.ContentCounts) ContentCounts {
+	return ContentCounts{
+		Headings:   counts[model.Heading],
+		Paragraphs: counts[model.Paragraph],
+		Footnotes:  counts[model.Footnote],
+		Summaries:  counts[model.Summary],
+	}
+}
+
+func sectionsToApiModels(in []model.Section, tocTexts map[int32]string) []SectionPreview {
+	out := []SectionPreview{}
+	for _, sIn := range in {
+		paragraphs := sIn.Paragraphs
+		if paragraphs == nil {
+			paragraphs = []int32{}
+		}
+		sOut := SectionPreview{
+			Heading:    sIn.Heading,
+			TocText:    tocTexts[sIn.Heading],
+			Paragraphs: paragraphs,
+			Sections:   sectionsToApiModels(sIn.Sections, tocTexts),
+		}
+		if sIn.Letter != nil {
+			sOut.Letter = &Letter{
+				Number:    sIn.Letter.Number,
+				Sender:    sIn.Letter.Sender,
+				Recipient: sIn.Letter.Recipient,
+				Date:      sIn.Letter.Date,
+			}
+		}
+		if sIn.Reflection != nil {
+			sOut.Reflection = &Reflection{
+				Number: sIn.Reflection.Number,
+				Dating: sIn.Reflection.Dating,
+			}
+		}
+		out = append(out, sOut)
+	}
+	return out
+}
@@ -0,0 +1,147 @@
+//go:build unit
+// +build unit
+
+package mapping
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/frhorschig/kant-search-backend/api/upload/errors"
+	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/frhorschig/kant-search-backend/core/upload"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobToApiModel(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	finished := created.Add(time.Minute)
+	testCases := []struct {
+		name     string
+		in       upload.Job
+		expected UploadJob
+	}{
+		{
+			name:     "queued job",
+			in:       upload.Job{ID: "id", VolumeNumber: 3, Status: upload.Queued, Created: created},
+			expected: UploadJob{ID: "id", VolumeNumber: 3, Status: "queued", Warnings: []string{}, Created: created},
+		},
+		{
+			name:     "running job with warning",
+			in:       upload.Job{ID: "id", VolumeNumber: 3, Status: upload.Running, Phase: "indexing", Progress: 70, Warnings: []string{"warning"}, Created: created},
+			expected: UploadJob{ID: "id", VolumeNumber: 3, Status: "running", Phase: "indexing", Progress: 70, Warnings: []string{"warning"}, Created: created},
+		},
+		{
+			name: "domain error",
+			in: upload.Job{
+				ID: "id", VolumeNumber: 3, Status: upload.Failed, Phase: "treeMapping", Created: created, Finished: &finished,
+				Error: &upload.JobError{IsDomainError: true, Message: "unknown tag"},
+			},
+			expected: UploadJob{
+				ID: "id", VolumeNumber: 3, Status: "failed", Phase: "treeMapping", Warnings: []string{}, Created: created, Finished: &finished,
+				Error: &errors.HttpError{Code: http.StatusBadRequest, Message: "error processing XML data: unknown tag"},
+			},
+		},
+		{
+			name: "technical error",
+			in: upload.Job{
+				ID: "id", VolumeNumber: 3, Status: upload.Failed, Phase: "indexing", Progress: 40, Created: created, Finished: &finished,
+				Error: &upload.JobError{IsDomainError: false, Message: "connection refused"},
+			},
+			expected: UploadJob{
+				ID: "id", VolumeNumber: 3, Status: "failed", Phase: "indexing", Progress: 40, Warnings: []string{}, Created: created, Finished: &finished,
+				Error: &errors.HttpError{Code: http.StatusInternalServerError, Message: "error processing XML data"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, JobToApiModel(tc.in))
+		})
+	}
+}
+
+func TestPreviewToApiModel(t *testing.T) {
+	in := upload.Preview{
+		Volume: model.Volume{
+			VolumeNumber: 10,
+			Title:        "Briefwechsel",
+			Version:      "abc",
+			Works: []model.Work{
+				{
+					Ordinal: 1,
+					Code:    "BW",
+					Siglum:  util.StrPtr("Br"),
+					Title:   "Briefe",
+					Year:    "1747",
+					Sections: []model.Section{{
+						Heading:    1,
+						Paragraphs: []int32{2},
+						Sections:   []model.Section{{Heading: 3}},
+						Letter:     &model.Letter{Number: "12", Sender: "Kant", Recipient: "Lambert", Date: "1765-12-31"},
+					}},
+				},
+				{
+					Ordinal:  2,
+					Code:     "RX",
+					Title:    "Reflexionen",
+					Year:     "1770",
+					Sections: []model.Section{{Heading: 1, Reflection: &model.Reflection{Number: "4275", Dating: "1770-71"}}},
+				},
+			},
+		},
+		TocTexts: map[string]map[int32]string{
+			"BW": {1: "Brief 12", 3: "Nachschrift"},
+			"RX": {1: "Reflexion 4275"},
+		},
+		Counts: map[string]model.ContentCounts{
+			"BW": {model.Heading: 2, model.Paragraph: 1, model.Footnote: 3},
+			"RX": {model.Heading: 1},
+		},
+		Warnings: []string{"the work 'RX' has no paragraphs"},
+	}
+	expected := UploadPreview{
+		VolumeNumber: 10,
+		Title:        "Briefwechsel",
+		Version:      "abc",
+		Works: []WorkPreview{
+			{
+				Ordinal:    1,
+				Code:       "BW",
+				Siglum:     "Br",
+				Title:      "Briefe",
+				Year:       "1747",
+				Counts:     ContentCounts{Headings: 2, Paragraphs: 1, Footnotes: 3},
+				Paragraphs: []int32{},
+				Sections: []SectionPreview{{
+					Heading:    1,
+					TocText:    "Brief 12",
+					Paragraphs: []int32{2},
+					Sections:   []SectionPreview{{Heading: 3, TocText: "Nachschrift", Paragraphs: []int32{}, Sections: []SectionPreview{}}},
+					Letter:     &Letter{Number: "12", Sender: "Kant", Recipient: "Lambert", Date: "1765-12-31"},
+				}},
+			},
+			{
+				Ordinal:    2,
+				Code:       "RX",
+				Title:      "Reflexionen",
+				Year:       "1770",
+				Counts:     ContentCounts{Headings: 1},
+				Paragraphs: []int32{},
+				Sections: []SectionPreview{{
+					Heading:    1,
+					TocText:    "Reflexion 4275",
+					Paragraphs: []int32{},
+					Sections:   []SectionPreview{},
+					Reflection: &Reflection{Number: "4275", Dating: "1770-71"},
+				}},
+			},
+		},
+		Counts:   ContentCounts{Headings: 3, Paragraphs: 1, Footnotes: 3},
+		Warnings: []string{"the work 'RX' has no paragraphs"},
+	}
+
+	assert.Equal(t, expected, PreviewToApiModel(in))
+}
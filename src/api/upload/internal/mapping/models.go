@@ -0,0 +1,71 @@
+package mapping
+
+import (
+	"time"
+
+	"github.com/frhorschig/kant-search-backend/api/upload/errors"
+)
+
+// The following types are not (yet) part of the generated API models.
+
+// UploadJob is the state of the asynchronous processing of an uploaded volume
+type UploadJob struct {
+	ID           string            `json:"id"`
+	VolumeNumber int32             `json:"volumeNumber"`
+	Status       string            `json:"status"`          // "queued", "running", "succeeded" or "failed"
+	Phase        string            `json:"phase,omitempty"` // "treeMapping", "textMapping", "references", "ordering" or "indexing"
+	Progress     int32             `json:"progress"`        // in percent
+	Warnings     []string          `json:"warnings"`
+	Error        *errors.HttpError `json:"error,omitempty"`
+	Created      time.Time         `json:"created"`
+	Finished     *time.Time        `json:"finished,omitempty"`
+}
+
+// UploadPreview is the result of a dry-run upload, the volume as it would be stored
+type UploadPreview struct {
+	VolumeNumber int32         `json:"volumeNumber"`
+	Title        string        `json:"title"`
+	Version      string        `json:"version"`
+	Works        []WorkPreview `json:"works"`
+	Counts       ContentCounts `json:"counts"` // sum of the counts of the works
+	Warnings     []string      `json:"warnings"`
+}
+
+type WorkPreview struct {
+	Ordinal    int32            `json:"ordinal"`
+	Code       string           `json:"code"`
+	Siglum     string           `json:"siglum"`
+	Title      string           `json:"title"`
+	Year       string           `json:"year"`
+	Counts     ContentCounts    `json:"counts"`
+	Paragraphs []int32          `json:"paragraphs"`
+	Sections   []SectionPreview `json:"sections"`
+}
+
+type SectionPreview struct {
+	Heading    int32            `json:"heading"`
+	TocText    string           `json:"tocText"`
+	Paragraphs []int32          `json:"paragraphs"`
+	Sections   []SectionPreview `json:"sections"`
+	Letter     *Letter          `json:"letter,omitempty"`
+	Reflection *Reflection      `json:"reflection,omitempty"`
+}
+
+type Letter struct {
+	Number    string `json:"number"`
+	Sender    string `json:"sender"`
+	Recipient string `json:"recipient"`
+	Date      string `json:"date"`
+}
+
+type Reflection struct {
+	Number string `json:"number"`
+	Dating string `json:"dating"`
+}
+
+type ContentCounts struct {
+	Headings   int32 `json:"headings"`
+	Paragraphs int32 `json:"paragraphs"`
+	Footnotes  int32 `json:"footnotes"`
+	Summaries  int32 `json:"summaries"`
+}
@@ -2,6 +2,7 @@ package upload
 
 import (
 	"bytes"
+	stderrors "errors"
 	"fmt"
 	"html"
 	"io"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/beevik/etree"
 	"github.com/frhorschig/kant-search-backend/api/upload/errors"
+	"github.com/frhorschig/kant-search-backend/api/upload/internal/mapping"
 	"github.com/frhorschig/kant-search-backend/core/upload"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog/log"
@@ -21,19 +23,28 @@ import (
 
 type UploadHandler interface {
 	PostVolume(ctx echo.Context) error
+	ReadJob(ctx echo.Context) error
 }
 
 type uploadHandlerImpl struct {
-	volumeProcessor upload.UploadProcessor
+	uploadProcessor upload.UploadProcessor
+	jobManager      upload.JobManager
 }
 
-func NewUploadHandler(volumeProcessor upload.UploadProcessor) UploadHandler {
+func NewUploadHandler(uploadProcessor upload.UploadProcessor, jobManager upload.JobManager) UploadHandler {
 	return &uploadHandlerImpl{
-		volumeProcessor: volumeProcessor,
+		uploadProcessor: uploadProcessor,
+		jobManager:      jobManager,
 	}
 }
 
 func (rec *uploadHandlerImpl) PostVolume(ctx echo.Context) error {
+	dryRun, err := findDryRun(ctx.QueryParam("dryRun"))
+	if err != nil {
+		msg := fmt.Sprintf("invalid value '%s' of query parameter 'dryRun'", ctx.QueryParam("dryRun"))
+		log.Error().Msg(msg)
+		return errors.JsonError(ctx, http.StatusBadRequest, msg)
+	}
 	body, err := io.ReadAll(ctx.Request().Body)
 	if err != nil {
 		msg := fmt.Sprintf("error reading request body: %v", err.Error())
@@ -56,7 +67,34 @@ func (rec *uploadHandlerImpl) PostVolume(ctx echo.Context) error {
 		return errors.JsonError(ctx, code, msg)
 	}
 
-	if err := rec.volumeProcessor.Process(ctx.Request().Context(), volNum, xml); err.HasError {
+	if dryRun {
+		return rec.previewVolume(ctx, volNum, xml)
+	}
+
+	// the processing of large volumes takes longer than proxies wait for a response, so it is done asynchronously
+	job, err := rec.jobManager.Submit(volNum, xml)
+	if stderrors.Is(err, upload.ErrVolumeInProgress) {
+		msg := fmt.Sprintf("volume %d is already being uploaded", volNum)
+		log.Error().Msg(msg)
+		return errors.JsonError(ctx, http.StatusConflict, msg)
+	} else if stderrors.Is(err, upload.ErrQueueFull) {
+		msg := "too many volumes are being uploaded, try again later"
+		log.Error().Msg(msg)
+		return errors.JsonError(ctx, http.StatusServiceUnavailable, msg)
+	} else if err != nil {
+		msg := "error creating upload job"
+		log.Error().Err(err).Msg(msg)
+		return errors.JsonError(ctx, http.StatusInternalServerError, msg)
+	}
+
+	ctx.Response().Header().Set(echo.HeaderLocation, "/api/v1/upload/jobs/"+job.ID)
+	return ctx.JSON(http.StatusAccepted, mapping.JobToApiModel(job))
+}
+
+// previewVolume maps the volume without storing it, so that the XML can be checked before it is published; it is done synchronously, because the mapping is much faster than the indexing
+func (rec *uploadHandlerImpl) previewVolume(ctx echo.Context, volNum int32, xml string) error {
+	preview, err := rec.uploadProcessor.Preview(ctx.Request().Context(), volNum, xml)
+	if err.HasError {
 		msg := "error processing XML data"
 		if err.DomainError != nil {
 			msg += ": " + err.DomainError.Error()
@@ -67,8 +105,25 @@ func (rec *uploadHandlerImpl) PostVolume(ctx echo.Context) error {
 			return errors.JsonError(ctx, http.StatusInternalServerError, msg)
 		}
 	}
+	return ctx.JSON(http.StatusOK, mapping.PreviewToApiModel(preview))
+}
 
-	return ctx.NoContent(http.StatusCreated)
+func (rec *uploadHandlerImpl) ReadJob(ctx echo.Context) error {
+	id := ctx.Param("id")
+	job, ok := rec.jobManager.Get(id)
+	if !ok {
+		msg := fmt.Sprintf("no upload job with ID '%s'", id)
+		log.Error().Msg(msg)
+		return errors.JsonError(ctx, http.StatusNotFound, msg)
+	}
+	return ctx.JSON(http.StatusOK, mapping.JobToApiModel(job))
+}
+
+func findDryRun(param string) (bool, error) {
+	if param == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(param)
 }
 
 func validateXmlContent(xml string) (int32, int, string) {
@@ -85,7 +140,7 @@ func validateXmlContent(xml string) (int32, int, string) {
 	}
 	nrStr := strings.TrimLeft(band.SelectAttr("nr").Value, "0")
 	if nrStr == "" {
-		msg := "the volume number is 0, but it must be between 1 and 9"
+		msg := "the volume number is 0, but it must be greater than 0"
 		return 0, http.StatusBadRequest, msg
 	}
 	volNum, err := strconv.ParseInt(nrStr, 10, 32)
@@ -94,11 +149,8 @@ func validateXmlContent(xml string) (int32, int, string) {
 		return 0, http.StatusBadRequest, msg
 	}
 	if volNum < 1 {
-		msg := fmt.Sprintf("the volume number is %d, but it must be between 1 and 9", volNum)
+		msg := fmt.Sprintf("the volume number is %d, but it must be greater than 0", volNum)
 		return 0, http.StatusBadRequest, msg
-	} else if volNum > 9 {
-		msg := "uploading volumes greater than 9 is not yet implemented"
-		return 0, http.StatusNotImplemented, msg
 	}
 
 	return int32(volNum), 0, ""
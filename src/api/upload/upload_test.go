@@ -11,7 +11,9 @@ import (
 	"testing"
 
 	"github.com/frhorschig/kant-search-backend/common/errs"
+	"github.com/frhorschig/kant-search-backend/core/upload"
 	procMocks "github.com/frhorschig/kant-search-backend/core/upload/mocks"
+	dbmodel "github.com/frhorschig/kant-search-backend/dataaccess/model"
 	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -31,22 +33,23 @@ func TestUploadHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	volumeProcessor := procMocks.NewMockUploadProcessor(ctrl)
-	sut := NewUploadHandler(volumeProcessor).(*uploadHandlerImpl)
+	uploadProcessor := procMocks.NewMockUploadProcessor(ctrl)
+	jobManager := procMocks.NewMockJobManager(ctrl)
+	sut := NewUploadHandler(uploadProcessor, jobManager).(*uploadHandlerImpl)
 
 	testCases := []struct {
 		name        string
 		xml         string
 		mockSuccess bool
-		mockError   errs.UploadError
+		mockError   error
 		wantCode    int
 		wantMsg     string
 	}{
 		{
-			name:        "Processing success",
+			name:        "Job submitted",
 			xml:         abt1Xml,
 			mockSuccess: true,
-			wantCode:    http.StatusCreated,
+			wantCode:    http.StatusAccepted,
 		},
 		{
 			name:     "Unknown encoding",
@@ -76,33 +79,40 @@ func TestUploadHandler(t *testing.T) {
 			name:     "Volume number is zero",
 			xml:      xmlBase + `<root><band nr="0"></band></root>`,
 			wantCode: http.StatusBadRequest,
-			wantMsg:  "the volume number is 0, but it must be between 1 and 9",
+			wantMsg:  "the volume number is 0, but it must be greater than 0",
 		},
 		{
 			name:     "Volume number too low",
 			xml:      xmlBase + `<root><band nr="-1"></band></root>`,
 			wantCode: http.StatusBadRequest,
-			wantMsg:  "the volume number is -1, but it must be between 1 and 9",
+			wantMsg:  "the volume number is -1, but it must be greater than 0",
 		},
 		{
-			name:     "Volume number too high",
-			xml:      xmlBase + `<root><band nr="10"></band></root>`,
-			wantCode: http.StatusNotImplemented,
-			wantMsg:  "uploading volumes greater than 9 is not yet implemented",
+			name:        "Volume number greater than 9",
+			xml:         xmlBase + `<root><band nr="10"></band></root>`,
+			mockSuccess: true,
+			wantCode:    http.StatusAccepted,
 		},
 		{
-			name:      "Domain error processing XML",
+			name:      "Volume already in progress",
 			xml:       xmlBase + `<root><band nr="5"></band></root>`,
-			mockError: errs.New(errors.New("processing error"), nil),
-			wantCode:  http.StatusBadRequest,
-			wantMsg:   "error processing XML data",
+			mockError: upload.ErrVolumeInProgress,
+			wantCode:  http.StatusConflict,
+			wantMsg:   "volume 5 is already being uploaded",
 		},
 		{
-			name:      "Technical error processing XML",
+			name:      "Queue full",
 			xml:       xmlBase + `<root><band nr="5"></band></root>`,
-			mockError: errs.New(nil, errors.New("processing error")),
+			mockError: upload.ErrQueueFull,
+			wantCode:  http.StatusServiceUnavailable,
+			wantMsg:   "too many volumes are being uploaded",
+		},
+		{
+			name:      "Error creating job",
+			xml:       xmlBase + `<root><band nr="5"></band></root>`,
+			mockError: errors.New("random error"),
 			wantCode:  http.StatusInternalServerError,
-			wantMsg:   "error processing XML data",
+			wantMsg:   "error creating upload job",
 		},
 	}
 
@@ -115,10 +125,10 @@ func TestUploadHandler(t *testing.T) {
 			rec := httptest.NewRecorder()
 			ctx := echo.New().NewContext(req, rec)
 			if tc.mockSuccess {
-				volumeProcessor.EXPECT().Process(gomock.Any(), gomock.Any(), gomock.Any()).Return(errs.Nil())
+				jobManager.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(upload.Job{ID: "abc", Status: upload.Queued}, nil)
 			}
-			if tc.mockError.HasError {
-				volumeProcessor.EXPECT().Process(gomock.Any(), gomock.Any(), gomock.Any()).Return(tc.mockError)
+			if tc.mockError != nil {
+				jobManager.EXPECT().Submit(int32(5), gomock.Any()).Return(upload.Job{}, tc.mockError)
 			}
 
 			// WHEN
@@ -126,8 +136,11 @@ func TestUploadHandler(t *testing.T) {
 
 			// THEN
 
-			if tc.wantCode == http.StatusCreated {
+			if tc.wantCode == http.StatusAccepted {
 				assert.Equal(t, tc.wantCode, rec.Code)
+				assert.Equal(t, "/api/v1/upload/jobs/abc", rec.Header().Get(echo.HeaderLocation))
+				assert.Contains(t, rec.Body.String(), `"id":"abc"`)
+				assert.Contains(t, rec.Body.String(), `"status":"queued"`)
 			} else {
 				assert.Equal(t, tc.wantCode, rec.Code)
 				assert.Contains(t, rec.Body.String(), tc.wantMsg)
@@ -137,6 +150,122 @@ func TestUploadHandler(t *testing.T) {
 	}
 }
 
+func TestUploadHandlerDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	uploadProcessor := procMocks.NewMockUploadProcessor(ctrl)
+	jobManager := procMocks.NewMockJobManager(ctrl)
+	sut := NewUploadHandler(uploadProcessor, jobManager).(*uploadHandlerImpl)
+
+	testCases := []struct {
+		name      string
+		dryRun    string
+		mockCall  bool
+		mockError errs.UploadError
+		wantCode  int
+		wantMsg   string
+	}{
+		{
+			name:     "Preview success",
+			dryRun:   "true",
+			mockCall: true,
+			wantCode: http.StatusOK,
+			wantMsg:  `"warnings":["a warning"]`,
+		},
+		{
+			name:     "Invalid dryRun value",
+			dryRun:   "maybe",
+			wantCode: http.StatusBadRequest,
+			wantMsg:  "invalid value 'maybe' of query parameter 'dryRun'",
+		},
+		{
+			name:      "Domain error processing XML",
+			dryRun:    "true",
+			mockCall:  true,
+			mockError: errs.New(errors.New("processing error"), nil),
+			wantCode:  http.StatusBadRequest,
+			wantMsg:   "error processing XML data: processing error",
+		},
+		{
+			name:      "Technical error processing XML",
+			dryRun:    "true",
+			mockCall:  true,
+			mockError: errs.New(nil, errors.New("processing error")),
+			wantCode:  http.StatusInternalServerError,
+			wantMsg:   "error processing XML data",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// GIVEN
+			req := httptest.NewRequest(echo.POST, "/api/v1/upload?dryRun="+tc.dryRun, bytes.NewReader([]byte(abt1Xml)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationXML)
+			rec := httptest.NewRecorder()
+			ctx := echo.New().NewContext(req, rec)
+			if tc.mockCall {
+				uploadProcessor.EXPECT().Preview(gomock.Any(), int32(1), gomock.Any()).Return(upload.Preview{
+					Volume:   dbmodel.Volume{VolumeNumber: 1},
+					Warnings: []string{"a warning"},
+				}, tc.mockError)
+			}
+
+			// WHEN
+			sut.PostVolume(ctx)
+
+			// THEN
+			assert.Equal(t, tc.wantCode, rec.Code)
+			assert.Contains(t, rec.Body.String(), tc.wantMsg)
+		})
+	}
+}
+
+func TestReadJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	uploadProcessor := procMocks.NewMockUploadProcessor(ctrl)
+	jobManager := procMocks.NewMockJobManager(ctrl)
+	sut := NewUploadHandler(uploadProcessor, jobManager).(*uploadHandlerImpl)
+
+	t.Run("Existing job", func(t *testing.T) {
+		// GIVEN
+		req := httptest.NewRequest(echo.GET, "/api/v1/upload/jobs/abc", nil)
+		rec := httptest.NewRecorder()
+		ctx := echo.New().NewContext(req, rec)
+		ctx.SetParamNames("id")
+		ctx.SetParamValues("abc")
+		jobManager.EXPECT().Get("abc").Return(upload.Job{
+			ID:       "abc",
+			Status:   upload.Failed,
+			Phase:    "treeMapping",
+			Warnings: []string{"a warning"},
+			Error:    &upload.JobError{IsDomainError: true, Message: "unknown tag"},
+		}, true)
+		// WHEN
+		sut.ReadJob(ctx)
+		// THEN
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"phase":"treeMapping"`)
+		assert.Contains(t, rec.Body.String(), `"warnings":["a warning"]`)
+		assert.Contains(t, rec.Body.String(), "error processing XML data: unknown tag")
+	})
+
+	t.Run("Unknown job", func(t *testing.T) {
+		// GIVEN
+		req := httptest.NewRequest(echo.GET, "/api/v1/upload/jobs/xyz", nil)
+		rec := httptest.NewRecorder()
+		ctx := echo.New().NewContext(req, rec)
+		ctx.SetParamNames("id")
+		ctx.SetParamValues("xyz")
+		jobManager.EXPECT().Get("xyz").Return(upload.Job{}, false)
+		// WHEN
+		sut.ReadJob(ctx)
+		// THEN
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), "no upload job with ID 'xyz'")
+	})
+}
+
 func TestReplaceCustomEntities(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -0,0 +1,99 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var tableAttrRegex = regexp.MustCompile(`(colspan|rowspan)="(\d+)"`)
+
+type htmlRenderer struct {
+	sb   strings.Builder
+	epub bool
+}
+
+func (rec *htmlRenderer) text(s string) {
+	rec.sb.WriteString(html.EscapeString(s))
+}
+
+func (rec *htmlRenderer) open(name string, attrs string) {
+	tag, class := htmlTag(name)
+	rec.sb.WriteString("<" + tag)
+	if class != "" {
+		rec.sb.WriteString(fmt.Sprintf(` class="%s"`, class))
+	}
+	if name == "td" {
+		for _, m := range tableAttrRegex.FindAllStringSubmatch(attrs, -1) {
+			rec.sb.WriteString(fmt.Sprintf(` %s="%s"`, m[1], m[2]))
+		}
+	}
+	if lang := langAttr(attrs); name == "ks-fmt-lang" && lang != "" {
+		rec.sb.WriteString(fmt.Sprintf(` lang="%s"`, html.EscapeString(lang)))
+	}
+	rec.sb.WriteString(">")
+}
+
+func (rec *htmlRenderer) close(name string) {
+	tag, _ := htmlTag(name)
+	rec.sb.WriteString("</" + tag + ">")
+}
+
+func (rec *htmlRenderer) page(nr string) {
+	if rec.epub {
+		rec.sb.WriteString(fmt.Sprintf(`<span class="page" epub:type="pagebreak" role="doc-pagebreak" title="%s">[%s]</span>`, nr, nr))
+		return
+	}
+	rec.sb.WriteString(fmt.Sprintf(`<span class="page" data-page="%s">[%s]</span>`, nr, nr))
+}
+
+func (rec *htmlRenderer) line(nr string) {
+	rec.sb.WriteString(fmt.Sprintf(`<span class="line" data-line="%s">%s</span>`, nr, nr))
+}
+
+func (rec *htmlRenderer) fnRef(ref string) {
+	if rec.epub {
+		rec.sb.WriteString(fmt.Sprintf(`<sup class="fnref"><a epub:type="noteref" role="doc-noteref" href="#fn-%s">%s</a></sup>`, ref, ref))
+		return
+	}
+	rec.sb.WriteString(fmt.Sprintf(`<sup class="fnref"><a href="#fn-%s">%s</a></sup>`, ref, ref))
+}
+
+func (rec *htmlRenderer) img(src string, desc string) {
+	if rec.epub {
+		// the images are not part of the stored data, so only their description can be included
+		rec.sb.WriteString(fmt.Sprintf(`<span class="image">%s</span>`, html.EscapeString(desc)))
+		return
+	}
+	rec.sb.WriteString(fmt.Sprintf(`<img src="%s" alt="%s">`, html.EscapeString(src), html.EscapeString(desc)))
+}
+
+func (rec *htmlRenderer) result() string {
+	return rec.sb.String()
+}
+
+// htmlTag returns the HTML tag and the optional CSS class for a tag of the formatted text
+func htmlTag(name string) (string, string) {
+	switch name {
+	case "ks-fmt-bold":
+		return "strong", ""
+	case "ks-fmt-emph":
+		return "em", ""
+	case "ks-fmt-emph2":
+		return "em", "emph2"
+	case "ks-fmt-hpar":
+		return "strong", "par-heading"
+	case "ks-fmt-table":
+		return "table", ""
+	case "ks-meta-hit":
+		return "mark", ""
+	case "tr", "td":
+		return name, ""
+	}
+	if level := headingLevel(name); level > 0 {
+		return fmt.Sprintf("h%d", min(level, 6)), ""
+	}
+	// formula, lang, name, tracked and all other inline formatting
+	return "span", strings.TrimPrefix(name, "ks-fmt-")
+}
@@ -0,0 +1,114 @@
+package render
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var markdownEscapeRegex = regexp.MustCompile("([\\\\`*_\\[\\]<|])")
+
+type markdownRenderer struct {
+	sb    strings.Builder
+	rows  int // number of rows of the current table
+	cells int // number of cells of the current row, including the columns spanned by them
+	span  int // number of columns spanned by the current cell
+}
+
+func (rec *markdownRenderer) text(s string) {
+	s = whitespaceRegex.ReplaceAllString(s, " ")
+	rec.sb.WriteString(markdownEscapeRegex.ReplaceAllString(s, `\$1`))
+}
+
+func (rec *markdownRenderer) open(name string, attrs string) {
+	switch name {
+	case "ks-fmt-bold", "ks-fmt-hpar", "ks-meta-hit":
+		rec.sb.WriteString("**")
+	case "ks-fmt-emph", "ks-fmt-emph2", "ks-fmt-tracked":
+		rec.sb.WriteString("*")
+	case "ks-fmt-table":
+		rec.sb.WriteString("\n\n")
+		rec.rows = 0
+	case "tr":
+		rec.sb.WriteString("|")
+		rec.cells = 0
+	case "td":
+		rec.sb.WriteString(" ")
+		rec.span = colspan(attrs)
+		rec.cells += rec.span
+	default:
+		if level := headingLevel(name); level > 0 {
+			rec.sb.WriteString("\n\n" + strings.Repeat("#", level) + " ")
+		}
+	}
+}
+
+func (rec *markdownRenderer) close(name string) {
+	switch name {
+	case "ks-fmt-bold", "ks-fmt-hpar", "ks-meta-hit":
+		rec.sb.WriteString("**")
+	case "ks-fmt-emph", "ks-fmt-emph2", "ks-fmt-tracked":
+		rec.sb.WriteString("*")
+	case "ks-fmt-table":
+		rec.sb.WriteString("\n")
+	case "tr":
+		rec.sb.WriteString("\n")
+		if rec.rows == 0 {
+			// the first row is used as table header, because tables without header are not supported
+			rec.sb.WriteString("|" + strings.Repeat(" --- |", rec.cells) + "\n")
+		}
+		rec.rows++
+	case "td":
+		// markdown has no spanning cells, so empty cells are added for the spanned columns
+		rec.sb.WriteString(" |" + strings.Repeat(" |", rec.span-1))
+	default:
+		if headingLevel(name) > 0 {
+			rec.sb.WriteString("\n\n")
+		}
+	}
+}
+
+func (rec *markdownRenderer) page(nr string) {
+	rec.sb.WriteString(`\[` + nr + `\]`)
+}
+
+func (rec *markdownRenderer) line(nr string) {
+	rec.sb.WriteString("{" + nr + "}")
+}
+
+func (rec *markdownRenderer) fnRef(ref string) {
+	rec.sb.WriteString("[^" + ref + "]")
+}
+
+func (rec *markdownRenderer) img(src string, desc string) {
+	rec.sb.WriteString("![" + markdownEscapeRegex.ReplaceAllString(desc, `\$1`) + "](" + strings.ReplaceAll(src, " ", "%20") + ")")
+}
+
+func (rec *markdownRenderer) result() string {
+	return collapseSpaces(rec.sb.String())
+}
+
+func colspan(attrs string) int {
+	for _, m := range tableAttrRegex.FindAllStringSubmatch(attrs, -1) {
+		if m[1] == "colspan" {
+			span, err := strconv.Atoi(m[2])
+			if err == nil && span > 0 {
+				return span
+			}
+		}
+	}
+	return 1
+}
+
+var (
+	multiSpaceRegex    = regexp.MustCompile(` {2,}`)
+	newlineSpaceRegex  = regexp.MustCompile(` *\n *`)
+	multiNewlinesRegex = regexp.MustCompile(`\n{3,}`)
+)
+
+// collapseSpaces removes the duplicate spaces and empty lines that are left when tags are rendered without text
+func collapseSpaces(s string) string {
+	s = multiSpaceRegex.ReplaceAllString(s, " ")
+	s = newlineSpaceRegex.ReplaceAllString(s, "\n")
+	return multiNewlinesRegex.ReplaceAllString(s, "\n\n")
+}
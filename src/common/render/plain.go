@@ -0,0 +1,65 @@
+package render
+
+import (
+	"strings"
+)
+
+type plainRenderer struct {
+	sb    strings.Builder
+	cells int // number of cells of the current table row
+}
+
+func (rec *plainRenderer) text(s string) {
+	rec.sb.WriteString(whitespaceRegex.ReplaceAllString(s, " "))
+}
+
+func (rec *plainRenderer) open(name string, attrs string) {
+	switch name {
+	case "ks-fmt-table":
+		rec.sb.WriteString("\n\n")
+	case "tr":
+		rec.cells = 0
+	case "td":
+		if rec.cells > 0 {
+			rec.sb.WriteString("\t")
+		}
+		rec.cells++
+	default:
+		if headingLevel(name) > 0 {
+			rec.sb.WriteString("\n\n")
+		}
+	}
+}
+
+func (rec *plainRenderer) close(name string) {
+	switch name {
+	case "ks-fmt-table":
+		rec.sb.WriteString("\n")
+	case "tr":
+		rec.sb.WriteString("\n")
+	default:
+		if headingLevel(name) > 0 {
+			rec.sb.WriteString("\n\n")
+		}
+	}
+}
+
+func (rec *plainRenderer) page(nr string) {
+	rec.sb.WriteString("[" + nr + "]")
+}
+
+func (rec *plainRenderer) line(nr string) {
+	rec.sb.WriteString("{" + nr + "}")
+}
+
+func (rec *plainRenderer) fnRef(ref string) {
+	rec.sb.WriteString("^" + ref)
+}
+
+func (rec *plainRenderer) img(src string, desc string) {
+	// images are omitted in plain text
+}
+
+func (rec *plainRenderer) result() string {
+	return collapseSpaces(rec.sb.String())
+}
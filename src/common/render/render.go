@@ -0,0 +1,157 @@
+package render
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type Format string
+
+const (
+	Html     Format = "html"
+	Markdown Format = "markdown"
+	Plain    Format = "plain"
+	// Epub is XHTML with the semantic attributes of EPUB 3 content documents; it is only used by the EPUB export and can't be requested in the query parameters
+	Epub Format = "epub"
+	// Tei is the phrase level markup of TEI P5; footnote references are rendered as ptr elements of type noteAnchor, so that the notes can be inserted. It is only used by the TEI export.
+	Tei Format = "tei"
+)
+
+// Options configures how formatted text is rendered; page and line markers and footnote references are only rendered if they are shown
+type Options struct {
+	Format     Format
+	ShowPages  bool
+	ShowLines  bool
+	ShowFnRefs bool
+}
+
+// ParseOptions reads the query parameters format, showPages, showLines and showFnRefs; it returns nil if no format is given, the markers are shown by default
+func ParseOptions(query url.Values) (*Options, error) {
+	format := strings.TrimSpace(query.Get("format"))
+	if format == "" {
+		return nil, nil
+	}
+	opts := Options{Format: Format(format)}
+	if opts.Format != Html && opts.Format != Markdown && opts.Format != Plain {
+		return nil, fmt.Errorf("unknown format %s", format)
+	}
+
+	var err error
+	opts.ShowPages, err = parseBool(query.Get("showPages"))
+	if err != nil {
+		return nil, err
+	}
+	opts.ShowLines, err = parseBool(query.Get("showLines"))
+	if err != nil {
+		return nil, err
+	}
+	opts.ShowFnRefs, err = parseBool(query.Get("showFnRefs"))
+	if err != nil {
+		return nil, err
+	}
+	return &opts, nil
+}
+
+func parseBool(param string) (bool, error) {
+	param = strings.TrimSpace(param)
+	if param == "" {
+		return true, nil
+	}
+	return strconv.ParseBool(param)
+}
+
+// the groups of the regex are: page, line, fnref, imgref src, imgref desc, closing slash, tag name, tag attributes
+var tagRegex = regexp.MustCompile(
+	`<ks-meta-page>(\d+)</ks-meta-page>` +
+		`|<ks-meta-line>(\d+)</ks-meta-line>` +
+		`|<ks-meta-fnref>(\d+\.\d+)</ks-meta-fnref>` +
+		`|<ks-meta-imgref src="([^"]*)" desc="([^"]*)"/>` +
+		`|<(/?)(ks-fmt-[a-z0-9]+|ks-meta-hit|tr|td)((?:\s+[a-z]+="[^"]*")*)\s*>`,
+)
+
+var whitespaceRegex = regexp.MustCompile(`\s+`)
+
+// langAttrRegex matches the language attribute of ks-fmt-lang tags
+var langAttrRegex = regexp.MustCompile(`\blang="([^"]*)"`)
+
+// langAttr returns the language of the attributes of a ks-fmt-lang tag, or an empty string if there is none
+func langAttr(attrs string) string {
+	m := langAttrRegex.FindStringSubmatch(attrs)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// Render converts a text with the ks-fmt-* and ks-meta-* tags of the upload (and the ks-meta-hit tags of search highlights) into the given format; unknown tags are treated as text
+func Render(fmtText string, opts Options) string {
+	var r renderer
+	switch opts.Format {
+	case Html:
+		r = &htmlRenderer{}
+	case Epub:
+		r = &htmlRenderer{epub: true}
+	case Tei:
+		r = &teiRenderer{}
+	case Markdown:
+		r = &markdownRenderer{}
+	default:
+		r = &plainRenderer{}
+	}
+
+	last := 0
+	for _, m := range tagRegex.FindAllStringSubmatchIndex(fmtText, -1) {
+		r.text(fmtText[last:m[0]])
+		last = m[1]
+		group := func(i int) string {
+			if m[2*i] < 0 {
+				return ""
+			}
+			return fmtText[m[2*i]:m[2*i+1]]
+		}
+		switch {
+		case m[2] >= 0:
+			if opts.ShowPages {
+				r.page(group(1))
+			}
+		case m[4] >= 0:
+			if opts.ShowLines {
+				r.line(group(2))
+			}
+		case m[6] >= 0:
+			if opts.ShowFnRefs {
+				r.fnRef(group(3))
+			}
+		case m[8] >= 0:
+			r.img(group(4), group(5))
+		case group(6) == "/":
+			r.close(group(7))
+		default:
+			r.open(group(7), group(8))
+		}
+	}
+	r.text(fmtText[last:])
+	return strings.TrimSpace(r.result())
+}
+
+type renderer interface {
+	text(s string)
+	open(name string, attrs string)
+	close(name string)
+	page(nr string)
+	line(nr string)
+	fnRef(ref string)
+	img(src string, desc string)
+	result() string
+}
+
+func headingLevel(name string) int {
+	level, err := strconv.Atoi(strings.TrimPrefix(name, "ks-fmt-h"))
+	if err != nil {
+		return 0
+	}
+	return level
+}
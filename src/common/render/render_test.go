@@ -0,0 +1,181 @@
+//go:build unit
+// +build unit
+
+package render
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	allMarkers := Options{ShowPages: true, ShowLines: true, ShowFnRefs: true}
+	testCases := []struct {
+		name     string
+		input    string
+		format   Format
+		opts     Options
+		expected string
+	}{
+		{
+			name:     "html with inline formatting and escaping",
+			input:    `<ks-fmt-hpar>§ 1.</ks-fmt-hpar> Die <ks-fmt-emph>Vernunft</ks-fmt-emph> & <ks-fmt-name>Hume</ks-fmt-name>`,
+			format:   Html,
+			opts:     allMarkers,
+			expected: `<strong class="par-heading">§ 1.</strong> Die <em>Vernunft</em> &amp; <span class="name">Hume</span>`,
+		},
+		{
+			name:     "html with foreign language",
+			input:    `Die <ks-fmt-lang lang="lat" alphabet="latin">ratio</ks-fmt-lang> und <ks-fmt-lang>logos</ks-fmt-lang>`,
+			format:   Html,
+			opts:     allMarkers,
+			expected: `Die <span class="lang" lang="lat">ratio</span> und <span class="lang">logos</span>`,
+		},
+		{
+			name:     "tei with foreign language",
+			input:    `Die <ks-fmt-lang lang="lat" alphabet="latin">ratio</ks-fmt-lang>, <ks-fmt-lang lang="Gr">λόγος</ks-fmt-lang>, <ks-fmt-lang lang="xyz">x</ks-fmt-lang> und <ks-fmt-lang>logos</ks-fmt-lang>`,
+			format:   Tei,
+			opts:     allMarkers,
+			expected: `Die <foreign xml:lang="la">ratio</foreign>, <foreign xml:lang="grc">λόγος</foreign>, <foreign xml:lang="xyz">x</foreign> und <foreign>logos</foreign>`,
+		},
+		{
+			name:     "html with markers",
+			input:    `<ks-meta-page>421</ks-meta-page>Text<ks-meta-fnref>421.1</ks-meta-fnref> <ks-meta-line>5</ks-meta-line>more <ks-meta-imgref src="img.png" desc="a figure"/>`,
+			format:   Html,
+			opts:     allMarkers,
+			expected: `<span class="page" data-page="421">[421]</span>Text<sup class="fnref"><a href="#fn-421.1">421.1</a></sup> <span class="line" data-line="5">5</span>more <img src="img.png" alt="a figure">`,
+		},
+		{
+			name:     "html with hidden markers",
+			input:    `<ks-meta-page>421</ks-meta-page>Text<ks-meta-fnref>421.1</ks-meta-fnref> <ks-meta-line>5</ks-meta-line>more`,
+			format:   Html,
+			opts:     Options{},
+			expected: `Text more`,
+		},
+		{
+			name:     "html heading, table and search hit",
+			input:    `<ks-fmt-h8>Title</ks-fmt-h8><ks-fmt-table><tr><td colspan="2" onclick="x">a</td></tr></ks-fmt-table><ks-meta-hit>hit</ks-meta-hit>`,
+			format:   Html,
+			opts:     allMarkers,
+			expected: `<h6>Title</h6><table><tr><td colspan="2">a</td></tr></table><mark>hit</mark>`,
+		},
+		{
+			name:     "epub with markers",
+			input:    `<ks-meta-page>421</ks-meta-page>Text<ks-meta-fnref>421.1</ks-meta-fnref> <ks-meta-imgref src="img.png" desc="a figure"/>`,
+			format:   Epub,
+			opts:     allMarkers,
+			expected: `<span class="page" epub:type="pagebreak" role="doc-pagebreak" title="421">[421]</span>Text<sup class="fnref"><a epub:type="noteref" role="doc-noteref" href="#fn-421.1">421.1</a></sup> <span class="image">a figure</span>`,
+		},
+		{
+			name:     "tei with markers and formatting",
+			input:    `<ks-fmt-h1>Title</ks-fmt-h1><ks-meta-page>421</ks-meta-page><ks-fmt-tracked>Text</ks-fmt-tracked><ks-meta-fnref>421.1</ks-meta-fnref> <ks-meta-line>5</ks-meta-line>a & b`,
+			format:   Tei,
+			opts:     allMarkers,
+			expected: `Title<pb n="421"/><hi rend="spaced">Text</hi><ptr type="noteAnchor" target="#fn-421.1"/> <lb n="5"/>a &amp; b`,
+		},
+		{
+			name:     "tei table and image",
+			input:    `<ks-fmt-table><tr><td rowspan="2">a</td></tr></ks-fmt-table><ks-meta-imgref src="img.png" desc="a figure"/>`,
+			format:   Tei,
+			opts:     allMarkers,
+			expected: `<table><row><cell rows="2">a</cell></row></table><figure><graphic url="img.png"/><figDesc>a figure</figDesc></figure>`,
+		},
+		{
+			name:     "markdown with inline formatting and escaping",
+			input:    "<ks-fmt-bold>Kritik</ks-fmt-bold> der *reinen*\n  <ks-fmt-emph2>Vernunft</ks-fmt-emph2>",
+			format:   Markdown,
+			opts:     allMarkers,
+			expected: `**Kritik** der \*reinen\* *Vernunft*`,
+		},
+		{
+			name:     "markdown with markers",
+			input:    `<ks-meta-page>421</ks-meta-page>Text<ks-meta-fnref>421.1</ks-meta-fnref> <ks-meta-line>5</ks-meta-line>more <ks-meta-imgref src="img.png" desc="a figure"/>`,
+			format:   Markdown,
+			opts:     allMarkers,
+			expected: `\[421\]Text[^421.1] {5}more ![a figure](img.png)`,
+		},
+		{
+			name:     "markdown heading and table",
+			input:    `<ks-fmt-h2>Title</ks-fmt-h2><ks-fmt-table><tr><td colspan="2">a</td></tr><tr><td>b</td><td>c|d</td></tr></ks-fmt-table>`,
+			format:   Markdown,
+			opts:     allMarkers,
+			expected: "## Title\n\n| a | |\n| --- | --- |\n| b | c\\|d |",
+		},
+		{
+			name:     "plain with markers",
+			input:    `<ks-fmt-h1>Title</ks-fmt-h1><ks-meta-page>421</ks-meta-page><ks-fmt-tracked>Text</ks-fmt-tracked><ks-meta-fnref>421.1</ks-meta-fnref> <ks-meta-line>5</ks-meta-line>more<ks-meta-imgref src="img.png" desc="a figure"/>`,
+			format:   Plain,
+			opts:     allMarkers,
+			expected: "Title\n\n[421]Text^421.1 {5}more",
+		},
+		{
+			name:     "plain with hidden markers and table",
+			input:    "Text <ks-meta-page>421</ks-meta-page> more\n<ks-fmt-table><tr><td>a</td><td>b</td></tr></ks-fmt-table>",
+			format:   Plain,
+			opts:     Options{},
+			expected: "Text more\n\na\tb",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// GIVEN
+			tc.opts.Format = tc.format
+			// WHEN
+			result := Render(tc.input, tc.opts)
+			// THEN
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		expected *Options
+		hasError bool
+	}{
+		{
+			name:     "no format",
+			query:    "showPages=false",
+			expected: nil,
+		},
+		{
+			name:     "format with default markers",
+			query:    "format=html",
+			expected: &Options{Format: Html, ShowPages: true, ShowLines: true, ShowFnRefs: true},
+		},
+		{
+			name:     "format with hidden markers",
+			query:    "format=plain&showPages=false&showLines=false&showFnRefs=true",
+			expected: &Options{Format: Plain, ShowPages: false, ShowLines: false, ShowFnRefs: true},
+		},
+		{
+			name:     "unknown format",
+			query:    "format=pdf",
+			hasError: true,
+		},
+		{
+			name:     "invalid marker option",
+			query:    "format=markdown&showLines=maybe",
+			hasError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// GIVEN
+			query, _ := url.ParseQuery(tc.query)
+			// WHEN
+			opts, err := ParseOptions(query)
+			// THEN
+			if tc.hasError {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, opts)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// bcp47ByLang maps the (lowercased) German abbreviations of the sprache attribute to BCP 47 language tags; Greek is ancient Greek in Kant's texts
+var bcp47ByLang = map[string]string{
+	"lat":    "la",
+	"gr":     "grc",
+	"griech": "grc",
+	"franz":  "fr",
+	"frz":    "fr",
+	"engl":   "en",
+	"ital":   "it",
+	"span":   "es",
+	"holl":   "nl",
+	"hebr":   "he",
+	"dt":     "de",
+}
+
+// bcp47Lang returns the BCP 47 tag of a language of the sprache attribute, unknown languages are returned lowercased
+func bcp47Lang(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if tag, ok := bcp47ByLang[lang]; ok {
+		return tag
+	}
+	return lang
+}
+
+type teiRenderer struct {
+	sb strings.Builder
+}
+
+func (rec *teiRenderer) text(s string) {
+	rec.sb.WriteString(html.EscapeString(s))
+}
+
+func (rec *teiRenderer) open(name string, attrs string) {
+	if name == "td" {
+		rec.sb.WriteString("<cell")
+		for _, m := range tableAttrRegex.FindAllStringSubmatch(attrs, -1) {
+			attr := "cols"
+			if m[1] == "rowspan" {
+				attr = "rows"
+			}
+			rec.sb.WriteString(fmt.Sprintf(` %s="%s"`, attr, m[2]))
+		}
+		rec.sb.WriteString(">")
+		return
+	}
+	if lang := langAttr(attrs); name == "ks-fmt-lang" && lang != "" {
+		rec.sb.WriteString(fmt.Sprintf(`<foreign xml:lang="%s">`, html.EscapeString(bcp47Lang(lang))))
+		return
+	}
+	if tag := teiTag(name); tag != "" {
+		rec.sb.WriteString("<" + tag + ">")
+	}
+}
+
+func (rec *teiRenderer) close(name string) {
+	if name == "td" {
+		rec.sb.WriteString("</cell>")
+		return
+	}
+	if tag := teiTag(name); tag != "" {
+		rec.sb.WriteString("</" + strings.Fields(tag)[0] + ">")
+	}
+}
+
+func (rec *teiRenderer) page(nr string) {
+	rec.sb.WriteString(fmt.Sprintf(`<pb n="%s"/>`, nr))
+}
+
+func (rec *teiRenderer) line(nr string) {
+	rec.sb.WriteString(fmt.Sprintf(`<lb n="%s"/>`, nr))
+}
+
+func (rec *teiRenderer) fnRef(ref string) {
+	rec.sb.WriteString(fmt.Sprintf(`<ptr type="noteAnchor" target="#fn-%s"/>`, ref))
+}
+
+func (rec *teiRenderer) img(src string, desc string) {
+	rec.sb.WriteString(fmt.Sprintf(`<figure><graphic url="%s"/><figDesc>%s</figDesc></figure>`, html.EscapeString(src), html.EscapeString(desc)))
+}
+
+func (rec *teiRenderer) result() string {
+	return rec.sb.String()
+}
+
+// teiTag returns the TEI start tag (without brackets) for a tag of the formatted text, or an empty string if the tag has no TEI equivalent
+func teiTag(name string) string {
+	switch name {
+	case "ks-fmt-bold":
+		return `hi rend="bold"`
+	case "ks-fmt-emph":
+		return "emph"
+	case "ks-fmt-emph2":
+		return `emph rend="emph2"`
+	case "ks-fmt-tracked":
+		return `hi rend="spaced"`
+	case "ks-fmt-formula":
+		return "formula"
+	case "ks-fmt-lang":
+		return "foreign"
+	case "ks-fmt-name":
+		return "name"
+	case "ks-fmt-hpar":
+		return `seg type="parHeading"`
+	case "ks-fmt-table":
+		return "table"
+	case "tr":
+		return "row"
+	}
+	// headings are represented by the enclosing head element, search hits are not part of the text
+	return ""
+}
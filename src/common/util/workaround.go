@@ -16,6 +16,15 @@ func FalsePtr() *bool {
 	return &f
 }
 
+func TruePtr() *bool {
+	t := true
+	return &t
+}
+
 func IntPtr(i int) *int {
 	return &i
 }
+
+func Int32Ptr(i int32) *int32 {
+	return &i
+}
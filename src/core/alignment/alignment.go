@@ -0,0 +1,251 @@
+package alignment
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/alignment_mock.go -package=mocks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/frhorschig/kant-search-backend/core/alignment/internal/diff"
+	"github.com/frhorschig/kant-search-backend/core/alignment/internal/matching"
+	"github.com/frhorschig/kant-search-backend/dataaccess"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+)
+
+// alignedWorks are the pairs of works whose paragraphs are aligned, the alignment is stored in the order of the pair
+var alignedWorks = [][2]string{
+	{"KRV_A", "KRV_B"}, // the first and the second edition of the Critique of Pure Reason
+}
+
+type DiffType string
+
+const (
+	Equal  DiffType = "equal"
+	Insert DiffType = "insert"
+	Delete DiffType = "delete"
+)
+
+// DiffOp is a part of a word diff; the text consists of one or more whitespace separated words
+type DiffOp struct {
+	Type DiffType
+	Text string
+}
+
+// AlignedParagraphs is a paragraph of a work and the matching paragraph of the other work; one of them is nil if a paragraph has no match. The diff changes the text of the paragraph into the text of the other paragraph, so it contains the text of both paragraphs.
+type AlignedParagraphs struct {
+	Paragraph      *model.Content
+	OtherParagraph *model.Content
+	Similarity     float64
+	Diff           []DiffOp
+}
+
+// AlignmentPage is a page of the aligned paragraphs of two works in reading order; NextCursor is the cursor of the next page, or nil if this is the last page
+type AlignmentPage struct {
+	WorkCode      string
+	OtherWorkCode string
+	Paragraphs    []AlignedParagraphs
+	NextCursor    *int32
+}
+
+// AlignmentProcessor matches the paragraphs of two works by their text similarity, e.g. of two editions of the same work
+type AlignmentProcessor interface {
+	// ProcessAlignment returns nil if the work or the other work doesn't exist or if the work has no aligned work; the cursor is the index of the first aligned pair of the page
+	ProcessAlignment(ctx context.Context, workCode string, cursor int32, limit int) (*AlignmentPage, error)
+}
+
+type alignmentProcessorImpl struct {
+	volumeRepo    dataaccess.VolumeRepo
+	contentRepo   dataaccess.ContentRepo
+	alignmentRepo dataaccess.AlignmentRepo
+}
+
+func NewAlignmentProcessor(volumeRepo dataaccess.VolumeRepo, contentRepo dataaccess.ContentRepo, alignmentRepo dataaccess.AlignmentRepo) AlignmentProcessor {
+	processor := alignmentProcessorImpl{
+		volumeRepo:    volumeRepo,
+		contentRepo:   contentRepo,
+		alignmentRepo: alignmentRepo,
+	}
+	return &processor
+}
+
+func (rec *alignmentProcessorImpl) ProcessAlignment(ctx context.Context, workCode string, cursor int32, limit int) (*AlignmentPage, error) {
+	codes, swapped := findAlignedWorks(workCode)
+	if codes == nil {
+		return nil, nil
+	}
+	version, ok, err := rec.findVersion(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	alignment, err := rec.alignmentRepo.Get(ctx, codes[0], codes[1])
+	if err != nil {
+		return nil, err
+	}
+	if alignment == nil || version == "" || alignment.Version != version {
+		alignment, err = rec.createAlignment(ctx, codes, version)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	page := AlignmentPage{WorkCode: codes[0], OtherWorkCode: codes[1], Paragraphs: []AlignedParagraphs{}}
+	start := min(int(cursor), len(alignment.Pairs))
+	end := min(start+limit, len(alignment.Pairs))
+	if end < len(alignment.Pairs) {
+		page.NextCursor = util.Int32Ptr(int32(end))
+	}
+	pairs := alignment.Pairs[start:end]
+	paragraphs, err := rec.findParagraphs(ctx, codes[0], pairs, func(p model.AlignedPair) *int32 { return p.Ordinal })
+	if err != nil {
+		return nil, err
+	}
+	otherParagraphs, err := rec.findParagraphs(ctx, codes[1], pairs, func(p model.AlignedPair) *int32 { return p.OtherOrdinal })
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pairs {
+		aligned := AlignedParagraphs{Similarity: p.Similarity}
+		if p.Ordinal != nil {
+			aligned.Paragraph = paragraphs[*p.Ordinal]
+		}
+		if p.OtherOrdinal != nil {
+			aligned.OtherParagraph = otherParagraphs[*p.OtherOrdinal]
+		}
+		if swapped {
+			aligned.Paragraph, aligned.OtherParagraph = aligned.OtherParagraph, aligned.Paragraph
+		}
+		aligned.Diff = createDiff(aligned.Paragraph, aligned.OtherParagraph)
+		page.Paragraphs = append(page.Paragraphs, aligned)
+	}
+	if swapped {
+		page.WorkCode, page.OtherWorkCode = page.OtherWorkCode, page.WorkCode
+	}
+	return &page, nil
+}
+
+// findAlignedWorks returns the codes of the aligned works in the order in which the alignment is stored, and true if the given work is the second of them
+func findAlignedWorks(workCode string) ([]string, bool) {
+	for _, codes := range alignedWorks {
+		if codes[0] == workCode {
+			return codes[:], false
+		}
+		if codes[1] == workCode {
+			return codes[:], true
+		}
+	}
+	return nil, false
+}
+
+// findVersion returns false if one of the works doesn't exist; the version is empty if one of the volumes has no version
+func (rec *alignmentProcessorImpl) findVersion(ctx context.Context, codes []string) (string, bool, error) {
+	versions := []string{}
+	for _, code := range codes {
+		volume, err := rec.volumeRepo.GetByWorkCode(ctx, code)
+		if err != nil {
+			return "", false, err
+		}
+		if volume == nil {
+			return "", false, nil
+		}
+		if volume.Version == "" {
+			return "", true, nil
+		}
+		versions = append(versions, volume.Version)
+	}
+	return fmt.Sprintf("%s-%s", versions[0], versions[1]), true, nil
+}
+
+// createAlignment aligns the paragraphs of the works and stores the alignment; an alignment without a version is not stored, because it couldn't be recognized as outdated
+func (rec *alignmentProcessorImpl) createAlignment(ctx context.Context, codes []string, version string) (*model.Alignment, error) {
+	paragraphs, err := rec.contentRepo.GetByWork(ctx, codes[0], []model.Type{model.Paragraph}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	otherParagraphs, err := rec.contentRepo.GetByWork(ctx, codes[1], []model.Type{model.Paragraph}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	alignment := model.Alignment{
+		WorkCode:      codes[0],
+		OtherWorkCode: codes[1],
+		Version:       version,
+		Pairs:         []model.AlignedPair{},
+	}
+	for _, p := range matching.Align(searchTexts(paragraphs), searchTexts(otherParagraphs)) {
+		pair := model.AlignedPair{Similarity: p.Similarity}
+		if p.Index >= 0 {
+			pair.Ordinal = util.Int32Ptr(paragraphs[p.Index].Ordinal)
+		}
+		if p.OtherIndex >= 0 {
+			pair.OtherOrdinal = util.Int32Ptr(otherParagraphs[p.OtherIndex].Ordinal)
+		}
+		alignment.Pairs = append(alignment.Pairs, pair)
+	}
+
+	if version != "" {
+		err = rec.alignmentRepo.Insert(ctx, &alignment)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &alignment, nil
+}
+
+// findParagraphs returns the paragraphs of the pairs by their ordinal; only the paragraphs between the lowest and the highest ordinal are read, because the pairs are sorted by the ordinals of both works
+func (rec *alignmentProcessorImpl) findParagraphs(ctx context.Context, workCode string, pairs []model.AlignedPair, ordinal func(model.AlignedPair) *int32) (map[int32]*model.Content, error) {
+	var from, to *int32
+	for _, p := range pairs {
+		if ord := ordinal(p); ord != nil {
+			if from == nil {
+				from = ord
+			}
+			to = ord
+		}
+	}
+	result := make(map[int32]*model.Content)
+	if from == nil {
+		return result, nil
+	}
+	paragraphs, err := rec.contentRepo.GetByWork(ctx, workCode, []model.Type{model.Paragraph}, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for i := range paragraphs {
+		result[paragraphs[i].Ordinal] = &paragraphs[i]
+	}
+	for _, p := range pairs {
+		if ord := ordinal(p); ord != nil && result[*ord] == nil {
+			return nil, fmt.Errorf("missing paragraph %d of work %s", *ord, workCode)
+		}
+	}
+	return result, nil
+}
+
+func searchTexts(contents []model.Content) []string {
+	texts := []string{}
+	for _, c := range contents {
+		texts = append(texts, c.SearchText)
+	}
+	return texts
+}
+
+func createDiff(paragraph *model.Content, otherParagraph *model.Content) []DiffOp {
+	text, otherText := "", ""
+	if paragraph != nil {
+		text = paragraph.SearchText
+	}
+	if otherParagraph != nil {
+		otherText = otherParagraph.SearchText
+	}
+	ops := []DiffOp{}
+	for _, op := range diff.Words(text, otherText) {
+		ops = append(ops, DiffOp{Type: DiffType(op.Type), Text: op.Text})
+	}
+	return ops
+}
@@ -0,0 +1,252 @@
+//go:build unit
+// +build unit
+
+package alignment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/frhorschig/kant-search-backend/dataaccess/mocks"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var paragraphType = []model.Type{model.Paragraph}
+
+var paragraphsA = []model.Content{
+	{Ordinal: 2, SearchText: "Die Vernunft urtheilt über sich selbst"},
+	{Ordinal: 3, SearchText: "nur in der ersten Auflage"},
+}
+
+var paragraphsB = []model.Content{
+	{Ordinal: 5, SearchText: "Die Vernunft urteilt über sich selbst"},
+	{Ordinal: 6, SearchText: "ganz neuer Text der zweiten Auflage"},
+}
+
+var storedAlignment = model.Alignment{
+	WorkCode:      "KRV_A",
+	OtherWorkCode: "KRV_B",
+	Version:       "a-b",
+	Pairs: []model.AlignedPair{
+		{Ordinal: util.Int32Ptr(2), OtherOrdinal: util.Int32Ptr(5), Similarity: 5.0 / 6.0},
+		{Ordinal: util.Int32Ptr(3)},
+		{OtherOrdinal: util.Int32Ptr(6)},
+	},
+}
+
+func TestAlignmentProcessor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	volumeRepo := mocks.NewMockVolumeRepo(ctrl)
+	contentRepo := mocks.NewMockContentRepo(ctrl)
+	alignmentRepo := mocks.NewMockAlignmentRepo(ctrl)
+	sut := &alignmentProcessorImpl{
+		volumeRepo:    volumeRepo,
+		contentRepo:   contentRepo,
+		alignmentRepo: alignmentRepo,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	for scenario, fn := range map[string]func(*testing.T, *alignmentProcessorImpl, *mocks.MockVolumeRepo, *mocks.MockContentRepo, *mocks.MockAlignmentRepo, context.Context){
+		"Process alignment":                        testProcessAlignment,
+		"Process alignment of the second work":     testProcessAlignmentSecondWork,
+		"Process alignment with next page":         testProcessAlignmentNextPage,
+		"Process alignment with cursor at the end": testProcessAlignmentCursorAtEnd,
+		"Process alignment with outdated version":  testProcessAlignmentOutdated,
+		"Process alignment without version":        testProcessAlignmentWithoutVersion,
+		"Process alignment with unaligned work":    testProcessAlignmentUnalignedWork,
+		"Process alignment with unknown work":      testProcessAlignmentUnknownWork,
+		"Process alignment with missing paragraph": testProcessAlignmentMissingParagraph,
+		"Process alignment with error":             testProcessAlignmentError,
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			fn(t, sut, volumeRepo, contentRepo, alignmentRepo, ctx)
+		})
+	}
+}
+
+func testProcessAlignment(t *testing.T, sut *alignmentProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, alignmentRepo *mocks.MockAlignmentRepo, ctx context.Context) {
+	// GIVEN
+	expectVersions(volumeRepo, "a", "b")
+	alignmentRepo.EXPECT().Get(gomock.Any(), "KRV_A", "KRV_B").Return(&storedAlignment, nil)
+	contentRepo.EXPECT().GetByWork(gomock.Any(), "KRV_A", paragraphType, util.Int32Ptr(2), util.Int32Ptr(3)).Return(paragraphsA, nil)
+	contentRepo.EXPECT().GetByWork(gomock.Any(), "KRV_B", paragraphType, util.Int32Ptr(5), util.Int32Ptr(6)).Return(paragraphsB, nil)
+	// WHEN
+	res, err := sut.ProcessAlignment(ctx, "KRV_A", 0, 10)
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, "KRV_A", res.WorkCode)
+	assert.Equal(t, "KRV_B", res.OtherWorkCode)
+	assert.Nil(t, res.NextCursor)
+	assert.Len(t, res.Paragraphs, 3)
+
+	assert.Equal(t, int32(2), res.Paragraphs[0].Paragraph.Ordinal)
+	assert.Equal(t, int32(5), res.Paragraphs[0].OtherParagraph.Ordinal)
+	assert.InDelta(t, 5.0/6.0, res.Paragraphs[0].Similarity, 0.0001)
+	assert.Equal(t, []DiffOp{
+		{Type: Equal, Text: "Die Vernunft"},
+		{Type: Delete, Text: "urtheilt"},
+		{Type: Insert, Text: "urteilt"},
+		{Type: Equal, Text: "über sich selbst"},
+	}, res.Paragraphs[0].Diff)
+
+	assert.Equal(t, int32(3), res.Paragraphs[1].Paragraph.Ordinal)
+	assert.Nil(t, res.Paragraphs[1].OtherParagraph)
+	assert.Equal(t, []DiffOp{{Type: Delete, Text: "nur in der ersten Auflage"}}, res.Paragraphs[1].Diff)
+
+	assert.Nil(t, res.Paragraphs[2].Paragraph)
+	assert.Equal(t, int32(6), res.Paragraphs[2].OtherParagraph.Ordinal)
+	assert.Equal(t, []DiffOp{{Type: Insert, Text: "ganz neuer Text der zweiten Auflage"}}, res.Paragraphs[2].Diff)
+}
+
+func testProcessAlignmentSecondWork(t *testing.T, sut *alignmentProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, alignmentRepo *mocks.MockAlignmentRepo, ctx context.Context) {
+	// GIVEN
+	expectVersions(volumeRepo, "a", "b")
+	alignmentRepo.EXPECT().Get(gomock.Any(), "KRV_A", "KRV_B").Return(&storedAlignment, nil)
+	contentRepo.EXPECT().GetByWork(gomock.Any(), "KRV_A", paragraphType, util.Int32Ptr(2), util.Int32Ptr(2)).Return(paragraphsA[:1], nil)
+	contentRepo.EXPECT().GetByWork(gomock.Any(), "KRV_B", paragraphType, util.Int32Ptr(5), util.Int32Ptr(5)).Return(paragraphsB[:1], nil)
+	// WHEN
+	res, err := sut.ProcessAlignment(ctx, "KRV_B", 0, 1)
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, "KRV_B", res.WorkCode)
+	assert.Equal(t, "KRV_A", res.OtherWorkCode)
+	assert.Len(t, res.Paragraphs, 1)
+	assert.Equal(t, int32(5), res.Paragraphs[0].Paragraph.Ordinal)
+	assert.Equal(t, int32(2), res.Paragraphs[0].OtherParagraph.Ordinal)
+	assert.Equal(t, []DiffOp{
+		{Type: Equal, Text: "Die Vernunft"},
+		{Type: Delete, Text: "urteilt"},
+		{Type: Insert, Text: "urtheilt"},
+		{Type: Equal, Text: "über sich selbst"},
+	}, res.Paragraphs[0].Diff)
+}
+
+func testProcessAlignmentNextPage(t *testing.T, sut *alignmentProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, alignmentRepo *mocks.MockAlignmentRepo, ctx context.Context) {
+	// GIVEN
+	expectVersions(volumeRepo, "a", "b")
+	alignmentRepo.EXPECT().Get(gomock.Any(), "KRV_A", "KRV_B").Return(&storedAlignment, nil)
+	contentRepo.EXPECT().GetByWork(gomock.Any(), "KRV_A", paragraphType, util.Int32Ptr(3), util.Int32Ptr(3)).Return(paragraphsA[1:], nil)
+	// WHEN
+	res, err := sut.ProcessAlignment(ctx, "KRV_A", 1, 1)
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, util.Int32Ptr(2), res.NextCursor)
+	assert.Len(t, res.Paragraphs, 1)
+	assert.Equal(t, int32(3), res.Paragraphs[0].Paragraph.Ordinal)
+	assert.Nil(t, res.Paragraphs[0].OtherParagraph)
+}
+
+func testProcessAlignmentCursorAtEnd(t *testing.T, sut *alignmentProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, alignmentRepo *mocks.MockAlignmentRepo, ctx context.Context) {
+	// GIVEN
+	expectVersions(volumeRepo, "a", "b")
+	alignmentRepo.EXPECT().Get(gomock.Any(), "KRV_A", "KRV_B").Return(&storedAlignment, nil)
+	// WHEN
+	res, err := sut.ProcessAlignment(ctx, "KRV_A", 5, 10)
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res.NextCursor)
+	assert.Empty(t, res.Paragraphs)
+}
+
+func testProcessAlignmentOutdated(t *testing.T, sut *alignmentProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, alignmentRepo *mocks.MockAlignmentRepo, ctx context.Context) {
+	outdated := storedAlignment
+	outdated.Version = "a-old"
+	var inserted *model.Alignment
+	// GIVEN
+	expectVersions(volumeRepo, "a", "b")
+	alignmentRepo.EXPECT().Get(gomock.Any(), "KRV_A", "KRV_B").Return(&outdated, nil)
+	contentRepo.EXPECT().GetByWork(gomock.Any(), "KRV_A", paragraphType, nil, nil).Return(paragraphsA, nil)
+	contentRepo.EXPECT().GetByWork(gomock.Any(), "KRV_B", paragraphType, nil, nil).Return(paragraphsB, nil)
+	alignmentRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, data *model.Alignment) error {
+		inserted = data
+		return nil
+	})
+	contentRepo.EXPECT().GetByWork(gomock.Any(), "KRV_A", paragraphType, util.Int32Ptr(2), util.Int32Ptr(3)).Return(paragraphsA, nil)
+	contentRepo.EXPECT().GetByWork(gomock.Any(), "KRV_B", paragraphType, util.Int32Ptr(5), util.Int32Ptr(6)).Return(paragraphsB, nil)
+	// WHEN
+	res, err := sut.ProcessAlignment(ctx, "KRV_A", 0, 10)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, res.Paragraphs, 3)
+	assert.Equal(t, "KRV_A", inserted.WorkCode)
+	assert.Equal(t, "KRV_B", inserted.OtherWorkCode)
+	assert.Equal(t, "a-b", inserted.Version)
+	assert.Len(t, inserted.Pairs, 3)
+	assert.Equal(t, util.Int32Ptr(2), inserted.Pairs[0].Ordinal)
+	assert.Equal(t, util.Int32Ptr(5), inserted.Pairs[0].OtherOrdinal)
+	assert.InDelta(t, 5.0/6.0, inserted.Pairs[0].Similarity, 0.0001)
+	assert.Equal(t, util.Int32Ptr(3), inserted.Pairs[1].Ordinal)
+	assert.Nil(t, inserted.Pairs[1].OtherOrdinal)
+	assert.Nil(t, inserted.Pairs[2].Ordinal)
+	assert.Equal(t, util.Int32Ptr(6), inserted.Pairs[2].OtherOrdinal)
+}
+
+func testProcessAlignmentWithoutVersion(t *testing.T, sut *alignmentProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, alignmentRepo *mocks.MockAlignmentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "KRV_A").Return(&model.Volume{Version: ""}, nil)
+	alignmentRepo.EXPECT().Get(gomock.Any(), "KRV_A", "KRV_B").Return(nil, nil)
+	contentRepo.EXPECT().GetByWork(gomock.Any(), "KRV_A", paragraphType, nil, nil).Return(paragraphsA[:1], nil)
+	contentRepo.EXPECT().GetByWork(gomock.Any(), "KRV_B", paragraphType, nil, nil).Return(paragraphsB[:1], nil)
+	contentRepo.EXPECT().GetByWork(gomock.Any(), "KRV_A", paragraphType, util.Int32Ptr(2), util.Int32Ptr(2)).Return(paragraphsA[:1], nil)
+	contentRepo.EXPECT().GetByWork(gomock.Any(), "KRV_B", paragraphType, util.Int32Ptr(5), util.Int32Ptr(5)).Return(paragraphsB[:1], nil)
+	// WHEN
+	res, err := sut.ProcessAlignment(ctx, "KRV_A", 0, 10)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, res.Paragraphs, 1)
+}
+
+func testProcessAlignmentUnalignedWork(t *testing.T, sut *alignmentProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, alignmentRepo *mocks.MockAlignmentRepo, ctx context.Context) {
+	// WHEN
+	res, err := sut.ProcessAlignment(ctx, "GMS", 0, 10)
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessAlignmentUnknownWork(t *testing.T, sut *alignmentProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, alignmentRepo *mocks.MockAlignmentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "KRV_A").Return(&model.Volume{Version: "a"}, nil)
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "KRV_B").Return(nil, nil)
+	// WHEN
+	res, err := sut.ProcessAlignment(ctx, "KRV_A", 0, 10)
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessAlignmentMissingParagraph(t *testing.T, sut *alignmentProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, alignmentRepo *mocks.MockAlignmentRepo, ctx context.Context) {
+	// GIVEN
+	expectVersions(volumeRepo, "a", "b")
+	alignmentRepo.EXPECT().Get(gomock.Any(), "KRV_A", "KRV_B").Return(&storedAlignment, nil)
+	contentRepo.EXPECT().GetByWork(gomock.Any(), "KRV_A", paragraphType, util.Int32Ptr(2), util.Int32Ptr(3)).Return(paragraphsA[:1], nil)
+	// WHEN
+	res, err := sut.ProcessAlignment(ctx, "KRV_A", 0, 10)
+	// THEN
+	assert.NotNil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessAlignmentError(t *testing.T, sut *alignmentProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, alignmentRepo *mocks.MockAlignmentRepo, ctx context.Context) {
+	e := errors.New("test error")
+	// GIVEN
+	expectVersions(volumeRepo, "a", "b")
+	alignmentRepo.EXPECT().Get(gomock.Any(), "KRV_A", "KRV_B").Return(nil, e)
+	// WHEN
+	res, err := sut.ProcessAlignment(ctx, "KRV_A", 0, 10)
+	// THEN
+	assert.Equal(t, e, err)
+	assert.Nil(t, res)
+}
+
+func expectVersions(volumeRepo *mocks.MockVolumeRepo, versionA string, versionB string) {
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "KRV_A").Return(&model.Volume{Version: versionA}, nil)
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "KRV_B").Return(&model.Volume{Version: versionB}, nil)
+}
@@ -0,0 +1,88 @@
+package diff
+
+import "strings"
+
+type OpType string
+
+const (
+	Equal  OpType = "equal"
+	Insert OpType = "insert"
+	Delete OpType = "delete"
+)
+
+// Op is a part of a diff; the text of an operation consists of one or more whitespace separated words
+type Op struct {
+	Type OpType
+	Text string
+}
+
+// Words returns the operations that change the text into the other text, word by word. The words are separated by whitespace, so a changed punctuation mark changes the whole word. Consecutive words with the same operation are joined, and a deletion comes before the insertion that replaces it.
+func Words(text string, otherText string) []Op {
+	a, b := strings.Fields(text), strings.Fields(otherText)
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := []Op{}
+	ops = appendWords(ops, Equal, a[:prefix])
+	ops = appendChanges(ops, a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	ops = appendWords(ops, Equal, a[len(a)-suffix:])
+	return ops
+}
+
+// appendChanges appends the operations of the longest common subsequence of the words
+func appendChanges(ops []Op, a []string, b []string) []Op {
+	n, m := len(a), len(b)
+	// lcs[i*(m+1)+j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([]int32, (n+1)*(m+1))
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i*(m+1)+j] = lcs[(i+1)*(m+1)+j+1] + 1
+			} else {
+				lcs[i*(m+1)+j] = max(lcs[(i+1)*(m+1)+j], lcs[i*(m+1)+j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = appendWords(ops, Equal, a[i:i+1])
+			i, j = i+1, j+1
+		case lcs[(i+1)*(m+1)+j] >= lcs[i*(m+1)+j+1]:
+			ops = appendWords(ops, Delete, a[i:i+1])
+			i++
+		default:
+			ops = appendWords(ops, Insert, b[j:j+1])
+			j++
+		}
+	}
+	ops = appendWords(ops, Delete, a[i:])
+	ops = appendWords(ops, Insert, b[j:])
+	return ops
+}
+
+func appendWords(ops []Op, opType OpType, words []string) []Op {
+	if len(words) == 0 {
+		return ops
+	}
+	text := strings.Join(words, " ")
+	if len(ops) > 0 && ops[len(ops)-1].Type == opType {
+		ops[len(ops)-1].Text += " " + text
+		return ops
+	}
+	// a deletion directly before an insertion is moved in front of preceding insertions, so that replaced words are always deleted first
+	if opType == Delete && len(ops) > 0 && ops[len(ops)-1].Type == Insert {
+		insert := ops[len(ops)-1]
+		ops = appendWords(ops[:len(ops)-1], Delete, words)
+		return append(ops, insert)
+	}
+	return append(ops, Op{Type: opType, Text: text})
+}
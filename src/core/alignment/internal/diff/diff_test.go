@@ -0,0 +1,82 @@
+//go:build unit
+// +build unit
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWords(t *testing.T) {
+	testCases := []struct {
+		name      string
+		text      string
+		otherText string
+		expected  []Op
+	}{
+		{
+			name:      "equal texts",
+			text:      "Die reine Vernunft",
+			otherText: "Die  reine\nVernunft",
+			expected:  []Op{{Type: Equal, Text: "Die reine Vernunft"}},
+		},
+		{
+			name:      "replaced word",
+			text:      "Die Vernunft urtheilt über sich",
+			otherText: "Die Vernunft urteilt über sich",
+			expected: []Op{
+				{Type: Equal, Text: "Die Vernunft"},
+				{Type: Delete, Text: "urtheilt"},
+				{Type: Insert, Text: "urteilt"},
+				{Type: Equal, Text: "über sich"},
+			},
+		},
+		{
+			name:      "inserted and deleted words",
+			text:      "a b c d",
+			otherText: "a x y c d e",
+			expected: []Op{
+				{Type: Equal, Text: "a"},
+				{Type: Delete, Text: "b"},
+				{Type: Insert, Text: "x y"},
+				{Type: Equal, Text: "c d"},
+				{Type: Insert, Text: "e"},
+			},
+		},
+		{
+			name:      "changed punctuation",
+			text:      "Raum und Zeit.",
+			otherText: "Raum und Zeit;",
+			expected: []Op{
+				{Type: Equal, Text: "Raum und"},
+				{Type: Delete, Text: "Zeit."},
+				{Type: Insert, Text: "Zeit;"},
+			},
+		},
+		{
+			name:      "empty text",
+			text:      "",
+			otherText: "a b",
+			expected:  []Op{{Type: Insert, Text: "a b"}},
+		},
+		{
+			name:      "empty other text",
+			text:      "a b",
+			otherText: "",
+			expected:  []Op{{Type: Delete, Text: "a b"}},
+		},
+		{
+			name:      "empty texts",
+			text:      "",
+			otherText: "",
+			expected:  []Op{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Words(tc.text, tc.otherText))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package matching
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// MinSimilarity is the minimal similarity of two matching paragraphs
+const MinSimilarity = 0.5
+
+// Pair is the index of a text and the index of the matching other text; one of the indices is -1 if a text has no match
+type Pair struct {
+	Index      int
+	OtherIndex int
+	Similarity float64
+}
+
+type direction byte
+
+const (
+	diagonal direction = iota
+	up
+	left
+)
+
+// Align matches the texts with the other texts, keeping the order of both. Only 1:1 matches are found, so a text that was split into two other texts matches only one of them.
+// The matching maximizes the sum of the similarities (minus MinSimilarity) of the matched texts; this is the Needleman-Wunsch algorithm without gap costs.
+func Align(texts []string, otherTexts []string) []Pair {
+	words := wordIds(texts, otherTexts)
+	n, m := len(texts), len(otherTexts)
+
+	// only the back pointers are stored for all cells, the scores just for the current and the previous row
+	back := make([][]direction, n+1)
+	prev := make([]float64, m+1)
+	curr := make([]float64, m+1)
+	for i := range back {
+		back[i] = make([]direction, m+1)
+		if i == 0 {
+			for j := 1; j <= m; j++ {
+				back[0][j] = left
+			}
+			continue
+		}
+		back[i][0] = up
+		curr[0] = 0
+		for j := 1; j <= m; j++ {
+			// on ties the text without match is preferred to the other text without match, because the pairs are found backwards
+			curr[j], back[i][j] = curr[j-1], left
+			if prev[j] > curr[j] {
+				curr[j], back[i][j] = prev[j], up
+			}
+			sim := similarity(words[i-1], words[n+j-1])
+			if sim >= MinSimilarity && prev[j-1]+sim-MinSimilarity >= curr[j] {
+				curr[j], back[i][j] = prev[j-1]+sim-MinSimilarity, diagonal
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	pairs := []Pair{}
+	for i, j := n, m; i > 0 || j > 0; {
+		switch back[i][j] {
+		case diagonal:
+			pairs = append(pairs, Pair{Index: i - 1, OtherIndex: j - 1, Similarity: similarity(words[i-1], words[n+j-1])})
+			i, j = i-1, j-1
+		case up:
+			pairs = append(pairs, Pair{Index: i - 1, OtherIndex: -1})
+			i--
+		case left:
+			pairs = append(pairs, Pair{Index: -1, OtherIndex: j - 1})
+			j--
+		}
+	}
+	slices.Reverse(pairs)
+	return pairs
+}
+
+// wordIds returns the sorted word ids of the texts followed by those of the other texts; the same words have the same ids, so two texts can be compared by merging their ids
+func wordIds(texts []string, otherTexts []string) [][]int32 {
+	ids := make(map[string]int32)
+	result := [][]int32{}
+	for _, text := range slices.Concat(texts, otherTexts) {
+		textIds := []int32{}
+		for _, w := range Words(text) {
+			id, ok := ids[strings.ToLower(w)]
+			if !ok {
+				id = int32(len(ids))
+				ids[strings.ToLower(w)] = id
+			}
+			textIds = append(textIds, id)
+		}
+		slices.Sort(textIds)
+		result = append(result, textIds)
+	}
+	return result
+}
+
+// similarity is the Dice coefficient of the word multisets of two texts, i.e. 1 for texts with the same words and 0 for texts without common words
+func similarity(a []int32, b []int32) float64 {
+	if len(a)+len(b) == 0 {
+		return 1
+	}
+	common := 0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			common++
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return 2 * float64(common) / float64(len(a)+len(b))
+}
+
+// Words splits a text into words; punctuation is not part of the words
+func Words(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,107 @@
+//go:build unit
+// +build unit
+
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlign(t *testing.T) {
+	testCases := []struct {
+		name       string
+		texts      []string
+		otherTexts []string
+		expected   []Pair
+	}{
+		{
+			name:       "identical texts",
+			texts:      []string{"erster Absatz", "zweiter Absatz"},
+			otherTexts: []string{"erster Absatz", "zweiter Absatz"},
+			expected: []Pair{
+				{Index: 0, OtherIndex: 0, Similarity: 1},
+				{Index: 1, OtherIndex: 1, Similarity: 1},
+			},
+		},
+		{
+			name:       "changed spelling and punctuation",
+			texts:      []string{"Die Vernunft urtheilt über sich selbst."},
+			otherTexts: []string{"Die Vernunft urteilt, über sich selbst"},
+			expected:   []Pair{{Index: 0, OtherIndex: 0, Similarity: 5.0 / 6.0}},
+		},
+		{
+			name:       "inserted and removed texts",
+			texts:      []string{"a b c d", "nur in der ersten Auflage", "e f g h"},
+			otherTexts: []string{"a b c d", "e f g h", "nur in der zweiten Auflage hinzugefügt"},
+			expected: []Pair{
+				{Index: 0, OtherIndex: 0, Similarity: 1},
+				{Index: 1, OtherIndex: -1},
+				{Index: 2, OtherIndex: 1, Similarity: 1},
+				{Index: -1, OtherIndex: 2},
+			},
+		},
+		{
+			name:       "dissimilar texts",
+			texts:      []string{"a b c d"},
+			otherTexts: []string{"a x y z"},
+			expected: []Pair{
+				{Index: 0, OtherIndex: -1},
+				{Index: -1, OtherIndex: 0},
+			},
+		},
+		{
+			name:       "reordered texts keep the order",
+			texts:      []string{"a b c d", "e f g h"},
+			otherTexts: []string{"e f g h", "a b c d"},
+			expected: []Pair{
+				{Index: 0, OtherIndex: -1},
+				{Index: 1, OtherIndex: 0, Similarity: 1},
+				{Index: -1, OtherIndex: 1},
+			},
+		},
+		{
+			name:       "no other texts",
+			texts:      []string{"a"},
+			otherTexts: []string{},
+			expected:   []Pair{{Index: 0, OtherIndex: -1}},
+		},
+		{
+			name:       "no texts",
+			texts:      []string{},
+			otherTexts: []string{},
+			expected:   []Pair{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := Align(tc.texts, tc.otherTexts)
+			assert.Len(t, actual, len(tc.expected))
+			for i := range tc.expected {
+				assert.Equal(t, tc.expected[i].Index, actual[i].Index)
+				assert.Equal(t, tc.expected[i].OtherIndex, actual[i].OtherIndex)
+				assert.InDelta(t, tc.expected[i].Similarity, actual[i].Similarity, 0.0001)
+			}
+		})
+	}
+}
+
+func TestAlignPrefersBetterMatch(t *testing.T) {
+	// the first text matches both other texts, but the second one better
+	texts := []string{"a b c d e f"}
+	otherTexts := []string{"a b c d x y", "a b c d e f"}
+
+	actual := Align(texts, otherTexts)
+
+	assert.Equal(t, []Pair{
+		{Index: -1, OtherIndex: 0},
+		{Index: 0, OtherIndex: 1, Similarity: 1},
+	}, actual)
+}
+
+func TestWords(t *testing.T) {
+	assert.Equal(t, []string{"Raum", "und", "Zeit"}, Words("Raum, und Zeit."))
+	assert.Equal(t, []string{"1"}, Words("(§ 1)"))
+	assert.Empty(t, Words(" ,.; "))
+}
@@ -0,0 +1,121 @@
+package export
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/export_mock.go -package=mocks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/frhorschig/kant-search-backend/core/export/internal/epub"
+	"github.com/frhorschig/kant-search-backend/core/export/internal/tei"
+	"github.com/frhorschig/kant-search-backend/core/read"
+	"github.com/frhorschig/kant-search-backend/dataaccess"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+)
+
+// ExportProcessor creates files of works and volumes for offline reading; the methods return nil if the work or volume doesn't exist
+type ExportProcessor interface {
+	ProcessWorkEpub(ctx context.Context, workCode string) ([]byte, error)
+	ProcessVolumeEpub(ctx context.Context, volumeNumber int32) ([]byte, error)
+	ProcessWorkTei(ctx context.Context, workCode string) ([]byte, error)
+	ProcessVolumeTei(ctx context.Context, volumeNumber int32) ([]byte, error)
+}
+
+type exportProcessorImpl struct {
+	volumeRepo    dataaccess.VolumeRepo
+	readProcessor read.ReadProcessor
+}
+
+func NewExportProcessor(volumeRepo dataaccess.VolumeRepo, readProcessor read.ReadProcessor) ExportProcessor {
+	processor := exportProcessorImpl{
+		volumeRepo:    volumeRepo,
+		readProcessor: readProcessor,
+	}
+	return &processor
+}
+
+func (rec *exportProcessorImpl) ProcessWorkEpub(ctx context.Context, workCode string) ([]byte, error) {
+	text, err := rec.readProcessor.ProcessWorkText(ctx, workCode, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if text == nil {
+		return nil, nil
+	}
+	return epub.Build(epub.Book{
+		Identifier: "urn:kant-search:work:" + workCode,
+		Title:      text.Work.Title,
+		Modified:   time.Now(),
+		Works:      []read.WorkText{*text},
+	})
+}
+
+func (rec *exportProcessorImpl) ProcessVolumeEpub(ctx context.Context, volumeNumber int32) ([]byte, error) {
+	volume, works, err := rec.findVolumeTexts(ctx, volumeNumber)
+	if err != nil {
+		return nil, err
+	}
+	if volume == nil {
+		return nil, nil
+	}
+	return epub.Build(epub.Book{
+		Identifier: fmt.Sprintf("urn:kant-search:volume:%d", volumeNumber),
+		Title:      volume.Title,
+		Modified:   time.Now(),
+		Works:      works,
+	})
+}
+
+func (rec *exportProcessorImpl) ProcessWorkTei(ctx context.Context, workCode string) ([]byte, error) {
+	text, err := rec.readProcessor.ProcessWorkText(ctx, workCode, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if text == nil {
+		return nil, nil
+	}
+	return tei.Build(tei.Document{
+		Title:  text.Work.Title,
+		Source: "Akademie-Ausgabe",
+		Works:  []read.WorkText{*text},
+	}), nil
+}
+
+func (rec *exportProcessorImpl) ProcessVolumeTei(ctx context.Context, volumeNumber int32) ([]byte, error) {
+	volume, works, err := rec.findVolumeTexts(ctx, volumeNumber)
+	if err != nil {
+		return nil, err
+	}
+	if volume == nil {
+		return nil, nil
+	}
+	return tei.Build(tei.Document{
+		Title:  volume.Title,
+		Source: fmt.Sprintf("Akademie-Ausgabe, Band %d", volumeNumber),
+		Works:  works,
+	}), nil
+}
+
+func (rec *exportProcessorImpl) findVolumeTexts(ctx context.Context, volumeNumber int32) (*model.Volume, []read.WorkText, error) {
+	volume, err := rec.volumeRepo.GetByVolumeNumber(ctx, volumeNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	if volume == nil {
+		return nil, nil, nil
+	}
+
+	works := []read.WorkText{}
+	for _, w := range volume.Works {
+		text, err := rec.readProcessor.ProcessWorkText(ctx, w.Code, nil, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		if text == nil {
+			return nil, nil, fmt.Errorf("missing text of work %s in volume %d", w.Code, volumeNumber)
+		}
+		works = append(works, *text)
+	}
+	return volume, works, nil
+}
@@ -0,0 +1,220 @@
+//go:build unit
+// +build unit
+
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frhorschig/kant-search-backend/core/read"
+	readMocks "github.com/frhorschig/kant-search-backend/core/read/mocks"
+	dbMocks "github.com/frhorschig/kant-search-backend/dataaccess/mocks"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportProcessor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	volumeRepo := dbMocks.NewMockVolumeRepo(ctrl)
+	readProcessor := readMocks.NewMockReadProcessor(ctrl)
+	sut := &exportProcessorImpl{
+		volumeRepo:    volumeRepo,
+		readProcessor: readProcessor,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	for scenario, fn := range map[string]func(*testing.T, *exportProcessorImpl, *dbMocks.MockVolumeRepo, *readMocks.MockReadProcessor, context.Context){
+		"Process work epub":                   testProcessWorkEpub,
+		"Process work epub with unknown work": testProcessWorkEpubUnknownWork,
+		"Process work epub with error":        testProcessWorkEpubError,
+		"Process volume epub":                 testProcessVolumeEpub,
+		"Process volume epub unknown volume":  testProcessVolumeEpubUnknownVolume,
+		"Process volume epub with error":      testProcessVolumeEpubError,
+		"Process work tei":                    testProcessWorkTei,
+		"Process work tei with unknown work":  testProcessWorkTeiUnknownWork,
+		"Process volume tei":                  testProcessVolumeTei,
+		"Process volume tei unknown volume":   testProcessVolumeTeiUnknownVolume,
+		"Process volume tei with error":       testProcessVolumeTeiError,
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			fn(t, sut, volumeRepo, readProcessor, ctx)
+		})
+	}
+}
+
+func testProcessWorkEpub(t *testing.T, sut *exportProcessorImpl, volumeRepo *dbMocks.MockVolumeRepo, readProcessor *readMocks.MockReadProcessor, ctx context.Context) {
+	// GIVEN
+	text := createWorkText("GMS", "Grundlegung")
+	readProcessor.EXPECT().ProcessWorkText(gomock.Any(), "GMS", gomock.Nil(), gomock.Nil()).Return(&text, nil)
+	// WHEN
+	res, err := sut.ProcessWorkEpub(ctx, "GMS")
+	// THEN
+	assert.Nil(t, err)
+	files := unzip(t, res)
+	assert.Contains(t, files, "OEBPS/work001.xhtml")
+	assert.NotContains(t, files, "OEBPS/work002.xhtml")
+}
+
+func testProcessWorkEpubUnknownWork(t *testing.T, sut *exportProcessorImpl, volumeRepo *dbMocks.MockVolumeRepo, readProcessor *readMocks.MockReadProcessor, ctx context.Context) {
+	// GIVEN
+	readProcessor.EXPECT().ProcessWorkText(gomock.Any(), "GMS", gomock.Nil(), gomock.Nil()).Return(nil, nil)
+	// WHEN
+	res, err := sut.ProcessWorkEpub(ctx, "GMS")
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessWorkEpubError(t *testing.T, sut *exportProcessorImpl, volumeRepo *dbMocks.MockVolumeRepo, readProcessor *readMocks.MockReadProcessor, ctx context.Context) {
+	e := errors.New("test error")
+	// GIVEN
+	readProcessor.EXPECT().ProcessWorkText(gomock.Any(), "GMS", gomock.Nil(), gomock.Nil()).Return(nil, e)
+	// WHEN
+	res, err := sut.ProcessWorkEpub(ctx, "GMS")
+	// THEN
+	assert.Equal(t, e, err)
+	assert.Nil(t, res)
+}
+
+func testProcessVolumeEpub(t *testing.T, sut *exportProcessorImpl, volumeRepo *dbMocks.MockVolumeRepo, readProcessor *readMocks.MockReadProcessor, ctx context.Context) {
+	// GIVEN
+	volume := model.Volume{
+		VolumeNumber: 4,
+		Title:        "Band IV",
+		Works:        []model.Work{{Code: "GMS"}, {Code: "MAN"}},
+	}
+	gms := createWorkText("GMS", "Grundlegung")
+	man := createWorkText("MAN", "Metaphysische Anfangsgründe")
+	volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(4)).Return(&volume, nil)
+	readProcessor.EXPECT().ProcessWorkText(gomock.Any(), "GMS", gomock.Nil(), gomock.Nil()).Return(&gms, nil)
+	readProcessor.EXPECT().ProcessWorkText(gomock.Any(), "MAN", gomock.Nil(), gomock.Nil()).Return(&man, nil)
+	// WHEN
+	res, err := sut.ProcessVolumeEpub(ctx, 4)
+	// THEN
+	assert.Nil(t, err)
+	files := unzip(t, res)
+	assert.Contains(t, files["OEBPS/content.opf"], "<dc:title>Band IV</dc:title>")
+	assert.Contains(t, files["OEBPS/work001.xhtml"], "Grundlegung")
+	assert.Contains(t, files["OEBPS/work002.xhtml"], "Metaphysische Anfangsgründe")
+}
+
+func testProcessVolumeEpubUnknownVolume(t *testing.T, sut *exportProcessorImpl, volumeRepo *dbMocks.MockVolumeRepo, readProcessor *readMocks.MockReadProcessor, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(4)).Return(nil, nil)
+	// WHEN
+	res, err := sut.ProcessVolumeEpub(ctx, 4)
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessVolumeEpubError(t *testing.T, sut *exportProcessorImpl, volumeRepo *dbMocks.MockVolumeRepo, readProcessor *readMocks.MockReadProcessor, ctx context.Context) {
+	e := errors.New("test error")
+	// GIVEN
+	volume := model.Volume{VolumeNumber: 4, Works: []model.Work{{Code: "GMS"}}}
+	volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(4)).Return(&volume, nil)
+	readProcessor.EXPECT().ProcessWorkText(gomock.Any(), "GMS", gomock.Nil(), gomock.Nil()).Return(nil, e)
+	// WHEN
+	res, err := sut.ProcessVolumeEpub(ctx, 4)
+	// THEN
+	assert.Equal(t, e, err)
+	assert.Nil(t, res)
+}
+
+func testProcessWorkTei(t *testing.T, sut *exportProcessorImpl, volumeRepo *dbMocks.MockVolumeRepo, readProcessor *readMocks.MockReadProcessor, ctx context.Context) {
+	// GIVEN
+	text := createWorkText("GMS", "Grundlegung")
+	readProcessor.EXPECT().ProcessWorkText(gomock.Any(), "GMS", gomock.Nil(), gomock.Nil()).Return(&text, nil)
+	// WHEN
+	res, err := sut.ProcessWorkTei(ctx, "GMS")
+	// THEN
+	assert.Nil(t, err)
+	assert.Contains(t, string(res), "<title>Grundlegung</title>")
+	assert.Contains(t, string(res), `<div type="work" n="GMS">`)
+	assert.Contains(t, string(res), `<p n="1"><pb n="1"/>Text</p>`)
+}
+
+func testProcessWorkTeiUnknownWork(t *testing.T, sut *exportProcessorImpl, volumeRepo *dbMocks.MockVolumeRepo, readProcessor *readMocks.MockReadProcessor, ctx context.Context) {
+	// GIVEN
+	readProcessor.EXPECT().ProcessWorkText(gomock.Any(), "GMS", gomock.Nil(), gomock.Nil()).Return(nil, nil)
+	// WHEN
+	res, err := sut.ProcessWorkTei(ctx, "GMS")
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessVolumeTei(t *testing.T, sut *exportProcessorImpl, volumeRepo *dbMocks.MockVolumeRepo, readProcessor *readMocks.MockReadProcessor, ctx context.Context) {
+	// GIVEN
+	volume := model.Volume{
+		VolumeNumber: 4,
+		Title:        "Band IV",
+		Works:        []model.Work{{Code: "GMS"}, {Code: "MAN"}},
+	}
+	gms := createWorkText("GMS", "Grundlegung")
+	man := createWorkText("MAN", "Metaphysische Anfangsgründe")
+	volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(4)).Return(&volume, nil)
+	readProcessor.EXPECT().ProcessWorkText(gomock.Any(), "GMS", gomock.Nil(), gomock.Nil()).Return(&gms, nil)
+	readProcessor.EXPECT().ProcessWorkText(gomock.Any(), "MAN", gomock.Nil(), gomock.Nil()).Return(&man, nil)
+	// WHEN
+	res, err := sut.ProcessVolumeTei(ctx, 4)
+	// THEN
+	assert.Nil(t, err)
+	assert.Contains(t, string(res), "<title>Band IV</title>")
+	assert.Contains(t, string(res), "<bibl>Akademie-Ausgabe, Band 4</bibl>")
+	assert.Contains(t, string(res), `<div type="work" n="GMS">`)
+	assert.Contains(t, string(res), `<div type="work" n="MAN">`)
+}
+
+func testProcessVolumeTeiUnknownVolume(t *testing.T, sut *exportProcessorImpl, volumeRepo *dbMocks.MockVolumeRepo, readProcessor *readMocks.MockReadProcessor, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(4)).Return(nil, nil)
+	// WHEN
+	res, err := sut.ProcessVolumeTei(ctx, 4)
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessVolumeTeiError(t *testing.T, sut *exportProcessorImpl, volumeRepo *dbMocks.MockVolumeRepo, readProcessor *readMocks.MockReadProcessor, ctx context.Context) {
+	e := errors.New("test error")
+	// GIVEN
+	volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(4)).Return(nil, e)
+	// WHEN
+	res, err := sut.ProcessVolumeTei(ctx, 4)
+	// THEN
+	assert.Equal(t, e, err)
+	assert.Nil(t, res)
+}
+
+func createWorkText(code string, title string) read.WorkText {
+	return read.WorkText{
+		Work: model.Work{Code: code, Title: title, Paragraphs: []int32{1}},
+		Items: []read.TextItem{{
+			Content: model.Content{Type: model.Paragraph, Ordinal: 1, FmtText: "<ks-meta-page>1</ks-meta-page>Text"},
+		}},
+	}
+}
+
+func unzip(t *testing.T, data []byte) map[string]string {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	assert.Nil(t, err)
+	files := make(map[string]string)
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		assert.Nil(t, err)
+		buf := new(bytes.Buffer)
+		_, err = buf.ReadFrom(rc)
+		assert.Nil(t, err)
+		files[f.Name] = buf.String()
+	}
+	return files
+}
@@ -0,0 +1,257 @@
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frhorschig/kant-search-backend/common/render"
+	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/frhorschig/kant-search-backend/core/read"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+)
+
+// Book is the input of an EPUB file; each work is written to its own content document
+type Book struct {
+	Identifier string
+	Title      string
+	Modified   time.Time
+	Works      []read.WorkText
+}
+
+type pageTarget struct {
+	page int
+	href string
+}
+
+const (
+	xhtmlHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="de" lang="de">
+<head>
+<meta charset="UTF-8"/>
+<title>%s</title>
+<link rel="stylesheet" type="text/css" href="style.css"/>
+</head>
+<body>
+`
+	xhtmlFooter = `</body>
+</html>
+`
+	containerXml = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+<rootfiles>
+<rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+</rootfiles>
+</container>
+`
+	styleCss = `.summary { font-size: smaller; margin: 0.5em 2em; }
+.page { color: gray; font-size: smaller; }
+.line { display: none; }
+.fnref { font-size: smaller; }
+.endnotes ol { list-style: none; padding: 0; }
+.emph2, .tracked { letter-spacing: 0.15em; }
+`
+)
+
+var (
+	renderOpts = render.Options{
+		Format:     render.Epub,
+		ShowPages:  true,
+		ShowLines:  false,
+		ShowFnRefs: true,
+	}
+	pageBreakRegex = regexp.MustCompile(`<span class="page" epub:type="pagebreak" role="doc-pagebreak" title="(\d+)">`)
+	idCharRegex    = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+)
+
+// Build creates an EPUB 3 file with a navigation document that contains the section tree of the works and a page list of the page markers
+func Build(book Book) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+
+	// the mimetype must be the first and an uncompressed entry of the archive
+	mimetype, err := w.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = mimetype.Write([]byte("application/epub+zip")); err != nil {
+		return nil, err
+	}
+
+	files := map[string]string{
+		"META-INF/container.xml": containerXml,
+		"OEBPS/style.css":        styleCss,
+	}
+	seenPages := make(map[int]bool)
+	pageTargets := []pageTarget{}
+	hrefs := []string{}
+	for i, work := range book.Works {
+		href := fmt.Sprintf("work%03d.xhtml", i+1)
+		hrefs = append(hrefs, href)
+		doc, targets := createContentDocument(work, href, seenPages)
+		files["OEBPS/"+href] = doc
+		pageTargets = append(pageTargets, targets...)
+	}
+	slices.SortStableFunc(pageTargets, func(a, b pageTarget) int {
+		return a.page - b.page
+	})
+	files["OEBPS/nav.xhtml"] = createNavDocument(book, hrefs, pageTargets)
+	files["OEBPS/content.opf"] = createPackageDocument(book, hrefs)
+
+	names := []string{}
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		f, err := w.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = f.Write([]byte(files[name])); err != nil {
+			return nil, err
+		}
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func createContentDocument(work read.WorkText, href string, seenPages map[int]bool) (string, []pageTarget) {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf(xhtmlHeader, html.EscapeString(work.Work.Title)))
+	sb.WriteString(fmt.Sprintf(`<section epub:type="bodymatter chapter" id="%s">`+"\n", workId(work.Work)))
+
+	footnotes := []model.Content{}
+	seenFootnotes := make(map[string]bool)
+	for _, item := range work.Items {
+		if item.Summary != nil {
+			sb.WriteString(fmt.Sprintf(`<aside class="summary" id="o-%d">%s</aside>`+"\n", item.Summary.Ordinal, render.Render(item.Summary.FmtText, renderOpts)))
+		}
+		text := render.Render(item.Content.FmtText, renderOpts)
+		switch {
+		case item.Content.Type == model.Heading:
+			sb.WriteString(fmt.Sprintf(`<div class="heading" id="o-%d">%s</div>`+"\n", item.Content.Ordinal, text))
+		case strings.Contains(text, "<table"):
+			// tables are not allowed inside of p elements
+			sb.WriteString(fmt.Sprintf(`<div class="paragraph" id="o-%d">%s</div>`+"\n", item.Content.Ordinal, text))
+		default:
+			sb.WriteString(fmt.Sprintf(`<p id="o-%d">%s</p>`+"\n", item.Content.Ordinal, text))
+		}
+		for _, fn := range item.Footnotes {
+			ref := util.StrVal(fn.Ref)
+			if !seenFootnotes[ref] {
+				seenFootnotes[ref] = true
+				footnotes = append(footnotes, fn)
+			}
+		}
+	}
+	sb.WriteString("</section>\n")
+
+	if len(footnotes) > 0 {
+		sb.WriteString(`<section class="endnotes" epub:type="endnotes" role="doc-endnotes">` + "\n<h2>Anmerkungen</h2>\n<ol>\n")
+		for _, fn := range footnotes {
+			ref := html.EscapeString(util.StrVal(fn.Ref))
+			sb.WriteString(fmt.Sprintf(`<li id="fn-%s" epub:type="endnote" role="doc-endnote"><p><span class="fn-label">%s</span> %s</p></li>`+"\n", ref, ref, render.Render(fn.FmtText, renderOpts)))
+		}
+		sb.WriteString("</ol>\n</section>\n")
+	}
+	sb.WriteString(xhtmlFooter)
+
+	// only the first marker of a page gets an id, so that the ids are unique and the page list refers to the start of the page
+	targets := []pageTarget{}
+	doc := pageBreakRegex.ReplaceAllStringFunc(sb.String(), func(match string) string {
+		page, err := strconv.Atoi(pageBreakRegex.FindStringSubmatch(match)[1])
+		if err != nil || seenPages[page] {
+			return match
+		}
+		seenPages[page] = true
+		targets = append(targets, pageTarget{page: page, href: fmt.Sprintf("%s#page-%d", href, page)})
+		return strings.Replace(match, "<span ", fmt.Sprintf(`<span id="page-%d" `, page), 1)
+	})
+	return doc, targets
+}
+
+func createNavDocument(book Book, hrefs []string, pageTargets []pageTarget) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf(xhtmlHeader, html.EscapeString(book.Title)))
+	sb.WriteString(`<nav epub:type="toc" id="toc">` + "\n<h1>Inhalt</h1>\n<ol>\n")
+	for i, work := range book.Works {
+		sb.WriteString(fmt.Sprintf(`<li><a href="%s#%s">%s</a>`, hrefs[i], workId(work.Work), html.EscapeString(work.Work.Title)))
+		writeSections(&sb, work, hrefs[i], work.Work.Sections)
+		sb.WriteString("</li>\n")
+	}
+	sb.WriteString("</ol>\n</nav>\n")
+
+	if len(pageTargets) > 0 {
+		sb.WriteString(`<nav epub:type="page-list" id="page-list" hidden="hidden">` + "\n<ol>\n")
+		for _, t := range pageTargets {
+			sb.WriteString(fmt.Sprintf(`<li><a href="%s">%d</a></li>`+"\n", t.href, t.page))
+		}
+		sb.WriteString("</ol>\n</nav>\n")
+	}
+	sb.WriteString(xhtmlFooter)
+	return sb.String()
+}
+
+func writeSections(sb *strings.Builder, work read.WorkText, href string, sections []model.Section) {
+	if len(sections) == 0 {
+		return
+	}
+	sb.WriteString("\n<ol>\n")
+	for _, s := range sections {
+		sb.WriteString(fmt.Sprintf(`<li><a href="%s#o-%d">%s</a>`, href, s.Heading, html.EscapeString(findHeadingText(work, s.Heading))))
+		writeSections(sb, work, href, s.Sections)
+		sb.WriteString("</li>\n")
+	}
+	sb.WriteString("</ol>\n")
+}
+
+func findHeadingText(work read.WorkText, ordinal int32) string {
+	for _, item := range work.Items {
+		if item.Content.Ordinal == ordinal {
+			text := item.Content.FmtText
+			if item.Content.TocText != nil {
+				text = *item.Content.TocText
+			}
+			return render.Render(text, render.Options{Format: render.Plain})
+		}
+	}
+	return strconv.Itoa(int(ordinal))
+}
+
+func createPackageDocument(book Book, hrefs []string) string {
+	sb := strings.Builder{}
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id" xml:lang="de">
+<metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+`)
+	sb.WriteString(fmt.Sprintf("<dc:identifier id=\"book-id\">%s</dc:identifier>\n", html.EscapeString(book.Identifier)))
+	sb.WriteString(fmt.Sprintf("<dc:title>%s</dc:title>\n", html.EscapeString(book.Title)))
+	sb.WriteString("<dc:creator>Immanuel Kant</dc:creator>\n<dc:language>de</dc:language>\n")
+	sb.WriteString(fmt.Sprintf("<meta property=\"dcterms:modified\">%s</meta>\n", book.Modified.UTC().Format(time.RFC3339)))
+	sb.WriteString("</metadata>\n<manifest>\n")
+	sb.WriteString(`<item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>` + "\n")
+	sb.WriteString(`<item id="style" href="style.css" media-type="text/css"/>` + "\n")
+	for i, href := range hrefs {
+		sb.WriteString(fmt.Sprintf(`<item id="work%03d" href="%s" media-type="application/xhtml+xml"/>`+"\n", i+1, href))
+	}
+	sb.WriteString("</manifest>\n<spine>\n")
+	for i := range hrefs {
+		sb.WriteString(fmt.Sprintf(`<itemref idref="work%03d"/>`+"\n", i+1))
+	}
+	sb.WriteString("</spine>\n</package>\n")
+	return sb.String()
+}
+
+func workId(work model.Work) string {
+	return "work-" + idCharRegex.ReplaceAllString(work.Code, "_")
+}
@@ -0,0 +1,107 @@
+//go:build unit
+// +build unit
+
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/frhorschig/kant-search-backend/core/read"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuild(t *testing.T) {
+	// GIVEN
+	book := Book{
+		Identifier: "urn:kant-search:work:GMS",
+		Title:      "Grundlegung zur Metaphysik der Sitten",
+		Modified:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Works: []read.WorkText{{
+			Work: model.Work{
+				Code:  "GMS",
+				Title: "Grundlegung zur Metaphysik der Sitten",
+				Sections: []model.Section{
+					{Heading: 1, Paragraphs: []int32{3}},
+				},
+			},
+			Items: []read.TextItem{
+				{
+					Content: model.Content{Type: model.Heading, Ordinal: 1, FmtText: "<ks-fmt-h1>Vorrede</ks-fmt-h1>", TocText: util.StrPtr("Vorrede & Einleitung")},
+					Level:   1,
+				},
+				{
+					Content: model.Content{Type: model.Paragraph, Ordinal: 3, FmtText: "<ks-meta-page>387</ks-meta-page>Die alte <ks-fmt-emph>griechische</ks-fmt-emph> Philosophie<ks-meta-fnref>387.1</ks-meta-fnref> <ks-meta-page>388</ks-meta-page>theilte"},
+					Level:   1,
+					Summary: &model.Content{Type: model.Summary, Ordinal: 2, FmtText: "Einteilung"},
+					Footnotes: []model.Content{
+						{Type: model.Footnote, Ordinal: 4, Ref: util.StrPtr("387.1"), FmtText: "Eine Anmerkung <ks-meta-page>388</ks-meta-page>weiter"},
+					},
+				},
+			},
+		}},
+	}
+
+	// WHEN
+	result, err := Build(book)
+
+	// THEN
+	assert.Nil(t, err)
+	reader, err := zip.NewReader(bytes.NewReader(result), int64(len(result)))
+	assert.Nil(t, err)
+	assert.Equal(t, "mimetype", reader.File[0].Name)
+	assert.Equal(t, zip.Store, reader.File[0].Method)
+	files := make(map[string]string)
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		assert.Nil(t, err)
+		content, err := io.ReadAll(rc)
+		assert.Nil(t, err)
+		files[f.Name] = string(content)
+	}
+	assert.Equal(t, "application/epub+zip", files["mimetype"])
+	assert.Contains(t, files, "META-INF/container.xml")
+	assert.Contains(t, files, "OEBPS/style.css")
+	for _, name := range []string{"OEBPS/content.opf", "OEBPS/nav.xhtml", "OEBPS/work001.xhtml"} {
+		assert.Contains(t, files, name)
+		assertWellFormed(t, files[name])
+	}
+
+	opf := files["OEBPS/content.opf"]
+	assert.Contains(t, opf, `<dc:identifier id="book-id">urn:kant-search:work:GMS</dc:identifier>`)
+	assert.Contains(t, opf, `<meta property="dcterms:modified">2024-05-01T12:00:00Z</meta>`)
+	assert.Contains(t, opf, `<itemref idref="work001"/>`)
+
+	nav := files["OEBPS/nav.xhtml"]
+	assert.Contains(t, nav, `<li><a href="work001.xhtml#work-GMS">Grundlegung zur Metaphysik der Sitten</a>`)
+	assert.Contains(t, nav, `<li><a href="work001.xhtml#o-1">Vorrede &amp; Einleitung</a>`)
+	assert.Contains(t, nav, `<li><a href="work001.xhtml#page-387">387</a></li>`)
+	assert.Contains(t, nav, `<li><a href="work001.xhtml#page-388">388</a></li>`)
+
+	doc := files["OEBPS/work001.xhtml"]
+	assert.Contains(t, doc, `<aside class="summary" id="o-2">Einteilung</aside>`)
+	assert.Contains(t, doc, `<div class="heading" id="o-1"><h1>Vorrede</h1></div>`)
+	assert.Contains(t, doc, `<p id="o-3"><span id="page-387" class="page" epub:type="pagebreak"`)
+	assert.Contains(t, doc, `<a epub:type="noteref" role="doc-noteref" href="#fn-387.1">387.1</a>`)
+	assert.Contains(t, doc, `<li id="fn-387.1" epub:type="endnote" role="doc-endnote">`)
+	assert.Equal(t, 1, bytes.Count([]byte(doc), []byte(`id="page-388"`)))
+}
+
+func assertWellFormed(t *testing.T, doc string) {
+	decoder := xml.NewDecoder(bytes.NewReader([]byte(doc)))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return
+		}
+		if !assert.Nil(t, err) {
+			return
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package tei
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/frhorschig/kant-search-backend/common/render"
+	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/frhorschig/kant-search-backend/core/read"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+)
+
+// Document is the input of a TEI file; each work is written to its own div element
+type Document struct {
+	Title  string
+	Source string // description of the source edition, e.g. the volume of the Akademie-Ausgabe
+	Works  []read.WorkText
+}
+
+const (
+	teiHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<TEI xmlns="http://www.tei-c.org/ns/1.0" xml:lang="de">
+<teiHeader>
+<fileDesc>
+<titleStmt>
+<title>%s</title>
+<author>Immanuel Kant</author>
+</titleStmt>
+<publicationStmt>
+<p>Exported from Kant-Search</p>
+</publicationStmt>
+<sourceDesc>
+<bibl>%s</bibl>
+</sourceDesc>
+</fileDesc>
+</teiHeader>
+<text>
+<body>
+`
+	teiFooter = `</body>
+</text>
+</TEI>
+`
+)
+
+var (
+	renderOpts = render.Options{
+		Format:     render.Tei,
+		ShowPages:  true,
+		ShowLines:  true,
+		ShowFnRefs: true,
+	}
+	noteAnchorRegex = regexp.MustCompile(`<ptr type="noteAnchor" target="#fn-([^"]*)"/>`)
+)
+
+// node is either a paragraph or a section of a work
+type node struct {
+	ordinal int32
+	section *model.Section
+}
+
+// Build creates a TEI P5 document; the divs are created from the section tree of the works, the footnotes are inserted as notes at the position of their references.
+// Foreign-language passages are marked as foreign elements, their xml:lang is the BCP 47 tag of the language of the passage.
+func Build(doc Document) []byte {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf(teiHeader, html.EscapeString(doc.Title), html.EscapeString(doc.Source)))
+	for _, work := range doc.Works {
+		itemByOrdinal := make(map[int32]read.TextItem)
+		for _, item := range work.Items {
+			itemByOrdinal[item.Content.Ordinal] = item
+		}
+		sb.WriteString(fmt.Sprintf(`<div type="work" n="%s">`+"\n", html.EscapeString(work.Work.Code)))
+		writeNodes(&sb, sortNodes(work.Work.Paragraphs, work.Work.Sections), itemByOrdinal)
+		sb.WriteString("</div>\n")
+	}
+	sb.WriteString(teiFooter)
+	return []byte(sb.String())
+}
+
+// sortNodes returns the paragraphs and sections in reading order, a section is sorted by the ordinal of its heading
+func sortNodes(paragraphs []int32, sections []model.Section) []node {
+	nodes := []node{}
+	for _, p := range paragraphs {
+		nodes = append(nodes, node{ordinal: p})
+	}
+	for i := range sections {
+		nodes = append(nodes, node{ordinal: sections[i].Heading, section: &sections[i]})
+	}
+	slices.SortFunc(nodes, func(a, b node) int {
+		return int(a.ordinal - b.ordinal)
+	})
+	return nodes
+}
+
+func writeNodes(sb *strings.Builder, nodes []node, itemByOrdinal map[int32]read.TextItem) {
+	for _, n := range nodes {
+		item, ok := itemByOrdinal[n.ordinal]
+		if n.section != nil {
+			sb.WriteString(fmt.Sprintf(`<div type="section" n="%d">`+"\n", n.ordinal))
+			if ok {
+				sb.WriteString("<head>" + renderWithNotes(item.Content, item.Footnotes) + "</head>\n")
+			}
+			writeNodes(sb, sortNodes(n.section.Paragraphs, n.section.Sections), itemByOrdinal)
+			sb.WriteString("</div>\n")
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if item.Summary != nil {
+			sb.WriteString(`<note type="summary" place="margin">` + renderWithNotes(*item.Summary, item.Footnotes) + "</note>\n")
+		}
+		sb.WriteString(fmt.Sprintf(`<p n="%d">`, n.ordinal) + renderWithNotes(item.Content, item.Footnotes) + "</p>\n")
+	}
+}
+
+// renderWithNotes replaces the footnote references of the content by the footnotes; references of unknown footnotes are removed
+func renderWithNotes(content model.Content, footnotes []model.Content) string {
+	text := render.Render(content.FmtText, renderOpts)
+	return noteAnchorRegex.ReplaceAllStringFunc(text, func(match string) string {
+		ref := noteAnchorRegex.FindStringSubmatch(match)[1]
+		for _, fn := range footnotes {
+			if util.StrVal(fn.Ref) == ref {
+				fnText := noteAnchorRegex.ReplaceAllString(render.Render(fn.FmtText, renderOpts), "")
+				return fmt.Sprintf(`<note place="foot" n="%s">%s</note>`, ref, fnText)
+			}
+		}
+		return ""
+	})
+}
@@ -0,0 +1,82 @@
+//go:build unit
+// +build unit
+
+package tei
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/frhorschig/kant-search-backend/core/read"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuild(t *testing.T) {
+	// GIVEN
+	doc := Document{
+		Title:  "Grundlegung zur Metaphysik der Sitten",
+		Source: "Akademie-Ausgabe, Band IV",
+		Works: []read.WorkText{{
+			Work: model.Work{
+				Code:       "GMS",
+				Title:      "Grundlegung zur Metaphysik der Sitten",
+				Paragraphs: []int32{5},
+				Sections: []model.Section{
+					{Heading: 1, Paragraphs: []int32{3}},
+				},
+			},
+			Items: []read.TextItem{
+				{
+					Content: model.Content{Type: model.Heading, Ordinal: 1, FmtText: "<ks-fmt-h1>Vorrede</ks-fmt-h1>"},
+					Level:   1,
+				},
+				{
+					Content: model.Content{Type: model.Paragraph, Ordinal: 3, FmtText: "<ks-meta-page>387</ks-meta-page>Die alte <ks-fmt-emph>griechische</ks-fmt-emph> Philosophie<ks-meta-fnref>387.1</ks-meta-fnref> <ks-meta-line>5</ks-meta-line>theilte<ks-meta-fnref>387.2</ks-meta-fnref>"},
+					Level:   1,
+					Summary: &model.Content{Type: model.Summary, Ordinal: 2, FmtText: "Einteilung"},
+					Footnotes: []model.Content{
+						{Type: model.Footnote, Ordinal: 4, Ref: util.StrPtr("387.1"), FmtText: "Eine Anmerkung"},
+					},
+				},
+				{
+					Content: model.Content{Type: model.Paragraph, Ordinal: 5, FmtText: `Schluss & <ks-fmt-lang lang="lat">finis</ks-fmt-lang>`},
+				},
+			},
+		}},
+	}
+
+	// WHEN
+	result := string(Build(doc))
+
+	// THEN
+	assertWellFormed(t, result)
+	assert.Contains(t, result, `<TEI xmlns="http://www.tei-c.org/ns/1.0" xml:lang="de">`)
+	assert.Contains(t, result, `<title>Grundlegung zur Metaphysik der Sitten</title>`)
+	assert.Contains(t, result, `<bibl>Akademie-Ausgabe, Band IV</bibl>`)
+	assert.Contains(t, result, `<div type="work" n="GMS">`)
+	assert.Contains(t, result, `<div type="section" n="1">`+"\n<head>Vorrede</head>")
+	assert.Contains(t, result, `<note type="summary" place="margin">Einteilung</note>`)
+	assert.Contains(t, result, `<p n="3"><pb n="387"/>Die alte <emph>griechische</emph> Philosophie<note place="foot" n="387.1">Eine Anmerkung</note> <lb n="5"/>theilte</p>`)
+	assert.Contains(t, result, `<p n="5">Schluss &amp; <foreign xml:lang="la">finis</foreign></p>`)
+	assert.NotContains(t, result, "387.2")
+	// the paragraph of the section precedes the paragraph after the section
+	assert.Less(t, strings.Index(result, `<p n="3">`), strings.Index(result, `<p n="5">`))
+}
+
+func assertWellFormed(t *testing.T, doc string) {
+	decoder := xml.NewDecoder(bytes.NewReader([]byte(doc)))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return
+		}
+		if !assert.Nil(t, err) {
+			return
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package image
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/image_mock.go -package=mocks
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/frhorschig/kant-search-backend/common/errs"
+	"github.com/frhorschig/kant-search-backend/dataaccess"
+)
+
+type Image struct {
+	Data        []byte
+	ContentType string
+	Version     string // hash of the data, changes with every upload of a changed image
+}
+
+// ImageProcessor stores and reads the images that are referenced by the src attribute of the ks-meta-imgref tags
+type ImageProcessor interface {
+	ProcessUpload(ctx context.Context, name string, data []byte) errs.UploadError
+	ProcessImage(ctx context.Context, name string) (*Image, error) // returns nil if the image doesn't exist
+}
+
+var (
+	nameRegex     = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+	contentTypes  = map[string]string{".png": "image/png", ".jpg": "image/jpeg", ".jpeg": "image/jpeg", ".gif": "image/gif", ".webp": "image/webp", ".svg": "image/svg+xml"}
+	svgStartRegex = regexp.MustCompile(`(?s)^\s*(<\?xml[^>]*\?>\s*)?(<!--.*?-->\s*)*(<!DOCTYPE svg[^>]*>\s*)?<svg[\s>]`)
+)
+
+type imageProcessorImpl struct {
+	imageRepo dataaccess.ImageRepo
+}
+
+func NewImageProcessor(imageRepo dataaccess.ImageRepo) ImageProcessor {
+	processor := imageProcessorImpl{
+		imageRepo: imageRepo,
+	}
+	return &processor
+}
+
+func (rec *imageProcessorImpl) ProcessUpload(ctx context.Context, name string, data []byte) errs.UploadError {
+	if err := validateImage(name, data); err != nil {
+		return errs.New(err, nil)
+	}
+	if err := rec.imageRepo.Insert(ctx, name, data); err != nil {
+		return errs.New(nil, err)
+	}
+	return errs.Nil()
+}
+
+func (rec *imageProcessorImpl) ProcessImage(ctx context.Context, name string) (*Image, error) {
+	contentType, ok := findContentType(name)
+	if !ok || !nameRegex.MatchString(name) {
+		return nil, nil
+	}
+	data, err := rec.imageRepo.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	hash := sha256.Sum256(data)
+	return &Image{
+		Data:        data,
+		ContentType: contentType,
+		Version:     hex.EncodeToString(hash[:8]),
+	}, nil
+}
+
+// validateImage checks that the name is a file name with a supported extension and that the data has the format of this extension
+func validateImage(name string, data []byte) error {
+	if !nameRegex.MatchString(name) {
+		return fmt.Errorf("invalid image name '%s', only letters, digits, '.', '_' and '-' are allowed", name)
+	}
+	contentType, ok := findContentType(name)
+	if !ok {
+		return fmt.Errorf("unsupported file extension of image '%s'", name)
+	}
+	if len(data) == 0 {
+		return fmt.Errorf("image '%s' is empty", name)
+	}
+	if contentType == "image/svg+xml" {
+		if !svgStartRegex.Match(stripBom(data)) {
+			return fmt.Errorf("image '%s' is not an SVG image", name)
+		}
+		return nil
+	}
+	if detected := http.DetectContentType(data); detected != contentType {
+		return fmt.Errorf("image '%s' has the content type %s, but %s is expected", name, detected, contentType)
+	}
+	return nil
+}
+
+func findContentType(name string) (string, bool) {
+	contentType, ok := contentTypes[strings.ToLower(filepath.Ext(name))]
+	return contentType, ok
+}
+
+// stripBom removes the UTF-8 byte order mark that some editors put at the start of SVG files
+func stripBom(data []byte) []byte {
+	return bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+}
@@ -0,0 +1,141 @@
+//go:build unit
+// +build unit
+
+package image
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frhorschig/kant-search-backend/dataaccess/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var pngData = []byte("\x89PNG\x0D\x0A\x1A\x0A" + "image data")
+
+func TestImageProcessor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	imageRepo := mocks.NewMockImageRepo(ctrl)
+	sut := &imageProcessorImpl{
+		imageRepo: imageRepo,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	for scenario, fn := range map[string]func(*testing.T, *imageProcessorImpl, *mocks.MockImageRepo, context.Context){
+		"Process upload":                   testProcessUpload,
+		"Process upload of svg":            testProcessUploadSvg,
+		"Process upload with invalid name": testProcessUploadInvalidName,
+		"Process upload with unknown type": testProcessUploadUnknownType,
+		"Process upload with wrong format": testProcessUploadWrongFormat,
+		"Process upload with error":        testProcessUploadError,
+		"Process image":                    testProcessImage,
+		"Process image with unknown image": testProcessImageUnknownImage,
+		"Process image with invalid name":  testProcessImageInvalidName,
+		"Process image with error":         testProcessImageError,
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			fn(t, sut, imageRepo, ctx)
+		})
+	}
+}
+
+func testProcessUpload(t *testing.T, sut *imageProcessorImpl, imageRepo *mocks.MockImageRepo, ctx context.Context) {
+	// GIVEN
+	imageRepo.EXPECT().Insert(gomock.Any(), "fig_1.PNG", pngData).Return(nil)
+	// WHEN
+	err := sut.ProcessUpload(ctx, "fig_1.PNG", pngData)
+	// THEN
+	assert.False(t, err.HasError)
+}
+
+func testProcessUploadSvg(t *testing.T, sut *imageProcessorImpl, imageRepo *mocks.MockImageRepo, ctx context.Context) {
+	svg := []byte("\xef\xbb\xbf<?xml version=\"1.0\"?>\n<!-- a\nfigure -->\n<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>")
+	// GIVEN
+	imageRepo.EXPECT().Insert(gomock.Any(), "fig.svg", svg).Return(nil)
+	// WHEN
+	err := sut.ProcessUpload(ctx, "fig.svg", svg)
+	// THEN
+	assert.False(t, err.HasError)
+}
+
+func testProcessUploadInvalidName(t *testing.T, sut *imageProcessorImpl, imageRepo *mocks.MockImageRepo, ctx context.Context) {
+	// WHEN
+	err := sut.ProcessUpload(ctx, "../fig.png", pngData)
+	// THEN
+	assert.True(t, err.HasError)
+	assert.Contains(t, err.DomainError.Error(), "invalid image name")
+}
+
+func testProcessUploadUnknownType(t *testing.T, sut *imageProcessorImpl, imageRepo *mocks.MockImageRepo, ctx context.Context) {
+	// WHEN
+	err := sut.ProcessUpload(ctx, "fig.bmp", pngData)
+	// THEN
+	assert.True(t, err.HasError)
+	assert.Contains(t, err.DomainError.Error(), "unsupported file extension")
+}
+
+func testProcessUploadWrongFormat(t *testing.T, sut *imageProcessorImpl, imageRepo *mocks.MockImageRepo, ctx context.Context) {
+	for _, name := range []string{"fig.jpg", "fig.svg"} {
+		// WHEN
+		err := sut.ProcessUpload(ctx, name, pngData)
+		// THEN
+		assert.True(t, err.HasError)
+		assert.NotNil(t, err.DomainError)
+	}
+}
+
+func testProcessUploadError(t *testing.T, sut *imageProcessorImpl, imageRepo *mocks.MockImageRepo, ctx context.Context) {
+	// GIVEN
+	imageRepo.EXPECT().Insert(gomock.Any(), "fig.png", pngData).Return(errors.New("test error"))
+	// WHEN
+	err := sut.ProcessUpload(ctx, "fig.png", pngData)
+	// THEN
+	assert.True(t, err.HasError)
+	assert.NotNil(t, err.TechnicalError)
+}
+
+func testProcessImage(t *testing.T, sut *imageProcessorImpl, imageRepo *mocks.MockImageRepo, ctx context.Context) {
+	// GIVEN
+	imageRepo.EXPECT().Get(gomock.Any(), "fig.jpeg").Return([]byte("data"), nil)
+	// WHEN
+	res, err := sut.ProcessImage(ctx, "fig.jpeg")
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("data"), res.Data)
+	assert.Equal(t, "image/jpeg", res.ContentType)
+	assert.Len(t, res.Version, 16)
+}
+
+func testProcessImageUnknownImage(t *testing.T, sut *imageProcessorImpl, imageRepo *mocks.MockImageRepo, ctx context.Context) {
+	// GIVEN
+	imageRepo.EXPECT().Get(gomock.Any(), "fig.png").Return(nil, nil)
+	// WHEN
+	res, err := sut.ProcessImage(ctx, "fig.png")
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessImageInvalidName(t *testing.T, sut *imageProcessorImpl, imageRepo *mocks.MockImageRepo, ctx context.Context) {
+	// WHEN
+	res, err := sut.ProcessImage(ctx, "volume-metadata.json")
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessImageError(t *testing.T, sut *imageProcessorImpl, imageRepo *mocks.MockImageRepo, ctx context.Context) {
+	e := errors.New("test error")
+	// GIVEN
+	imageRepo.EXPECT().Get(gomock.Any(), "fig.png").Return(nil, e)
+	// WHEN
+	res, err := sut.ProcessImage(ctx, "fig.png")
+	// THEN
+	assert.Equal(t, e, err)
+	assert.Nil(t, res)
+}
@@ -0,0 +1,127 @@
+package citation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Citation is a parsed citation string; depending on the citation format, either Page (with Volume and Line), Edition and EditionPage, or Paragraph is set.
+type Citation struct {
+	Siglum      *string // nil for citations of an AA volume without a work
+	Volume      int32   // AA volume number, 0 if not part of the citation
+	Page        int32
+	Line        *int32
+	Edition     string // "A" or "B" for citations of the original editions (e.g. "KrV B 132")
+	EditionPage string // page of the original edition, roman for the prefaces (e.g. "132" or "XVI")
+	Paragraph   int32  // § number
+}
+
+var (
+	aaMatcher        = regexp.MustCompile(`^AA\s*([IVXL]+|\d+)[\s,]+(\d+)(?:\s*[.,]\s*(\d+))?$`)                  // AA IV 421.12
+	volPageMatcher   = regexp.MustCompile(`^(.+?)[\s,]+(?:AA\s*)?([IVXL]+|\d+)\s*:\s*(\d+)(?:\s*[.,]\s*(\d+))?$`) // GMS 4:421.12
+	editionMatcher   = regexp.MustCompile(`^(.+?)[\s,]+([AB])\s*([IVXLCDM]+|\d+)$`)                               // KrV B 132, KrV B XVI
+	paragraphMatcher = regexp.MustCompile(`^(.+?)[\s,]+§+\s*(\d+)$`)                                              // Prol § 13
+)
+
+func Parse(input string) (*Citation, error) {
+	input = strings.Join(strings.Fields(input), " ")
+	if m := aaMatcher.FindStringSubmatch(input); m != nil {
+		return newPageCitation(nil, m[1], m[2], m[3])
+	}
+	if m := volPageMatcher.FindStringSubmatch(input); m != nil {
+		return newPageCitation(&m[1], m[2], m[3], m[4])
+	}
+	if m := editionMatcher.FindStringSubmatch(input); m != nil {
+		page := m[3]
+		if strings.Trim(page, "IVXLCDM") != "" {
+			// arabic pages are normalized, e.g. "0132" to "132"
+			num, err := parseNumber(page)
+			if err != nil {
+				return nil, err
+			}
+			page = strconv.Itoa(int(num))
+		}
+		return &Citation{Siglum: &m[1], Edition: m[2], EditionPage: page}, nil
+	}
+	if m := paragraphMatcher.FindStringSubmatch(input); m != nil {
+		paragraph, err := parseNumber(m[2])
+		if err != nil {
+			return nil, err
+		}
+		return &Citation{Siglum: &m[1], Paragraph: paragraph}, nil
+	}
+	return nil, fmt.Errorf("unknown citation format: '%s'", input)
+}
+
+func newPageCitation(siglum *string, volume string, page string, line string) (*Citation, error) {
+	c := Citation{Siglum: siglum}
+	var err error
+	c.Volume, err = parseVolume(volume)
+	if err != nil {
+		return nil, err
+	}
+	c.Page, err = parseNumber(page)
+	if err != nil {
+		return nil, err
+	}
+	if line != "" {
+		l, err := parseNumber(line)
+		if err != nil {
+			return nil, err
+		}
+		c.Line = &l
+	}
+	return &c, nil
+}
+
+func parseVolume(volume string) (int32, error) {
+	if strings.Trim(volume, "IVXL") == "" {
+		return parseRoman(volume)
+	}
+	return parseNumber(volume)
+}
+
+func parseNumber(s string) (int32, error) {
+	num, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if num < 1 {
+		return 0, fmt.Errorf("%d is not a positive number", num)
+	}
+	return int32(num), nil
+}
+
+func parseRoman(s string) (int32, error) {
+	values := map[rune]int32{'I': 1, 'V': 5, 'X': 10, 'L': 50}
+	runes := []rune(s)
+	result := int32(0)
+	for i, r := range runes {
+		v := values[r]
+		if i+1 < len(runes) && v < values[runes[i+1]] {
+			result -= v
+		} else {
+			result += v
+		}
+	}
+	if toRoman(result) != s {
+		return 0, fmt.Errorf("invalid roman numeral '%s'", s)
+	}
+	return result, nil
+}
+
+func toRoman(num int32) string {
+	var sb strings.Builder
+	for _, r := range []struct {
+		value  int32
+		symbol string
+	}{{50, "L"}, {40, "XL"}, {10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"}} {
+		for num >= r.value {
+			sb.WriteString(r.symbol)
+			num -= r.value
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,93 @@
+//go:build unit
+// +build unit
+
+package citation
+
+import (
+	"testing"
+
+	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected *Citation
+		hasError bool
+	}{
+		{
+			name:     "AA with roman volume, page and line",
+			input:    "AA IV 421.12",
+			expected: &Citation{Volume: 4, Page: 421, Line: util.Int32Ptr(12)},
+		},
+		{
+			name:     "AA with arabic volume and page",
+			input:    "AA 5, 143",
+			expected: &Citation{Volume: 5, Page: 143},
+		},
+		{
+			name:     "siglum with volume and page",
+			input:    "GMS 4:421",
+			expected: &Citation{Siglum: util.StrPtr("GMS"), Volume: 4, Page: 421},
+		},
+		{
+			name:     "siglum with AA volume, page and line",
+			input:    "  GMS,  AA IV: 421, 12 ",
+			expected: &Citation{Siglum: util.StrPtr("GMS"), Volume: 4, Page: 421, Line: util.Int32Ptr(12)},
+		},
+		{
+			name:     "original edition",
+			input:    "KrV B 132",
+			expected: &Citation{Siglum: util.StrPtr("KrV"), Edition: "B", EditionPage: "132"},
+		},
+		{
+			name:     "original edition with roman page",
+			input:    "KrV A XVI",
+			expected: &Citation{Siglum: util.StrPtr("KrV"), Edition: "A", EditionPage: "XVI"},
+		},
+		{
+			name:     "original edition with page zero",
+			input:    "KrV B 0",
+			hasError: true,
+		},
+		{
+			name:     "paragraph",
+			input:    "Prol § 13",
+			expected: &Citation{Siglum: util.StrPtr("Prol"), Paragraph: 13},
+		},
+		{
+			name:     "paragraph without space",
+			input:    "Prol §13",
+			expected: &Citation{Siglum: util.StrPtr("Prol"), Paragraph: 13},
+		},
+		{
+			name:     "invalid roman numeral",
+			input:    "AA IIII 12",
+			hasError: true,
+		},
+		{
+			name:     "page zero",
+			input:    "GMS 4:0",
+			hasError: true,
+		},
+		{
+			name:     "unknown format",
+			input:    "Kant, somewhere",
+			hasError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Parse(tc.input)
+			if tc.hasError {
+				assert.NotNil(t, err)
+				assert.Nil(t, c)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expected, c)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package pages
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+)
+
+var tagMatcher = regexp.MustCompile(`<(/?)(ks-[a-z0-9-]+)[^>]*?(/?)>`)
+
+type openTag struct {
+	tag  string
+	name string
+}
+
+// TrimToPage trims the formatted text of the content to the part that is on the given page. This part starts at the ks-meta-page tag with the page number (or at the beginning of the text, if there is no such tag) and ends before the next ks-meta-page tag. Formatting tags that are opened before the start or closed after the end of the part are added, so that the result is well-formed. The page and line indices and the word index map are shifted to the trimmed text, indices outside of the part are removed.
+func TrimToPage(c *model.Content, page int32) {
+	runes := []rune(c.FmtText)
+	start, end := findPageBounds(c.PageByIndex, page, len(runes))
+
+	var sb strings.Builder
+	for _, t := range findOpenTags(string(runes[:start])) {
+		sb.WriteString(t.tag)
+	}
+	offset := int32(utf8.RuneCountInString(sb.String()) - start)
+	sb.WriteString(string(runes[start:end]))
+	unclosed := findOpenTags(string(runes[:end]))
+	for i := len(unclosed) - 1; i >= 0; i-- {
+		sb.WriteString("</" + unclosed[i].name + ">")
+	}
+
+	isOnPage := func(i int32) bool { return int(i) >= start && int(i) < end }
+	c.FmtText = sb.String()
+	c.PageByIndex = shiftPairs(c.PageByIndex, isOnPage, offset)
+	c.LineByIndex = shiftPairs(c.LineByIndex, isOnPage, offset)
+	wordIndexMap := make(map[int32]int32)
+	for k, v := range c.WordIndexMap {
+		if isOnPage(v) {
+			wordIndexMap[k] = v + offset
+		}
+	}
+	c.WordIndexMap = wordIndexMap
+}
+
+func shiftPairs(pairs []model.IndexNumberPair, isOnPage func(i int32) bool, offset int32) []model.IndexNumberPair {
+	shifted := []model.IndexNumberPair{}
+	for _, p := range pairs {
+		if isOnPage(p.I) {
+			shifted = append(shifted, model.IndexNumberPair{I: p.I + offset, Num: p.Num})
+		}
+	}
+	return shifted
+}
+
+// FindLineIndex returns the FmtText index of the ks-meta-line tag of the given line on the given page, or nil if the line doesn't start in the text.
+func FindLineIndex(pageByIndex []model.IndexNumberPair, lineByIndex []model.IndexNumberPair, page int32, line int32) *int32 {
+	start, end := findPageBounds(pageByIndex, page, math.MaxInt32)
+	for _, l := range lineByIndex {
+		if int(l.I) >= start && int(l.I) < end && l.Num == line {
+			return &l.I
+		}
+	}
+	return nil
+}
+
+// FindOrigPageIndex returns the FmtText index where the given page of the original edition starts, or nil if the page doesn't start in the text.
+func FindOrigPageIndex(origPageByIndex []model.IndexPagePair, origPage string) *int32 {
+	for _, p := range origPageByIndex {
+		if p.Page == origPage {
+			return &p.I
+		}
+	}
+	return nil
+}
+
+func findPageBounds(pageByIndex []model.IndexNumberPair, page int32, length int) (int, int) {
+	start := 0
+	end := length
+	for _, p := range pageByIndex {
+		if p.Num == page {
+			start = int(p.I)
+		}
+	}
+	for _, p := range pageByIndex {
+		if int(p.I) > start && p.Num > page {
+			end = int(p.I)
+			break
+		}
+	}
+	return start, end
+}
+
+func findOpenTags(text string) []openTag {
+	stack := []openTag{}
+	for _, m := range tagMatcher.FindAllStringSubmatch(text, -1) {
+		switch {
+		case m[3] == "/":
+			continue // self-closing
+		case m[1] == "/":
+			if len(stack) > 0 && stack[len(stack)-1].name == m[2] {
+				stack = stack[:len(stack)-1]
+			}
+		default:
+			stack = append(stack, openTag{tag: m[0], name: m[2]})
+		}
+	}
+	return stack
+}
@@ -0,0 +1,100 @@
+//go:build unit
+// +build unit
+
+package pages
+
+import (
+	"testing"
+
+	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrimToPage(t *testing.T) {
+	text := "first <ks-fmt-emph>page <ks-meta-page>2</ks-meta-page>second</ks-fmt-emph> page <ks-meta-page>3</ks-meta-page><ks-fmt-bold>third</ks-fmt-bold> page"
+	testCases := []struct {
+		name     string
+		page     int32
+		expected model.Content
+	}{
+		{
+			name: "first page without page tag",
+			page: 1,
+			expected: model.Content{
+				FmtText:      "first <ks-fmt-emph>page </ks-fmt-emph>",
+				PageByIndex:  []model.IndexNumberPair{},
+				LineByIndex:  []model.IndexNumberPair{{I: 0, Num: 5}},
+				WordIndexMap: map[int32]int32{0: 0, 1: 19},
+			},
+		},
+		{
+			name: "page in the middle",
+			page: 2,
+			expected: model.Content{
+				FmtText:      "<ks-fmt-emph><ks-meta-page>2</ks-meta-page>second</ks-fmt-emph> page ",
+				PageByIndex:  []model.IndexNumberPair{{I: 13, Num: 2}},
+				LineByIndex:  []model.IndexNumberPair{{I: 43, Num: 1}},
+				WordIndexMap: map[int32]int32{2: 43, 3: 64},
+			},
+		},
+		{
+			name: "last page",
+			page: 3,
+			expected: model.Content{
+				FmtText:      "<ks-meta-page>3</ks-meta-page><ks-fmt-bold>third</ks-fmt-bold> page",
+				PageByIndex:  []model.IndexNumberPair{{I: 0, Num: 3}},
+				LineByIndex:  []model.IndexNumberPair{{I: 43, Num: 1}},
+				WordIndexMap: map[int32]int32{4: 43, 5: 63},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := model.Content{
+				FmtText:      text,
+				PageByIndex:  []model.IndexNumberPair{{I: 24, Num: 2}, {I: 80, Num: 3}},
+				LineByIndex:  []model.IndexNumberPair{{I: 0, Num: 5}, {I: 54, Num: 1}, {I: 123, Num: 1}},
+				WordIndexMap: map[int32]int32{0: 0, 1: 19, 2: 54, 3: 75, 4: 123, 5: 143},
+			}
+			TrimToPage(&c, tc.page)
+			assert.Equal(t, tc.expected, c)
+		})
+	}
+}
+
+func TestTrimToPageWithoutPageTags(t *testing.T) {
+	text := "<ks-fmt-bold>some</ks-fmt-bold> text<ks-meta-imgref src=\"a\" desc=\"b\"/>"
+	c := model.Content{FmtText: text, WordIndexMap: map[int32]int32{0: 13, 1: 32}}
+	TrimToPage(&c, 5)
+	assert.Equal(t, text, c.FmtText)
+	assert.Equal(t, map[int32]int32{0: 13, 1: 32}, c.WordIndexMap)
+}
+
+func TestFindOrigPageIndex(t *testing.T) {
+	origPageByIndex := []model.IndexPagePair{{I: 0, Page: "XVI"}, {I: 48, Page: "1"}}
+	assert.Equal(t, util.Int32Ptr(0), FindOrigPageIndex(origPageByIndex, "XVI"))
+	assert.Equal(t, util.Int32Ptr(48), FindOrigPageIndex(origPageByIndex, "1"))
+	assert.Nil(t, FindOrigPageIndex(origPageByIndex, "2"))
+}
+
+func TestFindLineIndex(t *testing.T) {
+	pageByIndex := []model.IndexNumberPair{{I: 40, Num: 2}}
+	lineByIndex := []model.IndexNumberPair{{I: 0, Num: 12}, {I: 20, Num: 13}, {I: 60, Num: 1}, {I: 80, Num: 2}, {I: 100, Num: 12}}
+	testCases := []struct {
+		name     string
+		page     int32
+		line     int32
+		expected *int32
+	}{
+		{name: "line on first page", page: 1, line: 13, expected: util.Int32Ptr(20)},
+		{name: "line on second page", page: 2, line: 2, expected: util.Int32Ptr(80)},
+		{name: "same line number on both pages", page: 2, line: 12, expected: util.Int32Ptr(100)},
+		{name: "line not on page", page: 1, line: 2, expected: nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, FindLineIndex(pageByIndex, lineByIndex, tc.page, tc.line))
+		})
+	}
+}
@@ -4,17 +4,141 @@ package read
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 
+	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/frhorschig/kant-search-backend/core/read/internal/citation"
+	"github.com/frhorschig/kant-search-backend/core/read/internal/pages"
 	"github.com/frhorschig/kant-search-backend/dataaccess"
 	"github.com/frhorschig/kant-search-backend/dataaccess/model"
 )
 
+// NoLimit is passed as the limit of the paginated contents to read all contents after the cursor
+const NoLimit = 0
+
 type ReadProcessor interface {
 	ProcessVolumes(ctx context.Context) ([]model.Volume, error)
-	ProcessFootnotes(ctx context.Context, workCode string, ordinals []int32) ([]model.Content, error)
-	ProcessHeadings(ctx context.Context, workCode string, ordinals []int32) ([]model.Content, error)
-	ProcessParagraphs(ctx context.Context, workCode string, ordinals []int32) ([]model.Content, error)
-	ProcessSummaries(ctx context.Context, workCode string, ordinals []int32) ([]model.Content, error)
+	ProcessVolume(ctx context.Context, volumeNumber int32) (*VolumeMetadata, error)
+	ProcessWork(ctx context.Context, workCode string) (*WorkMetadata, error)
+	ProcessVersion(ctx context.Context) (string, error)
+	ProcessVolumeVersion(ctx context.Context, volumeNumber int32) (*string, error)
+	ProcessWorkVersion(ctx context.Context, workCode string) (*string, error)
+	ProcessFootnotes(ctx context.Context, workCode string, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error)
+	ProcessHeadings(ctx context.Context, workCode string, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error)
+	ProcessParagraphs(ctx context.Context, workCode string, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error)
+	ProcessSummaries(ctx context.Context, workCode string, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error)
+	ProcessEmbedded(ctx context.Context, workCode string, contents []model.Content, embed Embed) ([]EmbeddedContent, error)
+	ProcessWorkText(ctx context.Context, workCode string, from *int32, to *int32) (*WorkText, error)
+	ProcessPage(ctx context.Context, volumeNumber int32, page int32) (*Page, error)
+	ProcessCitation(ctx context.Context, citation string) (*ResolvedCitation, error)
+	ProcessNavigation(ctx context.Context, workCode string, ordinal int32) (*Navigation, error)
+	ProcessPersons(ctx context.Context, cursor *string, limit int) (*PersonPage, error)
+}
+
+// VolumeMetadata is a volume with the content counts of its works
+type VolumeMetadata struct {
+	Volume model.Volume
+	Counts map[string]model.ContentCounts // by work code, every work of the volume is contained
+}
+
+// WorkMetadata is a work with the number of its volume and its content counts
+type WorkMetadata struct {
+	VolumeNumber int32
+	Work         model.Work
+	Counts       model.ContentCounts
+}
+
+// Embed selects the referenced contents that are embedded in headings and paragraphs
+type Embed struct {
+	Footnotes bool
+	Summaries bool
+}
+
+// EmbeddedContent is a heading or a paragraph with the summary and footnotes it references
+type EmbeddedContent struct {
+	Content   model.Content
+	Summary   *model.Content
+	Footnotes []model.Content // footnotes of the summary and of the content itself
+}
+
+// WorkText is the text of a work in reading order; from and to restrict the ordinals of its headings and paragraphs
+type WorkText struct {
+	Work  model.Work
+	Items []TextItem
+}
+
+// TextItem is a heading or a paragraph with its attached summary and footnotes
+type TextItem struct {
+	Content   model.Content
+	Level     int32 // nesting level of the enclosing section, 0 for paragraphs outside of any section
+	Summary   *model.Content
+	Footnotes []model.Content // footnotes of the summary and of the content itself
+}
+
+// Page contains all contents of a volume that are (partially) on the page, with the formatted texts trimmed to the page
+type Page struct {
+	VolumeNumber int32
+	Page         int32
+	Contents     []model.Content
+	Prev         *int32 // nil if this is the first page of the volume
+	Next         *int32 // nil if this is the last page of the volume
+}
+
+// ResolvedCitation contains the contents of a work that are on the cited page or contain the cited paragraph
+type ResolvedCitation struct {
+	WorkCode   string
+	Ordinals   []int32
+	LineOffset *LineOffset // only for citations with a line that starts on the cited page and for citations of the original editions
+}
+
+type LineOffset struct {
+	Ordinal int32
+	Index   int32 // FmtText index (rune, not byte index) of the ks-meta-line tag of the cited line, or of the start of the cited page of an original edition
+}
+
+// Navigation contains the headings and paragraphs before and after a content in reading order, across the boundaries of works and volumes; a location is nil if there is no such heading or paragraph
+type Navigation struct {
+	PrevHeading   *Location
+	PrevParagraph *Location
+	NextHeading   *Location
+	NextParagraph *Location
+}
+
+type Location struct {
+	VolumeNumber int32
+	WorkCode     string
+	Ordinal      int32
+}
+
+// Person is a normalized person name with the contents that name the person in reading order
+type Person struct {
+	Name        string
+	Occurrences []Occurrence
+}
+
+// PersonPage is a page of persons sorted by their name; NextCursor is the name after which the next page starts, or nil if this is the last page
+type PersonPage struct {
+	Persons    []Person
+	NextCursor *string
+}
+
+type Occurrence struct {
+	Location
+	Type model.Type
+}
+
+// CitationError is returned for citations that can't be parsed or resolved with the stored data
+type CitationError struct {
+	Msg string
+}
+
+func (e *CitationError) Error() string {
+	return e.Msg
 }
 
 type readProcessorImpl struct {
@@ -34,18 +158,574 @@ func (rec *readProcessorImpl) ProcessVolumes(ctx context.Context) ([]model.Volum
 	return rec.volumeRepo.GetAll(ctx)
 }
 
-func (rec *readProcessorImpl) ProcessFootnotes(ctx context.Context, workCode string, ordinals []int32) ([]model.Content, error) {
-	return rec.contentRepo.GetFootnotesByWork(ctx, workCode, ordinals)
+func (rec *readProcessorImpl) ProcessVolume(ctx context.Context, volumeNumber int32) (*VolumeMetadata, error) {
+	volume, err := rec.volumeRepo.GetByVolumeNumber(ctx, volumeNumber)
+	if err != nil {
+		return nil, err
+	}
+	if volume == nil {
+		return nil, nil
+	}
+	counts, err := rec.contentRepo.CountByWorks(ctx, findWorkCodes(volume.Works))
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range volume.Works {
+		if _, ok := counts[w.Code]; !ok {
+			counts[w.Code] = model.ContentCounts{}
+		}
+	}
+	return &VolumeMetadata{Volume: *volume, Counts: counts}, nil
+}
+
+func (rec *readProcessorImpl) ProcessWork(ctx context.Context, workCode string) (*WorkMetadata, error) {
+	volume, err := rec.volumeRepo.GetByWorkCode(ctx, workCode)
+	if err != nil {
+		return nil, err
+	}
+	work := findWorkInVolume(volume, workCode)
+	if work == nil {
+		return nil, nil
+	}
+	counts, err := rec.contentRepo.CountByWorks(ctx, []string{workCode})
+	if err != nil {
+		return nil, err
+	}
+	workCounts, ok := counts[workCode]
+	if !ok {
+		workCounts = model.ContentCounts{}
+	}
+	return &WorkMetadata{VolumeNumber: volume.VolumeNumber, Work: *work, Counts: workCounts}, nil
+}
+
+// ProcessVersion returns a version of the data of all volumes, or an empty string if a volume has no version
+func (rec *readProcessorImpl) ProcessVersion(ctx context.Context) (string, error) {
+	volumes, err := rec.volumeRepo.GetAll(ctx)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	for _, v := range volumes {
+		if v.Version == "" {
+			return "", nil
+		}
+		hash.Write([]byte(fmt.Sprintf("%d:%s;", v.VolumeNumber, v.Version)))
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:8]), nil
+}
+
+// ProcessVolumeVersion returns the version of the volume, or nil if the volume doesn't exist
+func (rec *readProcessorImpl) ProcessVolumeVersion(ctx context.Context, volumeNumber int32) (*string, error) {
+	volume, err := rec.volumeRepo.GetByVolumeNumber(ctx, volumeNumber)
+	if err != nil || volume == nil {
+		return nil, err
+	}
+	return &volume.Version, nil
+}
+
+// ProcessWorkVersion returns the version of the volume of the work, or nil if the work doesn't exist
+func (rec *readProcessorImpl) ProcessWorkVersion(ctx context.Context, workCode string) (*string, error) {
+	volume, err := rec.volumeRepo.GetByWorkCode(ctx, workCode)
+	if err != nil || volume == nil {
+		return nil, err
+	}
+	return &volume.Version, nil
+}
+
+func (rec *readProcessorImpl) ProcessFootnotes(ctx context.Context, workCode string, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error) {
+	return readPages(cursor, limit, func(cursor *int32, limit int) (*model.ContentPage, error) {
+		return rec.contentRepo.GetFootnotesByWork(ctx, workCode, ordinals, cursor, limit)
+	})
+}
+
+func (rec *readProcessorImpl) ProcessHeadings(ctx context.Context, workCode string, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error) {
+	return readPages(cursor, limit, func(cursor *int32, limit int) (*model.ContentPage, error) {
+		return rec.contentRepo.GetHeadingsByWork(ctx, workCode, ordinals, cursor, limit)
+	})
+}
+
+func (rec *readProcessorImpl) ProcessParagraphs(ctx context.Context, workCode string, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error) {
+	return readPages(cursor, limit, func(cursor *int32, limit int) (*model.ContentPage, error) {
+		return rec.contentRepo.GetParagraphsByWork(ctx, workCode, ordinals, cursor, limit)
+	})
+}
+
+func (rec *readProcessorImpl) ProcessSummaries(ctx context.Context, workCode string, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error) {
+	return readPages(cursor, limit, func(cursor *int32, limit int) (*model.ContentPage, error) {
+		return rec.contentRepo.GetSummariesByWork(ctx, workCode, ordinals, cursor, limit)
+	})
+}
+
+// readPages returns a single page if there is a limit, otherwise it reads all pages after the cursor and returns them as one page without a next cursor
+func readPages(cursor *int32, limit int, getPage func(cursor *int32, limit int) (*model.ContentPage, error)) (*model.ContentPage, error) {
+	if limit != NoLimit {
+		return getPage(cursor, limit)
+	}
+	all := &model.ContentPage{Contents: []model.Content{}}
+	for {
+		page, err := getPage(cursor, dataaccess.MaxPageLimit)
+		if err != nil {
+			return nil, err
+		}
+		all.Contents = append(all.Contents, page.Contents...)
+		if page.NextCursor == nil {
+			return all, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// ProcessEmbedded resolves the refs of the contents with a single query; only if embedded summaries reference footnotes that aren't referenced by the contents themselves, a second query is necessary
+func (rec *readProcessorImpl) ProcessEmbedded(ctx context.Context, workCode string, contents []model.Content, embed Embed) ([]EmbeddedContent, error) {
+	cTypes := []model.Type{}
+	refs := []string{}
+	if embed.Footnotes {
+		cTypes = append(cTypes, model.Footnote)
+		for _, c := range contents {
+			refs = append(refs, c.FnRefs...)
+		}
+	}
+	if embed.Summaries {
+		cTypes = append(cTypes, model.Summary)
+		for _, c := range contents {
+			if c.SummaryRef != nil {
+				refs = append(refs, *c.SummaryRef)
+			}
+		}
+	}
+	referenced, err := rec.contentRepo.GetByRefs(ctx, workCode, cTypes, uniqueRefs(refs))
+	if err != nil {
+		return nil, err
+	}
+
+	fnByRef := make(map[string]model.Content)
+	summByRef := make(map[string]model.Content)
+	for _, c := range referenced {
+		if c.Type == model.Footnote {
+			fnByRef[util.StrVal(c.Ref)] = c
+		} else {
+			summByRef[util.StrVal(c.Ref)] = c
+		}
+	}
+	if embed.Footnotes && embed.Summaries {
+		missing := []string{}
+		for _, summ := range summByRef {
+			for _, ref := range summ.FnRefs {
+				if _, ok := fnByRef[ref]; !ok {
+					missing = append(missing, ref)
+				}
+			}
+		}
+		if len(missing) > 0 {
+			summFns, err := rec.contentRepo.GetByRefs(ctx, workCode, []model.Type{model.Footnote}, uniqueRefs(missing))
+			if err != nil {
+				return nil, err
+			}
+			for _, fn := range summFns {
+				fnByRef[util.StrVal(fn.Ref)] = fn
+			}
+		}
+	}
+
+	result := []EmbeddedContent{}
+	for _, c := range contents {
+		item := EmbeddedContent{Content: c, Footnotes: []model.Content{}}
+		if c.SummaryRef != nil {
+			if summ, ok := summByRef[*c.SummaryRef]; ok {
+				item.Summary = &summ
+				item.Footnotes = appendFootnotes(item.Footnotes, summ.FnRefs, fnByRef)
+			}
+		}
+		item.Footnotes = appendFootnotes(item.Footnotes, c.FnRefs, fnByRef)
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func (rec *readProcessorImpl) ProcessWorkText(ctx context.Context, workCode string, from *int32, to *int32) (*WorkText, error) {
+	work, err := rec.findWork(ctx, workCode)
+	if err != nil {
+		return nil, err
+	}
+	if work == nil {
+		return nil, nil
+	}
+	contents, err := rec.contentRepo.GetByWork(ctx, workCode, []model.Type{model.Heading, model.Paragraph}, from, to)
+	if err != nil {
+		return nil, err
+	}
+	// only the footnotes and summaries referenced by the selected contents are read
+	embedded, err := rec.ProcessEmbedded(ctx, workCode, contents, Embed{Footnotes: true, Summaries: true})
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make(map[int32]int32)
+	addLevels(levels, work.Paragraphs, work.Sections, 0)
+
+	items := []TextItem{}
+	for _, e := range embedded {
+		items = append(items, TextItem{
+			Content:   e.Content,
+			Level:     levels[e.Content.Ordinal],
+			Summary:   e.Summary,
+			Footnotes: e.Footnotes,
+		})
+	}
+	return &WorkText{Work: *work, Items: items}, nil
+}
+
+func (rec *readProcessorImpl) ProcessPage(ctx context.Context, volumeNumber int32, page int32) (*Page, error) {
+	volume, err := rec.volumeRepo.GetByVolumeNumber(ctx, volumeNumber)
+	if err != nil {
+		return nil, err
+	}
+	if volume == nil {
+		return nil, nil
+	}
+	workCodes := findWorkCodes(volume.Works)
+	contents, err := rec.getPageContents(ctx, volume.Works, page)
+	if err != nil {
+		return nil, err
+	}
+	if len(contents) == 0 {
+		return nil, nil
+	}
+	pageRange, err := rec.contentRepo.GetPageRange(ctx, workCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range contents {
+		pages.TrimToPage(&contents[i], page)
+	}
+	result := &Page{
+		VolumeNumber: volumeNumber,
+		Page:         page,
+		Contents:     contents,
+	}
+	if pageRange != nil && page > pageRange.First {
+		prev := page - 1
+		result.Prev = &prev
+	}
+	if pageRange != nil && page < pageRange.Last {
+		next := page + 1
+		result.Next = &next
+	}
+	return result, nil
+}
+
+func (rec *readProcessorImpl) ProcessCitation(ctx context.Context, citationStr string) (*ResolvedCitation, error) {
+	c, err := citation.Parse(citationStr)
+	if err != nil {
+		return nil, &CitationError{Msg: err.Error()}
+	}
+	volumes, err := rec.volumeRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if c.Edition != "" {
+		return rec.resolveOrigPage(ctx, volumes, *c.Siglum, c.Edition, c.EditionPage)
+	}
+
+	if c.Siglum == nil {
+		for _, v := range volumes {
+			if v.VolumeNumber == c.Volume {
+				return rec.resolvePage(ctx, v.Works, c.Page, c.Line)
+			}
+		}
+		return nil, nil
+	}
+	for _, v := range volumes {
+		for _, w := range v.Works {
+			if !matchesSiglum(w, *c.Siglum) || (c.Volume != 0 && c.Volume != v.VolumeNumber) {
+				continue
+			}
+			if c.Paragraph > 0 {
+				return rec.resolveParagraph(ctx, w.Code, c.Paragraph)
+			}
+			return rec.resolvePage(ctx, []model.Work{w}, c.Page, c.Line)
+		}
+	}
+	return nil, nil
+}
+
+// ProcessNavigation returns nil if the work doesn't exist; the ordinal can be the ordinal of any content of the work, e.g. of a footnote of a search result
+func (rec *readProcessorImpl) ProcessNavigation(ctx context.Context, workCode string, ordinal int32) (*Navigation, error) {
+	volumes, err := rec.volumeRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	works := findOrderedWorks(volumes)
+	i := slices.IndexFunc(works, func(w orderedWork) bool { return w.code == workCode })
+	if i < 0 {
+		return nil, nil
+	}
+	return &Navigation{
+		PrevHeading:   findPrevLocation(works, i, ordinal, func(w orderedWork) []int32 { return w.headings }),
+		PrevParagraph: findPrevLocation(works, i, ordinal, func(w orderedWork) []int32 { return w.paragraphs }),
+		NextHeading:   findNextLocation(works, i, ordinal, func(w orderedWork) []int32 { return w.headings }),
+		NextParagraph: findNextLocation(works, i, ordinal, func(w orderedWork) []int32 { return w.paragraphs }),
+	}, nil
+}
+
+// ProcessPersons returns the persons sorted by their name whose name is greater than the cursor, at most limit persons (or all of them with NoLimit); contents of works that aren't part of any volume are ignored
+func (rec *readProcessorImpl) ProcessPersons(ctx context.Context, cursor *string, limit int) (*PersonPage, error) {
+	volumes, err := rec.volumeRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := rec.contentRepo.GetWithPersons(ctx)
+	if err != nil {
+		return nil, err
+	}
+	works := findOrderedWorks(volumes)
+	workIndex := make(map[string]int)
+	for i, w := range works {
+		workIndex[w.code] = i
+	}
+	// the contents are sorted by their work code and ordinal, but the works must be in reading order
+	slices.SortStableFunc(contents, func(a, b model.Content) int {
+		return workIndex[a.WorkCode] - workIndex[b.WorkCode]
+	})
+
+	occurrences := make(map[string][]Occurrence)
+	for _, c := range contents {
+		i, ok := workIndex[c.WorkCode]
+		if !ok {
+			continue
+		}
+		loc := Location{VolumeNumber: works[i].volumeNumber, WorkCode: c.WorkCode, Ordinal: c.Ordinal}
+		for _, name := range c.Persons {
+			occurrences[name] = append(occurrences[name], Occurrence{Location: loc, Type: c.Type})
+		}
+	}
+	persons := []Person{}
+	for name, occs := range occurrences {
+		if cursor != nil && name <= *cursor {
+			continue
+		}
+		persons = append(persons, Person{Name: name, Occurrences: occs})
+	}
+	slices.SortFunc(persons, func(a, b Person) int { return strings.Compare(a.Name, b.Name) })
+	if limit == NoLimit || len(persons) <= limit {
+		return &PersonPage{Persons: persons}, nil
+	}
+	persons = persons[:limit]
+	return &PersonPage{Persons: persons, NextCursor: &persons[limit-1].Name}, nil
 }
 
-func (rec *readProcessorImpl) ProcessHeadings(ctx context.Context, workCode string, ordinals []int32) ([]model.Content, error) {
-	return rec.contentRepo.GetHeadingsByWork(ctx, workCode, ordinals)
+func (rec *readProcessorImpl) resolvePage(ctx context.Context, works []model.Work, page int32, line *int32) (*ResolvedCitation, error) {
+	contents, err := rec.getPageContents(ctx, works, page)
+	if err != nil {
+		return nil, err
+	}
+	if len(contents) == 0 {
+		return nil, nil
+	}
+
+	result := &ResolvedCitation{WorkCode: contents[0].WorkCode}
+	if line != nil {
+		for _, c := range contents {
+			index := pages.FindLineIndex(c.PageByIndex, c.LineByIndex, page, *line)
+			if index != nil {
+				result.WorkCode = c.WorkCode
+				result.LineOffset = &LineOffset{Ordinal: c.Ordinal, Index: *index}
+				break
+			}
+		}
+	}
+	result.Ordinals = []int32{}
+	for _, c := range contents {
+		if c.WorkCode == result.WorkCode {
+			result.Ordinals = append(result.Ordinals, c.Ordinal)
+		}
+	}
+	return result, nil
 }
 
-func (rec *readProcessorImpl) ProcessParagraphs(ctx context.Context, workCode string, ordinals []int32) ([]model.Content, error) {
-	return rec.contentRepo.GetParagraphsByWork(ctx, workCode, ordinals)
+// resolveOrigPage resolves a page of an original edition in the work of this edition, e.g. "KrV B 132" in the work with the siglum "KrV B"; if there is no such work, the page is resolved in the work with the siglum without the edition, e.g. for works with a single original edition
+func (rec *readProcessorImpl) resolveOrigPage(ctx context.Context, volumes []model.Volume, siglum string, edition string, origPage string) (*ResolvedCitation, error) {
+	work := findWorkBySiglum(volumes, siglum+" "+edition)
+	if work == nil {
+		work = findWorkBySiglum(volumes, siglum)
+	}
+	if work == nil {
+		return nil, nil
+	}
+	contents, err := rec.contentRepo.GetByOrigPage(ctx, work.Code, origPage)
+	if err != nil {
+		return nil, err
+	}
+	if len(contents) == 0 {
+		return nil, nil
+	}
+
+	result := &ResolvedCitation{WorkCode: work.Code, Ordinals: []int32{}}
+	for _, c := range contents {
+		result.Ordinals = append(result.Ordinals, c.Ordinal)
+	}
+	if index := pages.FindOrigPageIndex(contents[0].OrigPageByIndex, origPage); index != nil {
+		result.LineOffset = &LineOffset{Ordinal: contents[0].Ordinal, Index: *index}
+	}
+	return result, nil
 }
 
-func (rec *readProcessorImpl) ProcessSummaries(ctx context.Context, workCode string, ordinals []int32) ([]model.Content, error) {
-	return rec.contentRepo.GetSummariesByWork(ctx, workCode, ordinals)
+func (rec *readProcessorImpl) resolveParagraph(ctx context.Context, workCode string, paragraph int32) (*ResolvedCitation, error) {
+	contents, err := rec.contentRepo.GetByWork(ctx, workCode, []model.Type{model.Heading, model.Paragraph}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	matcher := regexp.MustCompile(fmt.Sprintf(`^§+\.?\s*%d\b`, paragraph))
+	for _, c := range contents {
+		if matcher.MatchString(strings.TrimSpace(c.SearchText)) {
+			return &ResolvedCitation{WorkCode: workCode, Ordinals: []int32{c.Ordinal}}, nil
+		}
+	}
+	return nil, nil
+}
+
+// getPageContents returns the contents of the works on the page in reading order
+func (rec *readProcessorImpl) getPageContents(ctx context.Context, works []model.Work, page int32) ([]model.Content, error) {
+	contents, err := rec.contentRepo.GetByPage(ctx, findWorkCodes(works), page)
+	if err != nil {
+		return nil, err
+	}
+	workIndex := make(map[string]int)
+	for i, w := range works {
+		workIndex[w.Code] = i
+	}
+	// the contents are sorted by their ordinal, which is only unique inside a work
+	slices.SortStableFunc(contents, func(a, b model.Content) int {
+		return workIndex[a.WorkCode] - workIndex[b.WorkCode]
+	})
+	return contents, nil
+}
+
+// orderedWork is a work with the sorted ordinals of its headings and paragraphs
+type orderedWork struct {
+	volumeNumber int32
+	code         string
+	headings     []int32
+	paragraphs   []int32
+}
+
+// findOrderedWorks returns the works of the volumes in reading order; the volumes must be sorted by their number, the works are sorted by their ordinal
+func findOrderedWorks(volumes []model.Volume) []orderedWork {
+	works := []orderedWork{}
+	for _, v := range volumes {
+		volWorks := slices.Clone(v.Works)
+		slices.SortFunc(volWorks, func(a, b model.Work) int { return int(a.Ordinal - b.Ordinal) })
+		for _, w := range volWorks {
+			work := orderedWork{volumeNumber: v.VolumeNumber, code: w.Code, headings: []int32{}, paragraphs: slices.Clone(w.Paragraphs)}
+			addSectionOrdinals(&work, w.Sections)
+			slices.Sort(work.headings)
+			slices.Sort(work.paragraphs)
+			works = append(works, work)
+		}
+	}
+	return works
+}
+
+func addSectionOrdinals(work *orderedWork, sections []model.Section) {
+	for _, s := range sections {
+		work.headings = append(work.headings, s.Heading)
+		work.paragraphs = append(work.paragraphs, s.Paragraphs...)
+		addSectionOrdinals(work, s.Sections)
+	}
+}
+
+// findPrevLocation returns the last ordinal before the given ordinal in the work with index i, or the last ordinal of the closest previous work that has one
+func findPrevLocation(works []orderedWork, i int, ordinal int32, ordinals func(orderedWork) []int32) *Location {
+	for j := i; j >= 0; j-- {
+		ords := ordinals(works[j])
+		for k := len(ords) - 1; k >= 0; k-- {
+			if j < i || ords[k] < ordinal {
+				return &Location{VolumeNumber: works[j].volumeNumber, WorkCode: works[j].code, Ordinal: ords[k]}
+			}
+		}
+	}
+	return nil
+}
+
+// findNextLocation returns the first ordinal after the given ordinal in the work with index i, or the first ordinal of the closest next work that has one
+func findNextLocation(works []orderedWork, i int, ordinal int32, ordinals func(orderedWork) []int32) *Location {
+	for j := i; j < len(works); j++ {
+		for _, ord := range ordinals(works[j]) {
+			if j > i || ord > ordinal {
+				return &Location{VolumeNumber: works[j].volumeNumber, WorkCode: works[j].code, Ordinal: ord}
+			}
+		}
+	}
+	return nil
+}
+
+func findWorkCodes(works []model.Work) []string {
+	codes := []string{}
+	for _, w := range works {
+		codes = append(codes, w.Code)
+	}
+	return codes
+}
+
+func matchesSiglum(work model.Work, siglum string) bool {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.NewReplacer(" ", "", ".", "").Replace(s))
+	}
+	return work.Siglum != nil && normalize(*work.Siglum) == normalize(siglum)
+}
+
+func findWorkBySiglum(volumes []model.Volume, siglum string) *model.Work {
+	for _, v := range volumes {
+		for _, w := range v.Works {
+			if matchesSiglum(w, siglum) {
+				return &w
+			}
+		}
+	}
+	return nil
+}
+
+func (rec *readProcessorImpl) findWork(ctx context.Context, workCode string) (*model.Work, error) {
+	volume, err := rec.volumeRepo.GetByWorkCode(ctx, workCode)
+	if err != nil {
+		return nil, err
+	}
+	return findWorkInVolume(volume, workCode), nil
+}
+
+func findWorkInVolume(volume *model.Volume, workCode string) *model.Work {
+	if volume == nil {
+		return nil
+	}
+	for _, w := range volume.Works {
+		if w.Code == workCode {
+			return &w
+		}
+	}
+	return nil
+}
+
+func addLevels(levels map[int32]int32, paragraphs []int32, sections []model.Section, level int32) {
+	for _, p := range paragraphs {
+		levels[p] = level
+	}
+	for _, s := range sections {
+		levels[s.Heading] = level + 1
+		addLevels(levels, s.Paragraphs, s.Sections, level+1)
+	}
+}
+
+func uniqueRefs(refs []string) []string {
+	slices.Sort(refs)
+	return slices.Compact(refs)
+}
+
+func appendFootnotes(footnotes []model.Content, fnRefs []string, fnByRef map[string]model.Content) []model.Content {
+	for _, ref := range fnRefs {
+		if fn, ok := fnByRef[ref]; ok {
+			footnotes = append(footnotes, fn)
+		}
+	}
+	return footnotes
 }
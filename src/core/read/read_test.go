@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/frhorschig/kant-search-backend/dataaccess"
 	"github.com/frhorschig/kant-search-backend/dataaccess/mocks"
 	dbMocks "github.com/frhorschig/kant-search-backend/dataaccess/mocks"
 	"github.com/frhorschig/kant-search-backend/dataaccess/model"
@@ -44,6 +45,8 @@ func TestReadProcessor(t *testing.T) {
 		"Process headings with error":   testProcessHeadingsError,
 		"Process paragraphs":            testProcessParagraphs,
 		"Process paragraphs with error": testProcessParagraphsError,
+		"Process paragraphs with limit": testProcessParagraphsWithLimit,
+		"Process paragraphs no limit":   testProcessParagraphsWithoutLimit,
 		"Process summaries":             testProcessSummaries,
 		"Process summaries with error":  testProcessSummariesError,
 	} {
@@ -51,6 +54,50 @@ func TestReadProcessor(t *testing.T) {
 			fn(t, sut, contentRepo, ctx)
 		})
 	}
+	for scenario, fn := range map[string]func(*testing.T, *readProcessorImpl, *mocks.MockVolumeRepo, *mocks.MockContentRepo, context.Context){
+		"Process volume":                      testProcessVolume,
+		"Process unknown volume":              testProcessVolumeUnknown,
+		"Process volume with error":           testProcessVolumeError,
+		"Process work":                        testProcessWork,
+		"Process unknown work":                testProcessWorkUnknown,
+		"Process work with error":             testProcessWorkError,
+		"Process version":                     testProcessVersion,
+		"Process version without versions":    testProcessVersionMissing,
+		"Process volume version":              testProcessVolumeVersion,
+		"Process work version":                testProcessWorkVersion,
+		"Process unknown work version":        testProcessWorkVersionUnknown,
+		"Process embedded":                    testProcessEmbedded,
+		"Process embedded footnotes":          testProcessEmbeddedFootnotes,
+		"Process embedded with error":         testProcessEmbeddedError,
+		"Process work text":                   testProcessWorkText,
+		"Process work text with unknown work": testProcessWorkTextUnknownWork,
+		"Process work text with error":        testProcessWorkTextError,
+		"Process page":                        testProcessPage,
+		"Process page of unknown volume":      testProcessPageUnknownVolume,
+		"Process page without contents":       testProcessPageWithoutContents,
+		"Process page with error":             testProcessPageError,
+		"Process AA citation":                 testProcessAaCitation,
+		"Process siglum citation":             testProcessSiglumCitation,
+		"Process paragraph citation":          testProcessParagraphCitation,
+		"Process original edition citation":   testProcessOrigPageCitation,
+		"Process original page of work":       testProcessOrigPageOfWorkCitation,
+		"Process roman original page":         testProcessRomanOrigPageCitation,
+		"Process unknown siglum citation":     testProcessUnknownSiglumCitation,
+		"Process invalid citation":            testProcessInvalidCitation,
+		"Process citation with error":         testProcessCitationError,
+		"Process navigation":                  testProcessNavigation,
+		"Process navigation across works":     testProcessNavigationAcrossWorks,
+		"Process navigation at the ends":      testProcessNavigationAtEnds,
+		"Process navigation of unknown work":  testProcessNavigationUnknownWork,
+		"Process navigation with error":       testProcessNavigationError,
+		"Process persons":                     testProcessPersons,
+		"Process persons with cursor":         testProcessPersonsWithCursor,
+		"Process persons with error":          testProcessPersonsError,
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			fn(t, sut, volumeRepo, contentRepo, ctx)
+		})
+	}
 }
 
 func testProcessVolumes(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, ctx context.Context) {
@@ -95,23 +142,23 @@ func testProcessFootnotes(t *testing.T, sut *readProcessorImpl, contentRepo *moc
 	}
 	// GIVEN
 	contentRepo.EXPECT().
-		GetFootnotesByWork(gomock.Any(), workCode, []int32{}).
-		Return([]model.Content{fn}, nil)
+		GetFootnotesByWork(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), 10).
+		Return(&model.ContentPage{Contents: []model.Content{fn}}, nil)
 	// WHEN
-	res, err := sut.ProcessFootnotes(ctx, workCode, []int32{})
+	res, err := sut.ProcessFootnotes(ctx, workCode, []model.OrdinalRange{}, nil, 10)
 	// THEN
 	assert.Nil(t, err)
-	assert.Len(t, res, 1)
-	assert.Equal(t, fn, res[0])
+	assert.Len(t, res.Contents, 1)
+	assert.Equal(t, fn, res.Contents[0])
 }
 
 func testProcessFootnotesError(t *testing.T, sut *readProcessorImpl, contentRepo *mocks.MockContentRepo, ctx context.Context) {
 	workCode := "workCode"
 	e := errors.New("test error")
 	// GIVEN
-	contentRepo.EXPECT().GetFootnotesByWork(gomock.Any(), workCode, []int32{}).Return(nil, e)
+	contentRepo.EXPECT().GetFootnotesByWork(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), 10).Return(nil, e)
 	// WHEN
-	res, err := sut.ProcessFootnotes(ctx, workCode, []int32{})
+	res, err := sut.ProcessFootnotes(ctx, workCode, []model.OrdinalRange{}, nil, 10)
 	// THEN
 	assert.NotNil(t, err)
 	assert.Nil(t, res)
@@ -129,23 +176,23 @@ func testProcessHeadings(t *testing.T, sut *readProcessorImpl, contentRepo *mock
 	}
 	// GIVEN
 	contentRepo.EXPECT().
-		GetHeadingsByWork(gomock.Any(), workCode, []int32{}).
-		Return([]model.Content{head}, nil)
+		GetHeadingsByWork(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), 10).
+		Return(&model.ContentPage{Contents: []model.Content{head}}, nil)
 	// WHEN
-	res, err := sut.ProcessHeadings(ctx, workCode, []int32{})
+	res, err := sut.ProcessHeadings(ctx, workCode, []model.OrdinalRange{}, nil, 10)
 	// THEN
 	assert.Nil(t, err)
-	assert.Len(t, res, 1)
-	assert.Equal(t, head, res[0])
+	assert.Len(t, res.Contents, 1)
+	assert.Equal(t, head, res.Contents[0])
 }
 
 func testProcessHeadingsError(t *testing.T, sut *readProcessorImpl, contentRepo *mocks.MockContentRepo, ctx context.Context) {
 	workCode := "workCode"
 	e := errors.New("test error")
 	// GIVEN
-	contentRepo.EXPECT().GetHeadingsByWork(gomock.Any(), workCode, []int32{}).Return(nil, e)
+	contentRepo.EXPECT().GetHeadingsByWork(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), 10).Return(nil, e)
 	// WHEN
-	res, err := sut.ProcessHeadings(ctx, workCode, []int32{})
+	res, err := sut.ProcessHeadings(ctx, workCode, []model.OrdinalRange{}, nil, 10)
 	// THEN
 	assert.NotNil(t, err)
 	assert.Nil(t, res)
@@ -164,23 +211,57 @@ func testProcessParagraphs(t *testing.T, sut *readProcessorImpl, contentRepo *mo
 	}
 	// GIVEN
 	contentRepo.EXPECT().
-		GetParagraphsByWork(gomock.Any(), workCode, []int32{}).
-		Return([]model.Content{par}, nil)
+		GetParagraphsByWork(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), 10).
+		Return(&model.ContentPage{Contents: []model.Content{par}}, nil)
 	// WHEN
-	res, err := sut.ProcessParagraphs(ctx, workCode, []int32{})
+	res, err := sut.ProcessParagraphs(ctx, workCode, []model.OrdinalRange{}, nil, 10)
 	// THEN
 	assert.Nil(t, err)
-	assert.Len(t, res, 1)
-	assert.Equal(t, par, res[0])
+	assert.Len(t, res.Contents, 1)
+	assert.Equal(t, par, res.Contents[0])
+}
+
+func testProcessParagraphsWithLimit(t *testing.T, sut *readProcessorImpl, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	workCode := "workCode"
+	par := model.Content{Type: model.Paragraph, Ordinal: 3, WorkCode: workCode}
+	// GIVEN
+	contentRepo.EXPECT().
+		GetParagraphsByWork(gomock.Any(), workCode, []model.OrdinalRange{}, util.Int32Ptr(2), 1).
+		Return(&model.ContentPage{Contents: []model.Content{par}, NextCursor: util.Int32Ptr(3)}, nil)
+	// WHEN
+	res, err := sut.ProcessParagraphs(ctx, workCode, []model.OrdinalRange{}, util.Int32Ptr(2), 1)
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, []model.Content{par}, res.Contents)
+	assert.Equal(t, util.Int32Ptr(3), res.NextCursor)
+}
+
+func testProcessParagraphsWithoutLimit(t *testing.T, sut *readProcessorImpl, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	workCode := "workCode"
+	par1 := model.Content{Type: model.Paragraph, Ordinal: 1, WorkCode: workCode}
+	par2 := model.Content{Type: model.Paragraph, Ordinal: 2, WorkCode: workCode}
+	// GIVEN
+	contentRepo.EXPECT().
+		GetParagraphsByWork(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), dataaccess.MaxPageLimit).
+		Return(&model.ContentPage{Contents: []model.Content{par1}, NextCursor: util.Int32Ptr(1)}, nil)
+	contentRepo.EXPECT().
+		GetParagraphsByWork(gomock.Any(), workCode, []model.OrdinalRange{}, util.Int32Ptr(1), dataaccess.MaxPageLimit).
+		Return(&model.ContentPage{Contents: []model.Content{par2}}, nil)
+	// WHEN
+	res, err := sut.ProcessParagraphs(ctx, workCode, []model.OrdinalRange{}, nil, NoLimit)
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, []model.Content{par1, par2}, res.Contents)
+	assert.Nil(t, res.NextCursor)
 }
 
 func testProcessParagraphsError(t *testing.T, sut *readProcessorImpl, contentRepo *mocks.MockContentRepo, ctx context.Context) {
 	workCode := "workCode"
 	e := errors.New("test error")
 	// GIVEN
-	contentRepo.EXPECT().GetParagraphsByWork(gomock.Any(), workCode, []int32{}).Return(nil, e)
+	contentRepo.EXPECT().GetParagraphsByWork(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), 10).Return(nil, e)
 	// WHEN
-	res, err := sut.ProcessParagraphs(ctx, workCode, []int32{})
+	res, err := sut.ProcessParagraphs(ctx, workCode, []model.OrdinalRange{}, nil, 10)
 	// THEN
 	assert.NotNil(t, err)
 	assert.Nil(t, res)
@@ -198,24 +279,693 @@ func testProcessSummaries(t *testing.T, sut *readProcessorImpl, contentRepo *moc
 	}
 	// GIVEN
 	contentRepo.EXPECT().
-		GetSummariesByWork(gomock.Any(), workCode, []int32{}).
-		Return([]model.Content{summ}, nil)
+		GetSummariesByWork(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), 10).
+		Return(&model.ContentPage{Contents: []model.Content{summ}}, nil)
 	// WHEN
-	res, err := sut.ProcessSummaries(ctx, workCode, []int32{})
+	res, err := sut.ProcessSummaries(ctx, workCode, []model.OrdinalRange{}, nil, 10)
 	// THEN
 	assert.Nil(t, err)
-	assert.Len(t, res, 1)
-	assert.Equal(t, summ, res[0])
+	assert.Len(t, res.Contents, 1)
+	assert.Equal(t, summ, res.Contents[0])
 }
 
 func testProcessSummariesError(t *testing.T, sut *readProcessorImpl, contentRepo *mocks.MockContentRepo, ctx context.Context) {
 	workCode := "workCode"
 	e := errors.New("test error")
 	// GIVEN
-	contentRepo.EXPECT().GetSummariesByWork(gomock.Any(), workCode, []int32{}).Return(nil, e)
+	contentRepo.EXPECT().GetSummariesByWork(gomock.Any(), workCode, []model.OrdinalRange{}, gomock.Nil(), 10).Return(nil, e)
 	// WHEN
-	res, err := sut.ProcessSummaries(ctx, workCode, []int32{})
+	res, err := sut.ProcessSummaries(ctx, workCode, []model.OrdinalRange{}, nil, 10)
 	// THEN
 	assert.NotNil(t, err)
 	assert.Nil(t, res)
 }
+
+func testProcessVolume(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	vol := model.Volume{
+		VolumeNumber: 4,
+		Title:        "volume title",
+		Works:        []model.Work{{Code: "GMS"}, {Code: "MAN"}},
+	}
+	gmsCounts := model.ContentCounts{model.Heading: 3, model.Paragraph: 10}
+	// GIVEN
+	volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(4)).Return(&vol, nil)
+	contentRepo.EXPECT().CountByWorks(gomock.Any(), []string{"GMS", "MAN"}).Return(map[string]model.ContentCounts{"GMS": gmsCounts}, nil)
+	// WHEN
+	res, err := sut.ProcessVolume(ctx, 4)
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, vol, res.Volume)
+	assert.Equal(t, map[string]model.ContentCounts{"GMS": gmsCounts, "MAN": {}}, res.Counts)
+}
+
+func testProcessVolumeUnknown(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(4)).Return(nil, nil)
+	// WHEN
+	res, err := sut.ProcessVolume(ctx, 4)
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessVolumeError(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	e := errors.New("test error")
+	// GIVEN
+	volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(4)).Return(&model.Volume{VolumeNumber: 4, Works: []model.Work{{Code: "GMS"}}}, nil)
+	contentRepo.EXPECT().CountByWorks(gomock.Any(), []string{"GMS"}).Return(nil, e)
+	// WHEN
+	res, err := sut.ProcessVolume(ctx, 4)
+	// THEN
+	assert.Equal(t, e, err)
+	assert.Nil(t, res)
+}
+
+func testProcessWork(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	work := model.Work{Code: "GMS", Title: "work title", Sections: []model.Section{{Heading: 1, Paragraphs: []int32{2}}}}
+	vol := model.Volume{VolumeNumber: 4, Works: []model.Work{{Code: "other"}, work}}
+	counts := model.ContentCounts{model.Heading: 1, model.Paragraph: 1}
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "GMS").Return(&vol, nil)
+	contentRepo.EXPECT().CountByWorks(gomock.Any(), []string{"GMS"}).Return(map[string]model.ContentCounts{"GMS": counts}, nil)
+	// WHEN
+	res, err := sut.ProcessWork(ctx, "GMS")
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, int32(4), res.VolumeNumber)
+	assert.Equal(t, work, res.Work)
+	assert.Equal(t, counts, res.Counts)
+}
+
+func testProcessWorkUnknown(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "GMS").Return(nil, nil)
+	// WHEN
+	res, err := sut.ProcessWork(ctx, "GMS")
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessWorkError(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	e := errors.New("test error")
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "GMS").Return(nil, e)
+	// WHEN
+	res, err := sut.ProcessWork(ctx, "GMS")
+	// THEN
+	assert.Equal(t, e, err)
+	assert.Nil(t, res)
+}
+
+func testProcessVersion(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	vol1 := model.Volume{VolumeNumber: 1, Version: "a"}
+	vol2 := model.Volume{VolumeNumber: 2, Version: "b"}
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return([]model.Volume{vol1, vol2}, nil)
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return([]model.Volume{vol1, vol2}, nil)
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return([]model.Volume{vol1, {VolumeNumber: 2, Version: "c"}}, nil)
+	// WHEN
+	res1, err1 := sut.ProcessVersion(ctx)
+	res2, err2 := sut.ProcessVersion(ctx)
+	res3, err3 := sut.ProcessVersion(ctx)
+	// THEN
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+	assert.Len(t, res1, 16)
+	assert.Equal(t, res1, res2)
+	assert.NotEqual(t, res1, res3)
+}
+
+func testProcessVersionMissing(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return([]model.Volume{{VolumeNumber: 1, Version: "a"}, {VolumeNumber: 2}}, nil)
+	// WHEN
+	res, err := sut.ProcessVersion(ctx)
+	// THEN
+	assert.Nil(t, err)
+	assert.Empty(t, res)
+}
+
+func testProcessVolumeVersion(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(4)).Return(&model.Volume{VolumeNumber: 4, Version: "a"}, nil)
+	// WHEN
+	res, err := sut.ProcessVolumeVersion(ctx, 4)
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, util.StrPtr("a"), res)
+}
+
+func testProcessWorkVersion(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "GMS").Return(&model.Volume{VolumeNumber: 4, Version: "a"}, nil)
+	// WHEN
+	res, err := sut.ProcessWorkVersion(ctx, "GMS")
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, util.StrPtr("a"), res)
+}
+
+func testProcessWorkVersionUnknown(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "GMS").Return(nil, nil)
+	// WHEN
+	res, err := sut.ProcessWorkVersion(ctx, "GMS")
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessEmbedded(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	workCode := "workCode"
+	par1 := model.Content{Type: model.Paragraph, Ordinal: 3, FnRefs: []string{"1.1"}, SummaryRef: util.StrPtr("1.2"), WorkCode: workCode}
+	par2 := model.Content{Type: model.Paragraph, Ordinal: 7, FnRefs: []string{"2.1", "1.1"}, WorkCode: workCode}
+	summ := model.Content{Type: model.Summary, Ordinal: 1, Ref: util.StrPtr("1.2"), FnRefs: []string{"1.3"}, WorkCode: workCode}
+	fn1 := model.Content{Type: model.Footnote, Ordinal: 2, Ref: util.StrPtr("1.3"), WorkCode: workCode}
+	fn2 := model.Content{Type: model.Footnote, Ordinal: 5, Ref: util.StrPtr("1.1"), WorkCode: workCode}
+	fn3 := model.Content{Type: model.Footnote, Ordinal: 8, Ref: util.StrPtr("2.1"), WorkCode: workCode}
+	// GIVEN
+	contentRepo.EXPECT().
+		GetByRefs(gomock.Any(), workCode, []model.Type{model.Footnote, model.Summary}, []string{"1.1", "1.2", "2.1"}).
+		Return([]model.Content{summ, fn2, fn3}, nil)
+	contentRepo.EXPECT().
+		GetByRefs(gomock.Any(), workCode, []model.Type{model.Footnote}, []string{"1.3"}).
+		Return([]model.Content{fn1}, nil)
+	// WHEN
+	res, err := sut.ProcessEmbedded(ctx, workCode, []model.Content{par1, par2}, Embed{Footnotes: true, Summaries: true})
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, par1, res[0].Content)
+	assert.Equal(t, &summ, res[0].Summary)
+	assert.Equal(t, []model.Content{fn1, fn2}, res[0].Footnotes)
+	assert.Equal(t, par2, res[1].Content)
+	assert.Nil(t, res[1].Summary)
+	assert.Equal(t, []model.Content{fn3, fn2}, res[1].Footnotes)
+}
+
+func testProcessEmbeddedFootnotes(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	workCode := "workCode"
+	head := model.Content{Type: model.Heading, Ordinal: 3, FnRefs: []string{"1.1"}, WorkCode: workCode}
+	par := model.Content{Type: model.Paragraph, Ordinal: 4, SummaryRef: util.StrPtr("1.2"), WorkCode: workCode}
+	fn := model.Content{Type: model.Footnote, Ordinal: 5, Ref: util.StrPtr("1.1"), WorkCode: workCode}
+	// GIVEN
+	contentRepo.EXPECT().
+		GetByRefs(gomock.Any(), workCode, []model.Type{model.Footnote}, []string{"1.1"}).
+		Return([]model.Content{fn}, nil)
+	// WHEN
+	res, err := sut.ProcessEmbedded(ctx, workCode, []model.Content{head, par}, Embed{Footnotes: true})
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, []model.Content{fn}, res[0].Footnotes)
+	assert.Nil(t, res[1].Summary)
+	assert.Empty(t, res[1].Footnotes)
+}
+
+func testProcessEmbeddedError(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	e := errors.New("test error")
+	par := model.Content{Type: model.Paragraph, Ordinal: 4, SummaryRef: util.StrPtr("1.2"), WorkCode: "workCode"}
+	// GIVEN
+	contentRepo.EXPECT().GetByRefs(gomock.Any(), "workCode", []model.Type{model.Summary}, []string{"1.2"}).Return(nil, e)
+	// WHEN
+	res, err := sut.ProcessEmbedded(ctx, "workCode", []model.Content{par}, Embed{Summaries: true})
+	// THEN
+	assert.Equal(t, e, err)
+	assert.Nil(t, res)
+}
+
+func testProcessWorkText(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	workCode := "workCode"
+	work := model.Work{
+		Code:       workCode,
+		Paragraphs: []int32{3},
+		Sections: []model.Section{{
+			Heading:    4,
+			Paragraphs: []int32{7},
+		}},
+	}
+	vol := model.Volume{VolumeNumber: 1, Works: []model.Work{{Code: "other"}, work}}
+	par := model.Content{Type: model.Paragraph, Ordinal: 3, FnRefs: []string{"1.1"}, SummaryRef: util.StrPtr("1.2"), WorkCode: workCode}
+	head := model.Content{Type: model.Heading, Ordinal: 4, WorkCode: workCode}
+	par2 := model.Content{Type: model.Paragraph, Ordinal: 7, FnRefs: []string{"2.1"}, WorkCode: workCode}
+	summ := model.Content{Type: model.Summary, Ordinal: 1, Ref: util.StrPtr("1.2"), FnRefs: []string{"1.3"}, WorkCode: workCode}
+	fn1 := model.Content{Type: model.Footnote, Ordinal: 2, Ref: util.StrPtr("1.3"), WorkCode: workCode}
+	fn2 := model.Content{Type: model.Footnote, Ordinal: 5, Ref: util.StrPtr("1.1"), WorkCode: workCode}
+	fn3 := model.Content{Type: model.Footnote, Ordinal: 8, Ref: util.StrPtr("2.1"), WorkCode: workCode}
+	from := int32(3)
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), workCode).Return(&vol, nil)
+	contentRepo.EXPECT().
+		GetByWork(gomock.Any(), workCode, []model.Type{model.Heading, model.Paragraph}, &from, gomock.Nil()).
+		Return([]model.Content{par, head, par2}, nil)
+	contentRepo.EXPECT().
+		GetByRefs(gomock.Any(), workCode, []model.Type{model.Footnote, model.Summary}, []string{"1.1", "1.2", "2.1"}).
+		Return([]model.Content{summ, fn2, fn3}, nil)
+	contentRepo.EXPECT().
+		GetByRefs(gomock.Any(), workCode, []model.Type{model.Footnote}, []string{"1.3"}).
+		Return([]model.Content{fn1}, nil)
+	// WHEN
+	res, err := sut.ProcessWorkText(ctx, workCode, &from, nil)
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, work, res.Work)
+	assert.Len(t, res.Items, 3)
+	assert.Equal(t, par, res.Items[0].Content)
+	assert.Equal(t, int32(0), res.Items[0].Level)
+	assert.Equal(t, &summ, res.Items[0].Summary)
+	assert.Equal(t, []model.Content{fn1, fn2}, res.Items[0].Footnotes)
+	assert.Equal(t, head, res.Items[1].Content)
+	assert.Equal(t, int32(1), res.Items[1].Level)
+	assert.Nil(t, res.Items[1].Summary)
+	assert.Empty(t, res.Items[1].Footnotes)
+	assert.Equal(t, par2, res.Items[2].Content)
+	assert.Equal(t, int32(1), res.Items[2].Level)
+	assert.Equal(t, []model.Content{fn3}, res.Items[2].Footnotes)
+}
+
+func testProcessWorkTextUnknownWork(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "workCode").Return(nil, nil)
+	// WHEN
+	res, err := sut.ProcessWorkText(ctx, "workCode", nil, nil)
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessWorkTextError(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	workCode := "workCode"
+	e := errors.New("test error")
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), workCode).Return(&model.Volume{VolumeNumber: 1, Works: []model.Work{{Code: workCode}}}, nil)
+	contentRepo.EXPECT().GetByWork(gomock.Any(), workCode, gomock.Any(), gomock.Nil(), gomock.Nil()).Return(nil, e)
+	// WHEN
+	res, err := sut.ProcessWorkText(ctx, workCode, nil, nil)
+	// THEN
+	assert.NotNil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessPage(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	vol := model.Volume{VolumeNumber: 4, Works: []model.Work{{Code: "first"}, {Code: "second"}}}
+	par1 := model.Content{
+		Type:         model.Paragraph,
+		Ordinal:      12,
+		FmtText:      "end of page 1 <ks-meta-page>2</ks-meta-page>page 2",
+		PageByIndex:  []model.IndexNumberPair{{I: 14, Num: 2}},
+		LineByIndex:  []model.IndexNumberPair{{I: 0, Num: 30}, {I: 44, Num: 1}},
+		WordIndexMap: map[int32]int32{0: 0, 3: 12, 4: 44, 5: 49},
+		Pages:        []int32{1, 2},
+		WorkCode:     "first",
+	}
+	par2 := model.Content{
+		Type:     model.Paragraph,
+		Ordinal:  1,
+		FmtText:  "page 2 as well",
+		Pages:    []int32{2},
+		WorkCode: "second",
+	}
+	// GIVEN
+	volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(4)).Return(&vol, nil)
+	contentRepo.EXPECT().GetByPage(gomock.Any(), []string{"first", "second"}, int32(2)).Return([]model.Content{par2, par1}, nil)
+	contentRepo.EXPECT().GetPageRange(gomock.Any(), []string{"first", "second"}).Return(&model.PageRange{First: 1, Last: 2}, nil)
+	// WHEN
+	res, err := sut.ProcessPage(ctx, 4, 2)
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, int32(4), res.VolumeNumber)
+	assert.Equal(t, int32(2), res.Page)
+	assert.Len(t, res.Contents, 2)
+	assert.Equal(t, "<ks-meta-page>2</ks-meta-page>page 2", res.Contents[0].FmtText)
+	assert.Equal(t, []model.IndexNumberPair{{I: 0, Num: 2}}, res.Contents[0].PageByIndex)
+	assert.Equal(t, []model.IndexNumberPair{{I: 30, Num: 1}}, res.Contents[0].LineByIndex)
+	assert.Equal(t, map[int32]int32{4: 30, 5: 35}, res.Contents[0].WordIndexMap)
+	assert.Equal(t, "page 2 as well", res.Contents[1].FmtText)
+	assert.NotNil(t, res.Prev)
+	assert.Equal(t, int32(1), *res.Prev)
+	assert.Nil(t, res.Next)
+}
+
+func testProcessPageUnknownVolume(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(4)).Return(nil, nil)
+	// WHEN
+	res, err := sut.ProcessPage(ctx, 4, 2)
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessPageWithoutContents(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	vol := model.Volume{VolumeNumber: 4, Works: []model.Work{{Code: "first"}}}
+	// GIVEN
+	volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(4)).Return(&vol, nil)
+	contentRepo.EXPECT().GetByPage(gomock.Any(), []string{"first"}, int32(999)).Return([]model.Content{}, nil)
+	// WHEN
+	res, err := sut.ProcessPage(ctx, 4, 999)
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessPageError(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	e := errors.New("test error")
+	// GIVEN
+	volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(4)).Return(nil, e)
+	// WHEN
+	res, err := sut.ProcessPage(ctx, 4, 2)
+	// THEN
+	assert.NotNil(t, err)
+	assert.Nil(t, res)
+}
+
+func citationVolumes() []model.Volume {
+	return []model.Volume{
+		{VolumeNumber: 4, Works: []model.Work{
+			{Code: "KrV-A", Siglum: util.StrPtr("KrV A")},
+			{Code: "Prol", Siglum: util.StrPtr("Prol")},
+			{Code: "GMS", Siglum: util.StrPtr("GMS")},
+		}},
+	}
+}
+
+func testProcessAaCitation(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	prolEnd := model.Content{Type: model.Paragraph, Ordinal: 402, Pages: []int32{385}, WorkCode: "Prol"}
+	gms1 := model.Content{
+		Type:        model.Heading,
+		Ordinal:     1,
+		Pages:       []int32{385},
+		LineByIndex: []model.IndexNumberPair{{I: 0, Num: 10}},
+		WorkCode:    "GMS",
+	}
+	gms2 := model.Content{
+		Type:        model.Paragraph,
+		Ordinal:     2,
+		Pages:       []int32{385, 386},
+		PageByIndex: []model.IndexNumberPair{{I: 200, Num: 386}},
+		LineByIndex: []model.IndexNumberPair{{I: 0, Num: 11}, {I: 100, Num: 12}, {I: 220, Num: 1}},
+		WorkCode:    "GMS",
+	}
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return(citationVolumes(), nil)
+	contentRepo.EXPECT().
+		GetByPage(gomock.Any(), []string{"KrV-A", "Prol", "GMS"}, int32(385)).
+		Return([]model.Content{gms1, gms2, prolEnd}, nil)
+	// WHEN
+	res, err := sut.ProcessCitation(ctx, "AA IV 385.12")
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, &ResolvedCitation{
+		WorkCode:   "GMS",
+		Ordinals:   []int32{1, 2},
+		LineOffset: &LineOffset{Ordinal: 2, Index: 100},
+	}, res)
+}
+
+func testProcessSiglumCitation(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	gms := model.Content{Type: model.Paragraph, Ordinal: 54, Pages: []int32{421}, WorkCode: "GMS"}
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return(citationVolumes(), nil)
+	contentRepo.EXPECT().
+		GetByPage(gomock.Any(), []string{"GMS"}, int32(421)).
+		Return([]model.Content{gms}, nil)
+	// WHEN
+	res, err := sut.ProcessCitation(ctx, "GMS 4:421")
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, &ResolvedCitation{WorkCode: "GMS", Ordinals: []int32{54}}, res)
+}
+
+func testProcessParagraphCitation(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	contents := []model.Content{
+		{Type: model.Heading, Ordinal: 60, SearchText: "§. 1. Von den Quellen", WorkCode: "Prol"},
+		{Type: model.Heading, Ordinal: 98, SearchText: "§. 13.", WorkCode: "Prol"},
+		{Type: model.Heading, Ordinal: 140, SearchText: "§. 130.", WorkCode: "Prol"},
+	}
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return(citationVolumes(), nil)
+	contentRepo.EXPECT().
+		GetByWork(gomock.Any(), "Prol", []model.Type{model.Heading, model.Paragraph}, gomock.Nil(), gomock.Nil()).
+		Return(contents, nil)
+	// WHEN
+	res, err := sut.ProcessCitation(ctx, "Prol § 13")
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, &ResolvedCitation{WorkCode: "Prol", Ordinals: []int32{98}}, res)
+}
+
+func testProcessOrigPageCitation(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	contents := []model.Content{
+		{Type: model.Paragraph, Ordinal: 12, FmtText: "text more", OrigPageByIndex: []model.IndexPagePair{{I: 5, Page: "7"}}, OrigPages: []string{"7"}, WorkCode: "KrV-A"},
+		{Type: model.Footnote, Ordinal: 13, FmtText: "note", OrigPageByIndex: []model.IndexPagePair{{I: 0, Page: "7"}}, OrigPages: []string{"7"}, WorkCode: "KrV-A"},
+	}
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return(citationVolumes(), nil)
+	contentRepo.EXPECT().GetByOrigPage(gomock.Any(), "KrV-A", "7").Return(contents, nil)
+	// WHEN
+	res, err := sut.ProcessCitation(ctx, "KrV A 7")
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, &ResolvedCitation{
+		WorkCode:   "KrV-A",
+		Ordinals:   []int32{12, 13},
+		LineOffset: &LineOffset{Ordinal: 12, Index: 5},
+	}, res)
+}
+
+func testProcessRomanOrigPageCitation(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	contents := []model.Content{
+		{Type: model.Heading, Ordinal: 2, FmtText: "Vorrede", OrigPageByIndex: []model.IndexPagePair{{I: 0, Page: "XVI"}}, OrigPages: []string{"XVI"}, WorkCode: "KrV-A"},
+	}
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return(citationVolumes(), nil)
+	contentRepo.EXPECT().GetByOrigPage(gomock.Any(), "KrV-A", "XVI").Return(contents, nil)
+	// WHEN
+	res, err := sut.ProcessCitation(ctx, "KrV A XVI")
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, &ResolvedCitation{
+		WorkCode:   "KrV-A",
+		Ordinals:   []int32{2},
+		LineOffset: &LineOffset{Ordinal: 2, Index: 0},
+	}, res)
+}
+
+func testProcessOrigPageOfWorkCitation(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return(citationVolumes(), nil).Times(2)
+	contentRepo.EXPECT().GetByOrigPage(gomock.Any(), "Prol", "13").Return([]model.Content{}, nil)
+	// WHEN the work of the edition doesn't exist, the work with the siglum is used
+	res, err := sut.ProcessCitation(ctx, "Prol A 13")
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+	// WHEN there is no work with the siglum
+	res, err = sut.ProcessCitation(ctx, "KpV A 13")
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessUnknownSiglumCitation(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return(citationVolumes(), nil)
+	// WHEN
+	res, err := sut.ProcessCitation(ctx, "GMS 5:421")
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessInvalidCitation(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	for _, citation := range []string{"somewhere", "KrV C 132"} {
+		// WHEN
+		res, err := sut.ProcessCitation(ctx, citation)
+		// THEN
+		assert.IsType(t, &CitationError{}, err)
+		assert.Nil(t, res)
+	}
+}
+
+func testProcessCitationError(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	e := errors.New("test error")
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return(nil, e)
+	// WHEN
+	res, err := sut.ProcessCitation(ctx, "GMS 4:421")
+	// THEN
+	assert.Equal(t, e, err)
+	assert.Nil(t, res)
+}
+
+// navVolumes are two volumes whose works are not stored in reading order
+var navVolumes = []model.Volume{
+	{
+		VolumeNumber: 1,
+		Works: []model.Work{
+			{
+				Code:       "W2",
+				Ordinal:    2,
+				Paragraphs: []int32{},
+				Sections: []model.Section{
+					{Heading: 1, Paragraphs: []int32{2, 3}},
+					{Heading: 4, Paragraphs: []int32{6}, Sections: []model.Section{{Heading: 7, Paragraphs: []int32{8}}}},
+				},
+			},
+			{Code: "W1", Ordinal: 1, Paragraphs: []int32{1, 2}},
+		},
+	},
+	{
+		VolumeNumber: 2,
+		Works: []model.Work{
+			{Code: "W3", Ordinal: 1, Paragraphs: []int32{}, Sections: []model.Section{{Heading: 1, Paragraphs: []int32{3}}}},
+		},
+	},
+}
+
+func testProcessNavigation(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return(navVolumes, nil)
+	// WHEN
+	res, err := sut.ProcessNavigation(ctx, "W2", 5)
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, &Location{VolumeNumber: 1, WorkCode: "W2", Ordinal: 4}, res.PrevHeading)
+	assert.Equal(t, &Location{VolumeNumber: 1, WorkCode: "W2", Ordinal: 3}, res.PrevParagraph)
+	assert.Equal(t, &Location{VolumeNumber: 1, WorkCode: "W2", Ordinal: 7}, res.NextHeading)
+	assert.Equal(t, &Location{VolumeNumber: 1, WorkCode: "W2", Ordinal: 6}, res.NextParagraph)
+}
+
+func testProcessNavigationAcrossWorks(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return(navVolumes, nil).Times(2)
+	// WHEN
+	res, err := sut.ProcessNavigation(ctx, "W2", 1)
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res.PrevHeading)
+	assert.Equal(t, &Location{VolumeNumber: 1, WorkCode: "W1", Ordinal: 2}, res.PrevParagraph)
+	assert.Equal(t, &Location{VolumeNumber: 1, WorkCode: "W2", Ordinal: 4}, res.NextHeading)
+	assert.Equal(t, &Location{VolumeNumber: 1, WorkCode: "W2", Ordinal: 2}, res.NextParagraph)
+
+	// WHEN
+	res, err = sut.ProcessNavigation(ctx, "W2", 8)
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, &Location{VolumeNumber: 1, WorkCode: "W2", Ordinal: 7}, res.PrevHeading)
+	assert.Equal(t, &Location{VolumeNumber: 1, WorkCode: "W2", Ordinal: 6}, res.PrevParagraph)
+	assert.Equal(t, &Location{VolumeNumber: 2, WorkCode: "W3", Ordinal: 1}, res.NextHeading)
+	assert.Equal(t, &Location{VolumeNumber: 2, WorkCode: "W3", Ordinal: 3}, res.NextParagraph)
+}
+
+func testProcessNavigationAtEnds(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return(navVolumes, nil).Times(2)
+	// WHEN
+	res, err := sut.ProcessNavigation(ctx, "W1", 1)
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res.PrevHeading)
+	assert.Nil(t, res.PrevParagraph)
+	assert.Equal(t, &Location{VolumeNumber: 1, WorkCode: "W2", Ordinal: 1}, res.NextHeading)
+	assert.Equal(t, &Location{VolumeNumber: 1, WorkCode: "W1", Ordinal: 2}, res.NextParagraph)
+
+	// WHEN
+	res, err = sut.ProcessNavigation(ctx, "W3", 3)
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, &Location{VolumeNumber: 2, WorkCode: "W3", Ordinal: 1}, res.PrevHeading)
+	assert.Equal(t, &Location{VolumeNumber: 1, WorkCode: "W2", Ordinal: 8}, res.PrevParagraph)
+	assert.Nil(t, res.NextHeading)
+	assert.Nil(t, res.NextParagraph)
+}
+
+func testProcessNavigationUnknownWork(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return(navVolumes, nil)
+	// WHEN
+	res, err := sut.ProcessNavigation(ctx, "W4", 1)
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessNavigationError(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	e := errors.New("test error")
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return(nil, e)
+	// WHEN
+	res, err := sut.ProcessNavigation(ctx, "W1", 1)
+	// THEN
+	assert.Equal(t, e, err)
+	assert.Nil(t, res)
+}
+
+func testProcessPersons(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	contents := []model.Content{
+		{WorkCode: "W1", Type: model.Paragraph, Ordinal: 2, Persons: []string{"Hume", "Leibniz"}},
+		{WorkCode: "W2", Type: model.Heading, Ordinal: 1, Persons: []string{"Hume"}},
+		{WorkCode: "W3", Type: model.Footnote, Ordinal: 2, Persons: []string{"Leibniz"}},
+		{WorkCode: "W4", Type: model.Paragraph, Ordinal: 1, Persons: []string{"Wolff"}},
+	}
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return(navVolumes, nil)
+	contentRepo.EXPECT().GetWithPersons(gomock.Any()).Return(contents, nil)
+	// WHEN
+	res, err := sut.ProcessPersons(ctx, nil, NoLimit)
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res.NextCursor)
+	assert.Equal(t, []Person{
+		{Name: "Hume", Occurrences: []Occurrence{
+			{Location: Location{VolumeNumber: 1, WorkCode: "W1", Ordinal: 2}, Type: model.Paragraph},
+			{Location: Location{VolumeNumber: 1, WorkCode: "W2", Ordinal: 1}, Type: model.Heading},
+		}},
+		{Name: "Leibniz", Occurrences: []Occurrence{
+			{Location: Location{VolumeNumber: 1, WorkCode: "W1", Ordinal: 2}, Type: model.Paragraph},
+			{Location: Location{VolumeNumber: 2, WorkCode: "W3", Ordinal: 2}, Type: model.Footnote},
+		}},
+	}, res.Persons)
+}
+
+func testProcessPersonsWithCursor(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	contents := []model.Content{
+		{WorkCode: "W1", Type: model.Paragraph, Ordinal: 2, Persons: []string{"Hume", "Kant", "Leibniz"}},
+		{WorkCode: "W2", Type: model.Heading, Ordinal: 1, Persons: []string{"Wolff"}},
+	}
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return(navVolumes, nil).Times(2)
+	contentRepo.EXPECT().GetWithPersons(gomock.Any()).Return(contents, nil).Times(2)
+	cursor := "Hume"
+	// WHEN
+	res, err := sut.ProcessPersons(ctx, &cursor, 2)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, res.Persons, 2)
+	assert.Equal(t, "Kant", res.Persons[0].Name)
+	assert.Equal(t, "Leibniz", res.Persons[1].Name)
+	assert.Equal(t, "Leibniz", *res.NextCursor)
+
+	// WHEN the last page is read
+	res, err = sut.ProcessPersons(ctx, res.NextCursor, 2)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, res.Persons, 1)
+	assert.Equal(t, "Wolff", res.Persons[0].Name)
+	assert.Nil(t, res.NextCursor)
+}
+
+func testProcessPersonsError(t *testing.T, sut *readProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	e := errors.New("test error")
+	// GIVEN
+	volumeRepo.EXPECT().GetAll(gomock.Any()).Return(navVolumes, nil)
+	contentRepo.EXPECT().GetWithPersons(gomock.Any()).Return(nil, e)
+	// WHEN
+	res, err := sut.ProcessPersons(ctx, nil, NoLimit)
+	// THEN
+	assert.Equal(t, e, err)
+	assert.Nil(t, res)
+}
@@ -36,4 +36,7 @@ const (
 	UnexpectedEndOfInput    ErrMsg = "UNEXPECTED_END_OF_INPUT"
 	MissingCloseParenthesis ErrMsg = "MISSING_CLOSING_PARENTHESIS"
 	UnterminatedDoubleQuote ErrMsg = "UNTERMINATED_DOUBLE_QUOTE"
+	TooManyTokens           ErrMsg = "TOO_MANY_TOKENS"
+	NestingTooDeep          ErrMsg = "NESTING_TOO_DEEP"
+	TooManyPhrases          ErrMsg = "TOO_MANY_PHRASES"
 )
@@ -13,10 +13,12 @@ type AstParser interface {
 	Parse(searchTerms string) (*dbmodel.SearchTermNode, *errors.SyntaxError)
 }
 
-type astParserImpl struct{}
+type astParserImpl struct {
+	limits model.Limits
+}
 
-func NewAstParser() AstParser {
-	impl := astParserImpl{}
+func NewAstParser(limits model.Limits) AstParser {
+	impl := astParserImpl{limits: limits}
 	return &impl
 }
 
@@ -25,7 +27,11 @@ func (rec *astParserImpl) Parse(searchTerms string) (*dbmodel.SearchTermNode, *e
 	if err != nil {
 		return nil, err
 	}
-	node, err := parse.Parse(tokens)
+	err = parse.CheckLimits(tokens, rec.limits)
+	if err != nil {
+		return nil, err
+	}
+	node, err := parse.Parse(tokens, rec.limits.MaxDepth)
 	if err != nil {
 		return nil, err
 	}
@@ -40,12 +46,14 @@ func mapNode(node *model.AstNode) *dbmodel.SearchTermNode {
 		Left:  mapNode(node.Left),
 		Right: mapNode(node.Right),
 		Token: &dbmodel.Token{
-			IsAnd:    node.Token.IsAnd,
-			IsOr:     node.Token.IsOr,
-			IsNot:    node.Token.IsNot,
-			IsWord:   node.Token.IsWord,
-			IsPhrase: node.Token.IsPhrase,
-			Text:     node.Token.Text,
+			IsAnd:          node.Token.IsAnd,
+			IsOr:           node.Token.IsOr,
+			IsNot:          node.Token.IsNot,
+			IsSameSentence: node.Token.IsSameSentence,
+			IsWord:         node.Token.IsWord,
+			IsPhrase:       node.Token.IsPhrase,
+			IsPerson:       node.Token.IsPerson,
+			Text:           node.Token.Text,
 		},
 	}
 	return &mapped
@@ -6,6 +6,8 @@ package internal
 import (
 	"testing"
 
+	"github.com/frhorschig/kant-search-backend/core/search/errors"
+	internalmodel "github.com/frhorschig/kant-search-backend/core/search/internal/model"
 	"github.com/frhorschig/kant-search-backend/dataaccess/model"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -14,7 +16,7 @@ import (
 func TestAstParser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	sut := NewAstParser()
+	sut := NewAstParser(internalmodel.Limits{})
 
 	tests := []struct {
 		name     string
@@ -82,6 +84,15 @@ func TestAstParser(t *testing.T) {
 				Right: &model.SearchTermNode{Token: newPhrase("night bird")},
 			},
 		},
+		{
+			name:  "Person filter",
+			input: "person:Leibniz & monade",
+			expected: &model.SearchTermNode{
+				Token: newAnd(),
+				Left:  &model.SearchTermNode{Token: newPerson("Leibniz")},
+				Right: &model.SearchTermNode{Token: newWord("monade")},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -93,6 +104,39 @@ func TestAstParser(t *testing.T) {
 	}
 }
 
+func TestAstParserLimits(t *testing.T) {
+	sut := NewAstParser(internalmodel.Limits{MaxTokens: 7, MaxDepth: 1})
+
+	tests := []struct {
+		name  string
+		input string
+		err   *errors.SyntaxError
+	}{
+		{
+			name:  "Within limits",
+			input: "(dog | cat) & mouse",
+			err:   nil,
+		},
+		{
+			name:  "Too many tokens",
+			input: "one two three four five",
+			err:   &errors.SyntaxError{Msg: errors.TooManyTokens, Params: []string{"7"}},
+		},
+		{
+			name:  "Nesting too deep",
+			input: "((dog))",
+			err:   &errors.SyntaxError{Msg: errors.NestingTooDeep, Params: []string{"1"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := sut.Parse(tc.input)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
 func newAnd() *model.Token {
 	return &model.Token{IsAnd: true, Text: "&"}
 }
@@ -108,3 +152,6 @@ func newWord(text string) *model.Token {
 func newPhrase(text string) *model.Token {
 	return &model.Token{IsPhrase: true, Text: text}
 }
+func newPerson(text string) *model.Token {
+	return &model.Token{IsPerson: true, Text: text}
+}
@@ -7,12 +7,21 @@ type AstNode struct {
 }
 
 type Token struct {
-	IsAnd    bool
-	IsOr     bool
-	IsNot    bool
-	IsOpen   bool
-	IsClose  bool
-	IsWord   bool
-	IsPhrase bool
-	Text     string
+	IsAnd          bool
+	IsOr           bool
+	IsNot          bool
+	IsSameSentence bool
+	IsOpen         bool
+	IsClose        bool
+	IsWord         bool
+	IsPhrase       bool
+	IsPerson       bool
+	Text           string
+}
+
+// Limits restricts the complexity of a search query; a value <= 0 disables the respective check.
+type Limits struct {
+	MaxTokens  int
+	MaxDepth   int
+	MaxPhrases int
 }
@@ -1,12 +1,15 @@
 package parse
 
 import (
+	"strconv"
+
 	"github.com/frhorschig/kant-search-backend/core/search/errors"
 	"github.com/frhorschig/kant-search-backend/core/search/internal/model"
 )
 
-func Parse(tokens []model.Token) (*model.AstNode, *errors.SyntaxError) {
-	node, err := parseExpression(&tokens)
+// Parse creates the AST from the tokens; a maxDepth <= 0 allows an arbitrary nesting of parentheses.
+func Parse(tokens []model.Token, maxDepth int) (*model.AstNode, *errors.SyntaxError) {
+	node, err := parseExpression(&tokens, 0, maxDepth)
 	if err != nil {
 		return nil, err
 	}
@@ -20,8 +23,8 @@ func Parse(tokens []model.Token) (*model.AstNode, *errors.SyntaxError) {
 	return node, nil
 }
 
-func parseExpression(tokens *[]model.Token) (*model.AstNode, *errors.SyntaxError) {
-	node, err := parseTerm(tokens)
+func parseExpression(tokens *[]model.Token, depth int, maxDepth int) (*model.AstNode, *errors.SyntaxError) {
+	node, err := parseTerm(tokens, depth, maxDepth)
 	if err != nil {
 		return nil, err
 	}
@@ -29,7 +32,7 @@ func parseExpression(tokens *[]model.Token) (*model.AstNode, *errors.SyntaxError
 	for len(*tokens) > 0 && ((*tokens)[0].IsAnd || (*tokens)[0].IsOr) {
 		opToken := &(*tokens)[0]
 		*tokens = (*tokens)[1:]
-		nextNode, err := parseTerm(tokens)
+		nextNode, err := parseTerm(tokens, depth, maxDepth)
 		if err != nil {
 			return nil, err
 		}
@@ -43,7 +46,7 @@ func parseExpression(tokens *[]model.Token) (*model.AstNode, *errors.SyntaxError
 	return node, nil
 }
 
-func parseTerm(tokens *[]model.Token) (*model.AstNode, *errors.SyntaxError) {
+func parseTerm(tokens *[]model.Token, depth int, maxDepth int) (*model.AstNode, *errors.SyntaxError) {
 	if len(*tokens) == 0 {
 		return nil, &errors.SyntaxError{Msg: errors.UnexpectedEndOfInput}
 	}
@@ -51,25 +54,35 @@ func parseTerm(tokens *[]model.Token) (*model.AstNode, *errors.SyntaxError) {
 	if (*tokens)[0].IsNot {
 		token := &(*tokens)[0]
 		*tokens = (*tokens)[1:]
-		node, err := parseTerm(tokens)
+		node, err := parseTerm(tokens, depth, maxDepth)
 		if err != nil {
 			return nil, err
 		}
 		return &model.AstNode{Left: node, Token: token}, nil
 	}
 
-	return parseFactor(tokens)
+	return parseFactor(tokens, depth, maxDepth)
 }
 
-func parseFactor(tokens *[]model.Token) (*model.AstNode, *errors.SyntaxError) {
+func parseFactor(tokens *[]model.Token, depth int, maxDepth int) (*model.AstNode, *errors.SyntaxError) {
 	token := &(*tokens)[0]
 	switch {
 	case token.IsWord || token.IsPhrase:
+		*tokens = (*tokens)[1:]
+		return parseSameSentence(tokens, &model.AstNode{Token: token})
+	case token.IsPerson:
+		// persons aren't matched by their positions in the text, so they can't be operands of a same sentence operator
 		*tokens = (*tokens)[1:]
 		return &model.AstNode{Token: token}, nil
 	case token.IsOpen:
+		if maxDepth > 0 && depth >= maxDepth {
+			return nil, &errors.SyntaxError{
+				Msg:    errors.NestingTooDeep,
+				Params: []string{strconv.Itoa(maxDepth)},
+			}
+		}
 		*tokens = (*tokens)[1:]
-		node, err := parseExpression(tokens)
+		node, err := parseExpression(tokens, depth+1, maxDepth)
 		if err != nil {
 			return nil, err
 		}
@@ -85,3 +98,28 @@ func parseFactor(tokens *[]model.Token) (*model.AstNode, *errors.SyntaxError) {
 		}
 	}
 }
+
+// parseSameSentence parses a (possibly empty) chain of same sentence operators following a word or phrase; the operands of a same sentence operator can only be words or phrases, because they are matched by their positions in the text.
+func parseSameSentence(tokens *[]model.Token, node *model.AstNode) (*model.AstNode, *errors.SyntaxError) {
+	for len(*tokens) > 0 && (*tokens)[0].IsSameSentence {
+		opToken := &(*tokens)[0]
+		*tokens = (*tokens)[1:]
+		if len(*tokens) == 0 {
+			return nil, &errors.SyntaxError{Msg: errors.UnexpectedEndOfInput}
+		}
+		operand := &(*tokens)[0]
+		if !operand.IsWord && !operand.IsPhrase {
+			return nil, &errors.SyntaxError{
+				Msg:    errors.UnexpectedToken,
+				Params: []string{operand.Text},
+			}
+		}
+		*tokens = (*tokens)[1:]
+		node = &model.AstNode{
+			Left:  node,
+			Right: &model.AstNode{Token: operand},
+			Token: opToken,
+		}
+	}
+	return node, nil
+}
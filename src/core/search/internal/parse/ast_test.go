@@ -172,12 +172,134 @@ func TestCheckSyntax(t *testing.T) {
 			},
 			err: &errors.SyntaxError{Msg: errors.UnexpectedEndOfInput},
 		},
+		{
+			name: "same sentence with words and phrases",
+			input: []model.Token{
+				{Text: "hello", IsWord: true},
+				{Text: "/s", IsSameSentence: true},
+				{Text: "\"dear world\"", IsPhrase: true},
+				{Text: "/s", IsSameSentence: true},
+				{Text: "friend", IsWord: true},
+				{Text: "|", IsOr: true},
+				{Text: "kant", IsWord: true},
+			},
+			err: nil,
+		},
+		{
+			name: "same sentence with group operand",
+			input: []model.Token{
+				{Text: "hello", IsWord: true},
+				{Text: "/s", IsSameSentence: true},
+				{Text: "(", IsOpen: true},
+				{Text: "world", IsWord: true},
+				{Text: ")", IsClose: true},
+			},
+			err: &errors.SyntaxError{Msg: errors.UnexpectedToken, Params: []string{"("}},
+		},
+		{
+			name: "same sentence without right operand",
+			input: []model.Token{
+				{Text: "hello", IsWord: true},
+				{Text: "/s", IsSameSentence: true},
+			},
+			err: &errors.SyntaxError{Msg: errors.UnexpectedEndOfInput},
+		},
+		{
+			name: "person OR word",
+			input: []model.Token{
+				{Text: "Leibniz", IsPerson: true},
+				{Text: "|", IsOr: true},
+				{Text: "monade", IsWord: true},
+			},
+			err: nil,
+		},
+		{
+			name: "same sentence with person operand",
+			input: []model.Token{
+				{Text: "monade", IsWord: true},
+				{Text: "/s", IsSameSentence: true},
+				{Text: "Leibniz", IsPerson: true},
+			},
+			err: &errors.SyntaxError{Msg: errors.UnexpectedToken, Params: []string{"Leibniz"}},
+		},
+		{
+			name: "starts with same sentence",
+			input: []model.Token{
+				{Text: "/s", IsSameSentence: true},
+				{Text: "hello", IsWord: true},
+			},
+			err: &errors.SyntaxError{Msg: errors.UnexpectedToken, Params: []string{"/s"}},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, err := Parse(tc.input)
+			_, err := Parse(tc.input, 0)
 			assert.Equal(t, tc.err, err)
 		})
 	}
 }
+
+func TestCheckNestingDepth(t *testing.T) {
+	input := []model.Token{
+		{Text: "(", IsOpen: true},
+		{Text: "(", IsOpen: true},
+		{Text: "hello", IsWord: true},
+		{Text: ")", IsClose: true},
+		{Text: ")", IsClose: true},
+	}
+	testCases := []struct {
+		name     string
+		maxDepth int
+		err      *errors.SyntaxError
+	}{
+		{
+			name:     "unlimited depth",
+			maxDepth: 0,
+			err:      nil,
+		},
+		{
+			name:     "depth equal to limit",
+			maxDepth: 2,
+			err:      nil,
+		},
+		{
+			name:     "depth greater than limit",
+			maxDepth: 1,
+			err:      &errors.SyntaxError{Msg: errors.NestingTooDeep, Params: []string{"1"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(input, tc.maxDepth)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func TestParseSameSentence(t *testing.T) {
+	// GIVEN
+	input := []model.Token{
+		{Text: "a", IsWord: true},
+		{Text: "/s", IsSameSentence: true},
+		{Text: "b", IsWord: true},
+		{Text: "/s", IsSameSentence: true},
+		{Text: "c", IsWord: true},
+		{Text: "&", IsAnd: true},
+		{Text: "d", IsWord: true},
+	}
+
+	// WHEN
+	node, err := Parse(input, 0)
+
+	// THEN
+	assert.Nil(t, err)
+	assert.True(t, node.Token.IsAnd)
+	assert.Equal(t, "d", node.Right.Token.Text)
+	chain := node.Left
+	assert.True(t, chain.Token.IsSameSentence)
+	assert.Equal(t, "c", chain.Right.Token.Text)
+	assert.True(t, chain.Left.Token.IsSameSentence)
+	assert.Equal(t, "a", chain.Left.Left.Token.Text)
+	assert.Equal(t, "b", chain.Left.Right.Token.Text)
+}
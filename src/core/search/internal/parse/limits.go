@@ -0,0 +1,32 @@
+package parse
+
+import (
+	"strconv"
+
+	"github.com/frhorschig/kant-search-backend/core/search/errors"
+	"github.com/frhorschig/kant-search-backend/core/search/internal/model"
+)
+
+// CheckLimits checks the number of tokens and phrases; the nesting depth is checked while parsing the tokens.
+func CheckLimits(tokens []model.Token, limits model.Limits) *errors.SyntaxError {
+	if limits.MaxTokens > 0 && len(tokens) > limits.MaxTokens {
+		return &errors.SyntaxError{
+			Msg:    errors.TooManyTokens,
+			Params: []string{strconv.Itoa(limits.MaxTokens)},
+		}
+	}
+
+	phrases := 0
+	for _, t := range tokens {
+		if t.IsPhrase {
+			phrases += 1
+		}
+	}
+	if limits.MaxPhrases > 0 && phrases > limits.MaxPhrases {
+		return &errors.SyntaxError{
+			Msg:    errors.TooManyPhrases,
+			Params: []string{strconv.Itoa(limits.MaxPhrases)},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+//go:build unit
+// +build unit
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/frhorschig/kant-search-backend/core/search/errors"
+	"github.com/frhorschig/kant-search-backend/core/search/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckLimits(t *testing.T) {
+	tokens := []model.Token{
+		newWord("hello"), newAnd(), newPhrase("big world"), newAnd(), newWord("kant"), newOr(), newPhrase("reine vernunft"),
+	}
+	testCases := []struct {
+		name   string
+		limits model.Limits
+		err    *errors.SyntaxError
+	}{
+		{
+			name:   "no limits",
+			limits: model.Limits{},
+			err:    nil,
+		},
+		{
+			name:   "all limits satisfied",
+			limits: model.Limits{MaxTokens: 7, MaxDepth: 1, MaxPhrases: 2},
+			err:    nil,
+		},
+		{
+			name:   "too many tokens",
+			limits: model.Limits{MaxTokens: 6},
+			err:    &errors.SyntaxError{Msg: errors.TooManyTokens, Params: []string{"6"}},
+		},
+		{
+			name:   "too many phrases",
+			limits: model.Limits{MaxPhrases: 1},
+			err:    &errors.SyntaxError{Msg: errors.TooManyPhrases, Params: []string{"1"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckLimits(tokens, tc.limits)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
@@ -7,6 +7,11 @@ import (
 	"github.com/frhorschig/kant-search-backend/core/search/internal/model"
 )
 
+const (
+	sameSentenceOp = "/s"
+	personPrefix   = "person:"
+)
+
 func Tokenize(input string) ([]model.Token, *errors.SyntaxError) {
 	input = strings.TrimSpace(input)
 	if wrongBeginChar(input[0]) {
@@ -56,6 +61,13 @@ func createTokens(input string) ([]model.Token, *errors.SyntaxError) {
 		case strings.HasPrefix(input, ")"):
 			tokens = append(tokens, newClose())
 			input = input[1:]
+		case strings.HasPrefix(input, personPrefix):
+			token, newInput, err := findPerson(input)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, *token)
+			input = newInput
 		case strings.HasPrefix(input, "\""):
 			token, newInput, err := findPhrase(input)
 			if err != nil {
@@ -91,22 +103,52 @@ func findPhrase(input string) (*model.Token, string, *errors.SyntaxError) {
 	return &token, input, nil
 }
 
+// findPerson finds the name after the person prefix, which is either a single word or a phrase in double quotes
+func findPerson(input string) (*model.Token, string, *errors.SyntaxError) {
+	input = input[len(personPrefix):]
+	var name *model.Token
+	if strings.HasPrefix(input, "\"") {
+		var err *errors.SyntaxError
+		name, input, err = findPhrase(input)
+		if err != nil {
+			return nil, "", err
+		}
+	} else {
+		name, input = findWord(input)
+	}
+	if name.Text == "" {
+		return nil, "", &errors.SyntaxError{
+			Msg:    errors.UnexpectedToken,
+			Params: []string{personPrefix},
+		}
+	}
+	token := newPerson(name.Text)
+	return &token, input, nil
+}
+
 func findWord(input string) (*model.Token, string) {
 	var token model.Token
 	end := nextNonWordCharIndex(input)
 	if end == -1 {
-		token = newWord(input)
+		token = newWordOrOperator(input)
 		input = ""
 	} else {
 		word := strings.TrimSpace(input[0:end])
 		if len(word) > 0 {
-			token = newWord(word)
+			token = newWordOrOperator(word)
 		}
 		input = input[end:]
 	}
 	return &token, input
 }
 
+func newWordOrOperator(word string) model.Token {
+	if word == sameSentenceOp {
+		return newSameSentence()
+	}
+	return newWord(word)
+}
+
 func nextNonWordCharIndex(s string) int {
 	for i, r := range s {
 		if strings.ContainsRune(`&|!()" `, r) {
@@ -132,9 +174,9 @@ func addInBetweenAnds(tokens []model.Token) []model.Token {
 }
 
 func isLhs(t model.Token) bool {
-	return t.IsWord || t.IsPhrase || t.IsClose
+	return t.IsWord || t.IsPhrase || t.IsPerson || t.IsClose
 }
 
 func isRhs(t model.Token) bool {
-	return t.IsWord || t.IsPhrase || t.IsNot || t.IsOpen
+	return t.IsWord || t.IsPhrase || t.IsPerson || t.IsNot || t.IsOpen
 }
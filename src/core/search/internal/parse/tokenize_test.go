@@ -90,6 +90,36 @@ func TestTokenize(t *testing.T) {
 			expected: []model.Token{newWord("hello"), newAnd(), newPhrase("world")},
 			err:      nil,
 		},
+		{
+			name:     "same sentence success",
+			input:    "hello /s \"dear world\" /s friend",
+			expected: []model.Token{newWord("hello"), newSameSentence(), newPhrase("dear world"), newSameSentence(), newWord("friend")},
+			err:      nil,
+		},
+		{
+			name:     "same sentence as part of a word success",
+			input:    "hello/s world",
+			expected: []model.Token{newWord("hello/s"), newAnd(), newWord("world")},
+			err:      nil,
+		},
+		{
+			name:     "person success",
+			input:    "person:Leibniz !monade",
+			expected: []model.Token{newPerson("Leibniz"), newAnd(), newNot(), newWord("monade")},
+			err:      nil,
+		},
+		{
+			name:     "person with phrase success",
+			input:    "raum person:\"Christian Wolff\"",
+			expected: []model.Token{newWord("raum"), newAnd(), newPerson("Christian Wolff")},
+			err:      nil,
+		},
+		{
+			name:     "person without name error",
+			input:    "person: Leibniz",
+			expected: nil,
+			err:      &errors.SyntaxError{Msg: errors.UnexpectedToken, Params: []string{"person:"}},
+		},
 		{
 			name:     "starts with AND error",
 			input:    "& hello",
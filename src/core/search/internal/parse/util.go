@@ -13,6 +13,9 @@ func newOr() model.Token {
 func newNot() model.Token {
 	return model.Token{IsNot: true, Text: "!"}
 }
+func newSameSentence() model.Token {
+	return model.Token{IsSameSentence: true, Text: sameSentenceOp}
+}
 func newOpen() model.Token {
 	return model.Token{IsOpen: true, Text: "("}
 }
@@ -25,3 +28,6 @@ func newWord(text string) model.Token {
 func newPhrase(text string) model.Token {
 	return model.Token{IsPhrase: true, Text: text}
 }
+func newPerson(text string) model.Token {
+	return model.Token{IsPerson: true, Text: text}
+}
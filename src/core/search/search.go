@@ -7,22 +7,37 @@ import (
 
 	"github.com/frhorschig/kant-search-backend/core/search/errors"
 	"github.com/frhorschig/kant-search-backend/core/search/internal"
+	internalmodel "github.com/frhorschig/kant-search-backend/core/search/internal/model"
 	"github.com/frhorschig/kant-search-backend/dataaccess"
 	"github.com/frhorschig/kant-search-backend/dataaccess/model"
 )
 
 type SearchProcessor interface {
 	Search(ctx context.Context, searchString string, options model.SearchOptions) ([]model.SearchResult, errors.SearchError)
+	SearchBatch(ctx context.Context, searchStrings []string, options model.SearchOptions, countOnly bool) ([]BatchResult, error)
 }
 
+// BatchResult is the result of a single search string of a batch search; if SyntaxError is not nil, the search string was not executed.
+type BatchResult struct {
+	SearchString string
+	SyntaxError  *errors.SyntaxError
+	Error        error // not nil if the execution of the search string failed
+	Count        int32
+	Results      []model.SearchResult
+	Truncated    bool // true if Results contains fewer hits than Count, because all results of a batch share the size of a single search
+}
+
+// QueryLimits restricts the complexity of search strings; a value <= 0 disables the respective limit.
+type QueryLimits = internalmodel.Limits
+
 type searchProcessorImpl struct {
 	astParser   internal.AstParser
 	contentRepo dataaccess.ContentRepo
 }
 
-func NewSearchProcessor(contentRepo dataaccess.ContentRepo) SearchProcessor {
+func NewSearchProcessor(contentRepo dataaccess.ContentRepo, limits QueryLimits) SearchProcessor {
 	impl := searchProcessorImpl{
-		astParser:   internal.NewAstParser(),
+		astParser:   internal.NewAstParser(limits),
 		contentRepo: contentRepo,
 	}
 	return &impl
@@ -39,3 +54,34 @@ func (rec *searchProcessorImpl) Search(ctx context.Context, searchTerms string,
 	}
 	return results, errors.Nil()
 }
+
+func (rec *searchProcessorImpl) SearchBatch(ctx context.Context, searchStrings []string, options model.SearchOptions, countOnly bool) ([]BatchResult, error) {
+	results := make([]BatchResult, len(searchStrings))
+	asts := []*model.SearchTermNode{}
+	astIndices := []int{}
+	for i, searchString := range searchStrings {
+		results[i] = BatchResult{SearchString: searchString, Results: []model.SearchResult{}}
+		ast, syntaxErr := rec.astParser.Parse(searchString)
+		if syntaxErr != nil {
+			results[i].SyntaxError = syntaxErr
+			continue
+		}
+		asts = append(asts, ast)
+		astIndices = append(astIndices, i)
+	}
+	if len(asts) == 0 {
+		return results, nil
+	}
+
+	batchResults, err := rec.contentRepo.SearchBatch(ctx, asts, options, countOnly)
+	if err != nil {
+		return nil, err
+	}
+	for i, r := range batchResults {
+		results[astIndices[i]].Error = r.Error
+		results[astIndices[i]].Count = r.Count
+		results[astIndices[i]].Results = r.Results
+		results[astIndices[i]].Truncated = r.Truncated
+	}
+	return results, nil
+}
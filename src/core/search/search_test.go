@@ -1,20 +1,29 @@
 package search
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
+	"github.com/frhorschig/kant-search-backend/core/search/errors"
 	dbMocks "github.com/frhorschig/kant-search-backend/dataaccess/mocks"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestSearchProcessor(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	contentRepo := dbMocks.NewMockContentRepo(ctrl)
-	sut := NewSearchProcessor(contentRepo).(*searchProcessorImpl)
+	sut := NewSearchProcessor(contentRepo, QueryLimits{}).(*searchProcessorImpl)
 
 	for scenario, fn := range map[string]func(t *testing.T, sut *searchProcessorImpl, searchProcessor *dbMocks.MockContentRepo){
-		"Search syntax error": testSearchSyntaxError,
+		"Search syntax error":              testSearchSyntaxError,
+		"Search batch":                     testSearchBatch,
+		"Search batch only syntax errors":  testSearchBatchOnlySyntaxErrors,
+		"Search batch with database error": testSearchBatchDatabaseError,
+		"Search batch with failed query":   testSearchBatchFailedQuery,
 	} {
 		t.Run(scenario, func(t *testing.T) {
 			fn(t, sut, contentRepo)
@@ -40,3 +49,68 @@ func testSearchSyntaxError(t *testing.T, sut *searchProcessorImpl, contentRepo *
 	// assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
 	// assertErrorResponse(t, res, string(models.BAD_REQUEST_VALIDATION_WRONG_STARTING_CHAR))
 }
+
+func testSearchBatch(t *testing.T, sut *searchProcessorImpl, contentRepo *dbMocks.MockContentRepo) {
+	result := model.SearchResult{FmtText: "fmtText", WorkCode: "workCode"}
+	// GIVEN
+	contentRepo.EXPECT().
+		SearchBatch(gomock.Any(), gomock.Len(2), gomock.Any(), false).
+		Return([]model.BatchSearchResult{
+			{Count: 1, Results: []model.SearchResult{result}},
+			{Count: 3, Results: []model.SearchResult{result}, Truncated: true},
+		}, nil)
+	// WHEN
+	res, err := sut.SearchBatch(context.Background(), []string{"kant", "& wrong", "vernunft"}, model.SearchOptions{}, false)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, res, 3)
+	assert.Equal(t, "kant", res[0].SearchString)
+	assert.Nil(t, res[0].SyntaxError)
+	assert.Equal(t, int32(1), res[0].Count)
+	assert.Equal(t, []model.SearchResult{result}, res[0].Results)
+	assert.False(t, res[0].Truncated)
+	assert.Equal(t, &errors.SyntaxError{Msg: errors.WrongStartingChar, Params: []string{"&"}}, res[1].SyntaxError)
+	assert.Nil(t, res[2].SyntaxError)
+	assert.Equal(t, int32(3), res[2].Count)
+	assert.True(t, res[2].Truncated)
+}
+
+func testSearchBatchOnlySyntaxErrors(t *testing.T, sut *searchProcessorImpl, contentRepo *dbMocks.MockContentRepo) {
+	// WHEN
+	res, err := sut.SearchBatch(context.Background(), []string{"& wrong", "wrong |"}, model.SearchOptions{}, true)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, res, 2)
+	assert.NotNil(t, res[0].SyntaxError)
+	assert.NotNil(t, res[1].SyntaxError)
+}
+
+func testSearchBatchFailedQuery(t *testing.T, sut *searchProcessorImpl, contentRepo *dbMocks.MockContentRepo) {
+	// GIVEN
+	contentRepo.EXPECT().
+		SearchBatch(gomock.Any(), gomock.Len(2), gomock.Any(), true).
+		Return([]model.BatchSearchResult{
+			{Results: []model.SearchResult{}, Error: fmt.Errorf("too many clauses")},
+			{Count: 2, Results: []model.SearchResult{}},
+		}, nil)
+	// WHEN
+	res, err := sut.SearchBatch(context.Background(), []string{"kant", "vernunft"}, model.SearchOptions{}, true)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, res, 2)
+	assert.EqualError(t, res[0].Error, "too many clauses")
+	assert.Nil(t, res[1].Error)
+	assert.Equal(t, int32(2), res[1].Count)
+}
+
+func testSearchBatchDatabaseError(t *testing.T, sut *searchProcessorImpl, contentRepo *dbMocks.MockContentRepo) {
+	// GIVEN
+	contentRepo.EXPECT().
+		SearchBatch(gomock.Any(), gomock.Len(1), gomock.Any(), true).
+		Return(nil, fmt.Errorf("database error"))
+	// WHEN
+	res, err := sut.SearchBatch(context.Background(), []string{"kant"}, model.SearchOptions{}, true)
+	// THEN
+	assert.NotNil(t, err)
+	assert.Nil(t, res)
+}
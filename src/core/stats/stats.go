@@ -0,0 +1,159 @@
+package stats
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/stats_mock.go -package=mocks
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/frhorschig/kant-search-backend/dataaccess"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+)
+
+// WordStats are the word statistics of a work or volume; the frequencies are sorted by descending count and then alphabetically
+type WordStats struct {
+	TotalWords    int32
+	DistinctWords int32
+	Frequencies   []WordFrequency
+}
+
+type WordFrequency struct {
+	Word  string
+	Count int32
+}
+
+// Options of the word statistics; filtered stop words are not counted at all, i.e. they are neither part of the total and distinct word counts nor of the frequencies
+type Options struct {
+	Lemmatize       bool // count the stems of the words (the terms of the germanStemming analyzer) instead of the words
+	FilterStopWords bool
+}
+
+// StatsProcessor computes word statistics from the search texts of all contents of a work or volume; the methods return nil if the work or volume doesn't exist
+type StatsProcessor interface {
+	ProcessWorkStats(ctx context.Context, workCode string, options Options) (*WordStats, error)
+	ProcessVolumeStats(ctx context.Context, volumeNumber int32, options Options) (*WordStats, error)
+}
+
+// the word counts of a work are cached until the volume of the work is uploaded again, i.e. until the version of the volume changes
+type cacheKey struct {
+	workCode string
+	options  Options
+}
+
+type cacheEntry struct {
+	version string
+	counts  map[string]int32
+}
+
+type statsProcessorImpl struct {
+	volumeRepo  dataaccess.VolumeRepo
+	contentRepo dataaccess.ContentRepo
+	mutex       sync.Mutex
+	cache       map[cacheKey]cacheEntry
+}
+
+func NewStatsProcessor(volumeRepo dataaccess.VolumeRepo, contentRepo dataaccess.ContentRepo) StatsProcessor {
+	processor := statsProcessorImpl{
+		volumeRepo:  volumeRepo,
+		contentRepo: contentRepo,
+		cache:       make(map[cacheKey]cacheEntry),
+	}
+	return &processor
+}
+
+func (rec *statsProcessorImpl) ProcessWorkStats(ctx context.Context, workCode string, options Options) (*WordStats, error) {
+	volume, err := rec.volumeRepo.GetByWorkCode(ctx, workCode)
+	if err != nil {
+		return nil, err
+	}
+	if volume == nil {
+		return nil, nil
+	}
+	counts, err := rec.countWords(ctx, volume.Version, workCode, options)
+	if err != nil {
+		return nil, err
+	}
+	return createStats(counts), nil
+}
+
+func (rec *statsProcessorImpl) ProcessVolumeStats(ctx context.Context, volumeNumber int32, options Options) (*WordStats, error) {
+	volume, err := rec.volumeRepo.GetByVolumeNumber(ctx, volumeNumber)
+	if err != nil {
+		return nil, err
+	}
+	if volume == nil {
+		return nil, nil
+	}
+	counts := make(map[string]int32)
+	for _, w := range volume.Works {
+		workCounts, err := rec.countWords(ctx, volume.Version, w.Code, options)
+		if err != nil {
+			return nil, err
+		}
+		for word, count := range workCounts {
+			counts[word] += count
+		}
+	}
+	return createStats(counts), nil
+}
+
+// countWords returns the number of occurrences of each word of the work; the returned map must not be modified, because it may be cached. Volumes without a version (uploaded by older versions of the application) are not cached.
+func (rec *statsProcessorImpl) countWords(ctx context.Context, version string, workCode string, options Options) (map[string]int32, error) {
+	key := cacheKey{workCode: workCode, options: options}
+	rec.mutex.Lock()
+	entry, ok := rec.cache[key]
+	rec.mutex.Unlock()
+	if ok && version != "" && entry.version == version {
+		return entry.counts, nil
+	}
+
+	// the unstemmed words are always needed to find the stop words
+	wordAnalyzer, keyAnalyzer := model.NoStemming, model.NoStemming
+	if options.Lemmatize {
+		keyAnalyzer = model.GermanStemming
+	}
+	texts, err := rec.contentRepo.GetTermsByWork(ctx, workCode, slices.Compact([]model.Analyzer{wordAnalyzer, keyAnalyzer}))
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int32)
+	for _, terms := range texts {
+		keys := terms[keyAnalyzer]
+		for i, word := range terms[wordAnalyzer] {
+			if word == "" || i >= len(keys) || keys[i] == "" {
+				continue
+			}
+			if options.FilterStopWords && isStopWord(word) {
+				continue
+			}
+			counts[keys[i]]++
+		}
+	}
+
+	if version != "" {
+		rec.mutex.Lock()
+		rec.cache[key] = cacheEntry{version: version, counts: counts}
+		rec.mutex.Unlock()
+	}
+	return counts, nil
+}
+
+func createStats(counts map[string]int32) *WordStats {
+	stats := WordStats{
+		DistinctWords: int32(len(counts)),
+		Frequencies:   []WordFrequency{},
+	}
+	for word, count := range counts {
+		stats.TotalWords += count
+		stats.Frequencies = append(stats.Frequencies, WordFrequency{Word: word, Count: count})
+	}
+	slices.SortFunc(stats.Frequencies, func(a, b WordFrequency) int {
+		if a.Count != b.Count {
+			return int(b.Count - a.Count)
+		}
+		return strings.Compare(a.Word, b.Word)
+	})
+	return &stats
+}
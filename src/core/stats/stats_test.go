@@ -0,0 +1,222 @@
+//go:build unit
+// +build unit
+
+package stats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frhorschig/kant-search-backend/dataaccess/mocks"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsProcessor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	for scenario, fn := range map[string]func(*testing.T, *statsProcessorImpl, *mocks.MockVolumeRepo, *mocks.MockContentRepo, context.Context){
+		"Process work stats":                       testProcessWorkStats,
+		"Process work stats lemmatized":            testProcessWorkStatsLemmatized,
+		"Process work stats without stop words":    testProcessWorkStatsWithoutStopWords,
+		"Process work stats from cache":            testProcessWorkStatsFromCache,
+		"Process work stats after upload":          testProcessWorkStatsAfterUpload,
+		"Process work stats without version":       testProcessWorkStatsWithoutVersion,
+		"Process work stats with unknown work":     testProcessWorkStatsUnknownWork,
+		"Process work stats with error":            testProcessWorkStatsError,
+		"Process volume stats":                     testProcessVolumeStats,
+		"Process volume stats with unknown volume": testProcessVolumeStatsUnknownVolume,
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			// each scenario needs an empty cache
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			volumeRepo := mocks.NewMockVolumeRepo(ctrl)
+			contentRepo := mocks.NewMockContentRepo(ctrl)
+			sut := NewStatsProcessor(volumeRepo, contentRepo).(*statsProcessorImpl)
+			fn(t, sut, volumeRepo, contentRepo, ctx)
+		})
+	}
+}
+
+func testProcessWorkStats(t *testing.T, sut *statsProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "GMS").Return(createVolume("v1", "GMS"), nil)
+	contentRepo.EXPECT().GetTermsByWork(gomock.Any(), "GMS", []model.Analyzer{model.NoStemming}).Return([]model.TextTerms{
+		{model.NoStemming: {"die", "vernunft", "und", "die", "natur"}},
+		{model.NoStemming: {"vernunft", "", "die"}},
+	}, nil)
+	// WHEN
+	res, err := sut.ProcessWorkStats(ctx, "GMS", Options{})
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, &WordStats{
+		TotalWords:    7,
+		DistinctWords: 4,
+		Frequencies: []WordFrequency{
+			{Word: "die", Count: 3},
+			{Word: "vernunft", Count: 2},
+			{Word: "natur", Count: 1},
+			{Word: "und", Count: 1},
+		},
+	}, res)
+}
+
+func testProcessWorkStatsLemmatized(t *testing.T, sut *statsProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "GMS").Return(createVolume("v1", "GMS"), nil)
+	contentRepo.EXPECT().GetTermsByWork(gomock.Any(), "GMS", []model.Analyzer{model.NoStemming, model.GermanStemming}).Return([]model.TextTerms{
+		{
+			model.NoStemming:     {"gesetze", "und", "gesetz"},
+			model.GermanStemming: {"gesetz", "und", "gesetz"},
+		},
+	}, nil)
+	// WHEN
+	res, err := sut.ProcessWorkStats(ctx, "GMS", Options{Lemmatize: true, FilterStopWords: true})
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, &WordStats{
+		TotalWords:    2,
+		DistinctWords: 1,
+		Frequencies:   []WordFrequency{{Word: "gesetz", Count: 2}},
+	}, res)
+}
+
+func testProcessWorkStatsWithoutStopWords(t *testing.T, sut *statsProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "GMS").Return(createVolume("v1", "GMS"), nil)
+	contentRepo.EXPECT().GetTermsByWork(gomock.Any(), "GMS", []model.Analyzer{model.NoStemming}).Return([]model.TextTerms{
+		{model.NoStemming: {"daß", "die", "vernunft", "seyn", "müsse"}},
+	}, nil)
+	// WHEN
+	res, err := sut.ProcessWorkStats(ctx, "GMS", Options{FilterStopWords: true})
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, &WordStats{
+		TotalWords:    2,
+		DistinctWords: 2,
+		Frequencies: []WordFrequency{
+			{Word: "müsse", Count: 1},
+			{Word: "vernunft", Count: 1},
+		},
+	}, res)
+}
+
+func testProcessWorkStatsFromCache(t *testing.T, sut *statsProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "GMS").Return(createVolume("v1", "GMS"), nil).Times(2)
+	contentRepo.EXPECT().GetTermsByWork(gomock.Any(), "GMS", gomock.Any()).Return([]model.TextTerms{
+		{model.NoStemming: {"vernunft"}},
+	}, nil).Times(1)
+	// WHEN
+	first, err1 := sut.ProcessWorkStats(ctx, "GMS", Options{})
+	second, err2 := sut.ProcessWorkStats(ctx, "GMS", Options{})
+	// THEN
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Equal(t, first, second)
+}
+
+func testProcessWorkStatsAfterUpload(t *testing.T, sut *statsProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	gomock.InOrder(
+		volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "GMS").Return(createVolume("v1", "GMS"), nil),
+		volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "GMS").Return(createVolume("v2", "GMS"), nil),
+	)
+	gomock.InOrder(
+		contentRepo.EXPECT().GetTermsByWork(gomock.Any(), "GMS", gomock.Any()).Return([]model.TextTerms{
+			{model.NoStemming: {"vernunft"}},
+		}, nil),
+		contentRepo.EXPECT().GetTermsByWork(gomock.Any(), "GMS", gomock.Any()).Return([]model.TextTerms{
+			{model.NoStemming: {"natur"}},
+		}, nil),
+	)
+	// WHEN
+	sut.ProcessWorkStats(ctx, "GMS", Options{})
+	res, err := sut.ProcessWorkStats(ctx, "GMS", Options{})
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, []WordFrequency{{Word: "natur", Count: 1}}, res.Frequencies)
+}
+
+func testProcessWorkStatsWithoutVersion(t *testing.T, sut *statsProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "GMS").Return(createVolume("", "GMS"), nil).Times(2)
+	contentRepo.EXPECT().GetTermsByWork(gomock.Any(), "GMS", gomock.Any()).Return([]model.TextTerms{
+		{model.NoStemming: {"vernunft"}},
+	}, nil).Times(2)
+	// WHEN
+	sut.ProcessWorkStats(ctx, "GMS", Options{})
+	res, err := sut.ProcessWorkStats(ctx, "GMS", Options{})
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), res.TotalWords)
+}
+
+func testProcessWorkStatsUnknownWork(t *testing.T, sut *statsProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "GMS").Return(nil, nil)
+	// WHEN
+	res, err := sut.ProcessWorkStats(ctx, "GMS", Options{})
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func testProcessWorkStatsError(t *testing.T, sut *statsProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	e := errors.New("test error")
+	// GIVEN
+	volumeRepo.EXPECT().GetByWorkCode(gomock.Any(), "GMS").Return(createVolume("v1", "GMS"), nil)
+	contentRepo.EXPECT().GetTermsByWork(gomock.Any(), "GMS", gomock.Any()).Return(nil, e)
+	// WHEN
+	res, err := sut.ProcessWorkStats(ctx, "GMS", Options{})
+	// THEN
+	assert.Equal(t, e, err)
+	assert.Nil(t, res)
+}
+
+func testProcessVolumeStats(t *testing.T, sut *statsProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(4)).Return(createVolume("v1", "GMS", "KpV"), nil)
+	contentRepo.EXPECT().GetTermsByWork(gomock.Any(), "GMS", gomock.Any()).Return([]model.TextTerms{
+		{model.NoStemming: {"vernunft", "natur"}},
+	}, nil)
+	contentRepo.EXPECT().GetTermsByWork(gomock.Any(), "KpV", gomock.Any()).Return([]model.TextTerms{
+		{model.NoStemming: {"vernunft", "freiheit"}},
+	}, nil)
+	// WHEN
+	res, err := sut.ProcessVolumeStats(ctx, 4, Options{})
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, &WordStats{
+		TotalWords:    4,
+		DistinctWords: 3,
+		Frequencies: []WordFrequency{
+			{Word: "vernunft", Count: 2},
+			{Word: "freiheit", Count: 1},
+			{Word: "natur", Count: 1},
+		},
+	}, res)
+}
+
+func testProcessVolumeStatsUnknownVolume(t *testing.T, sut *statsProcessorImpl, volumeRepo *mocks.MockVolumeRepo, contentRepo *mocks.MockContentRepo, ctx context.Context) {
+	// GIVEN
+	volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(4)).Return(nil, nil)
+	// WHEN
+	res, err := sut.ProcessVolumeStats(ctx, 4, Options{})
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func createVolume(version string, workCodes ...string) *model.Volume {
+	volume := model.Volume{VolumeNumber: 4, Title: "Band 4", Version: version}
+	for _, code := range workCodes {
+		volume.Works = append(volume.Works, model.Work{Code: code})
+	}
+	return &volume
+}
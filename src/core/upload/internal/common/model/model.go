@@ -20,6 +20,20 @@ type Section struct {
 	Heading    Heading
 	Paragraphs []Paragraph
 	Sections   []Section
+	Letter     *Letter     // only for the sections of letters
+	Reflection *Reflection // only for the sections of Reflexionen
+}
+
+type Letter struct {
+	Number    string
+	Sender    string
+	Recipient string
+	Date      string
+}
+
+type Reflection struct {
+	Number string
+	Dating string
 }
 
 type Heading struct {
@@ -32,6 +32,8 @@ func assertSections(t *testing.T, exp []model.Section, act []model.Section) {
 		assert.Equal(t, exp[i].Heading.Text, act[i].Heading.Text)
 		assert.Equal(t, exp[i].Heading.TocText, act[i].Heading.TocText)
 		assert.Equal(t, exp[i].Heading.Pages, act[i].Heading.Pages)
+		assert.Equal(t, exp[i].Letter, act[i].Letter)
+		assert.Equal(t, exp[i].Reflection, act[i].Reflection)
 		assert.Equal(t, len(exp[i].Paragraphs), len(act[i].Paragraphs))
 		assertParagraphs(t, exp[i].Paragraphs, act[i].Paragraphs)
 		assert.Equal(t, len(exp[i].Sections), len(act[i].Sections))
@@ -114,9 +116,22 @@ func AssertDbContents(t *testing.T, exp []dbmodel.Content, act []dbmodel.Content
 			assert.Equal(t, *exp[i].TocText, *act[i].TocText)
 		}
 		assert.Equal(t, exp[i].SearchText, act[i].SearchText)
+		if exp[i].Sentences != nil {
+			assert.Equal(t, exp[i].Sentences, act[i].Sentences)
+		}
+		if exp[i].OrigPageByIndex != nil {
+			assert.Equal(t, exp[i].OrigPageByIndex, act[i].OrigPageByIndex)
+		}
+		if exp[i].OrigPages != nil {
+			assert.Equal(t, exp[i].OrigPages, act[i].OrigPages)
+		}
 		assert.Equal(t, exp[i].Type, act[i].Type)
+		assert.Equal(t, exp[i].Kind, act[i].Kind)
 		assert.Equal(t, exp[i].Ordinal, act[i].Ordinal)
 		assert.Equal(t, exp[i].WorkCode, act[i].WorkCode)
+		assert.Equal(t, exp[i].Persons, act[i].Persons)
+		assert.Equal(t, exp[i].Languages, act[i].Languages)
+		assert.Equal(t, exp[i].ForeignTexts, act[i].ForeignTexts)
 		assert.Equal(t, len(exp[i].Pages), len(act[i].Pages))
 		for j := range exp[i].Pages {
 			assert.Equal(t, exp[i].Pages[j], act[i].Pages[j])
@@ -12,14 +12,20 @@ import (
 )
 
 const (
-	fnRefFmt    = `<ks-meta-fnref>%d.%d</ks-meta-fnref>`
-	FnRefMatch  = `<ks-meta-fnref>(\d+\.\d+)</ks-meta-fnref>`
-	imgRefFmt   = `<ks-meta-imgref src="%s" desc="%s"/>`
-	ImgRefMatch = `<ks-meta-imgref src=".+" desc=".+"/>`
-	lineFmt     = `<ks-meta-line>%d</ks-meta-line>`
-	LineMatch   = `<ks-meta-line>(\d+)</ks-meta-line>`
-	pageFmt     = `<ks-meta-page>%d</ks-meta-page>`
-	PageMatch   = `<ks-meta-page>(\d+)</ks-meta-page>`
+	fnRefFmt      = `<ks-meta-fnref>%d.%d</ks-meta-fnref>`
+	FnRefMatch    = `<ks-meta-fnref>(\d+\.\d+)</ks-meta-fnref>`
+	imgRefFmt     = `<ks-meta-imgref src="%s" desc="%s"/>`
+	ImgRefMatch   = `<ks-meta-imgref src=".+" desc=".+"/>`
+	ImgSrcMatch   = `<ks-meta-imgref src="([^"]*)"`
+	lineFmt       = `<ks-meta-line>%d</ks-meta-line>`
+	LineMatch     = `<ks-meta-line>(\d+)</ks-meta-line>`
+	pageFmt       = `<ks-meta-page>%d</ks-meta-page>`
+	PageMatch     = `<ks-meta-page>(\d+)</ks-meta-page>`
+	origPageFmt   = `<ks-meta-origpage>%s</ks-meta-origpage>`
+	OrigPageMatch = `<ks-meta-origpage>([^<]*)</ks-meta-origpage>`
+	NameMatch     = `<ks-fmt-name>(.*?)</ks-fmt-name>`
+	LangMatch     = `<ks-fmt-lang([^>]*)>(.*?)</ks-fmt-lang>`
+	LangAttrMatch = `\blang="([^"]*)"`
 )
 
 func FmtFnRef(page int32, nr int32) string {
@@ -38,6 +44,11 @@ func FmtPage(page int32) string {
 	return fmt.Sprintf(pageFmt, page)
 }
 
+// FmtOrigPage formats the start of a page of the original edition of a work, e.g. "132" of "KrV B 132"; the page is a string, because the prefaces have roman page numbers. The marker is removed from the stored text when the contents are flattened.
+func FmtOrigPage(page string) string {
+	return fmt.Sprintf(origPageFmt, page)
+}
+
 const (
 	boldFmtStart    = "<ks-fmt-bold>"
 	boldFmtEnd      = "</ks-fmt-bold>"
@@ -55,7 +66,10 @@ const (
 	headMatchStart  = `<ks-fmt-h\d>`
 	headMatchEnd    = `</ks-fmt-h\d>`
 	headMatch       = headMatchStart + "%s" + headMatchEnd
-	langFmt         = "%s"
+	langFmtStart    = `<ks-fmt-lang%s>`
+	langMatchStart  = `<ks-fmt-lang[^>]*>`
+	langFmtEnd      = "</ks-fmt-lang>"
+	langFmt         = langFmtStart + "%s" + langFmtEnd
 	nameFmtStart    = "<ks-fmt-name>"
 	nameFmtEnd      = "</ks-fmt-name>"
 	nameFmt         = nameFmtStart + "%s" + nameFmtEnd
@@ -90,8 +104,9 @@ func FmtHeading(level int32, content string) string {
 	return fmt.Sprintf(headingFmt, level, content, level)
 }
 
-func FmtLang(lang string) string {
-	return lang
+// FmtLang formats a foreign language text; attrs are the (space prefixed) attributes of the tag, e.g. ` lang="lat"`
+func FmtLang(attrs string, content string) string {
+	return fmt.Sprintf(langFmt, attrs, content)
 }
 
 func FmtName(content string) string {
@@ -115,8 +130,10 @@ func RemoveTags(input string) string {
 	input = regexp.MustCompile(ImgRefMatch).ReplaceAllString(input, "")
 	input = regexp.MustCompile(LineMatch).ReplaceAllString(input, "")
 	input = regexp.MustCompile(PageMatch).ReplaceAllString(input, "")
+	input = regexp.MustCompile(OrigPageMatch).ReplaceAllString(input, "")
 	input = regexp.MustCompile(headMatchStart).ReplaceAllString(input, "")
 	input = regexp.MustCompile(headMatchEnd).ReplaceAllString(input, "")
+	input = regexp.MustCompile(langMatchStart).ReplaceAllString(input, "")
 
 	input = strings.ReplaceAll(input, boldFmtStart, "")
 	input = strings.ReplaceAll(input, boldFmtEnd, "")
@@ -126,6 +143,7 @@ func RemoveTags(input string) string {
 	input = strings.ReplaceAll(input, emph2FmtEnd, "")
 	input = strings.ReplaceAll(input, formulaFmtStart, "")
 	input = strings.ReplaceAll(input, formulaFmtEnd, "")
+	input = strings.ReplaceAll(input, langFmtEnd, "")
 	input = strings.ReplaceAll(input, nameFmtStart, "")
 	input = strings.ReplaceAll(input, nameFmtEnd, "")
 	input = strings.ReplaceAll(input, parHeadFmtStart, "")
@@ -149,8 +167,10 @@ func MaskTags(input string) string {
 	input = regexp.MustCompile(ImgRefMatch).ReplaceAllStringFunc(input, mask)
 	input = regexp.MustCompile(LineMatch).ReplaceAllStringFunc(input, mask)
 	input = regexp.MustCompile(PageMatch).ReplaceAllStringFunc(input, mask)
+	input = regexp.MustCompile(OrigPageMatch).ReplaceAllStringFunc(input, mask)
 	input = regexp.MustCompile(headMatchStart).ReplaceAllStringFunc(input, mask)
 	input = regexp.MustCompile(headMatchEnd).ReplaceAllStringFunc(input, mask)
+	input = regexp.MustCompile(langMatchStart).ReplaceAllStringFunc(input, mask)
 
 	input = strings.ReplaceAll(input, boldFmtStart, mask(boldFmtStart))
 	input = strings.ReplaceAll(input, boldFmtEnd, mask(boldFmtEnd))
@@ -160,8 +180,9 @@ func MaskTags(input string) string {
 	input = strings.ReplaceAll(input, emph2FmtEnd, mask(emph2FmtEnd))
 	input = strings.ReplaceAll(input, formulaFmtStart, mask(formulaFmtStart))
 	input = strings.ReplaceAll(input, formulaFmtEnd, mask(formulaFmtEnd))
-	input = strings.ReplaceAll(input, nameFmtStart, mask(trackedFmtStart))
-	input = strings.ReplaceAll(input, nameFmtEnd, mask(trackedFmtEnd))
+	input = strings.ReplaceAll(input, langFmtEnd, mask(langFmtEnd))
+	input = strings.ReplaceAll(input, nameFmtStart, mask(nameFmtStart))
+	input = strings.ReplaceAll(input, nameFmtEnd, mask(nameFmtEnd))
 	input = strings.ReplaceAll(input, parHeadFmtStart, mask(parHeadFmtStart))
 	input = strings.ReplaceAll(input, parHeadFmtEnd, mask(parHeadFmtEnd))
 	input = strings.ReplaceAll(input, trackedFmtStart, mask(trackedFmtStart))
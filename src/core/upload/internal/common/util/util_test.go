@@ -146,6 +146,11 @@ func TestRemoveTags(t *testing.T) {
 			text:     "This is a text with " + fnRef(1, 2) + " and " + fnRef(3, 4) + ".",
 			expected: "This is a text with and .",
 		},
+		{
+			name:     "Text with language tags",
+			text:     "Text " + FmtLang(` lang="lat" alphabet="latin"`, "ratio") + ".",
+			expected: "Text ratio.",
+		},
 		{
 			name:     "Text with line matches",
 			text:     "This is a text with " + line(12) + " tags.",
@@ -222,6 +227,16 @@ func TestMaskTags(t *testing.T) {
 			text:     "Mixed " + fnRef(1, 2) + " and <b>HTML</b> tags " + page(3) + ".",
 			expected: "Mixed ********************************** and ***HTML**** tags ******************************.",
 		},
+		{
+			name:     "Text with language tags",
+			text:     "Text " + FmtLang(` lang="lat"`, "ratio") + ".",
+			expected: "Text ************************ratio**************.",
+		},
+		{
+			name:     "Text with name tags",
+			text:     "Text of " + FmtName("Leibniz") + ".",
+			expected: "Text of *************Leibniz**************.",
+		},
 		{
 			name:     "Text with no tags",
 			text:     "Plain text without any tags.",
@@ -1,6 +1,9 @@
 package flattening
 
 import (
+	"regexp"
+	"unicode/utf8"
+
 	"github.com/frhorschig/kant-search-backend/core/upload/internal/common/model"
 	"github.com/frhorschig/kant-search-backend/core/upload/internal/common/util"
 	dbmodel "github.com/frhorschig/kant-search-backend/dataaccess/model"
@@ -39,11 +42,35 @@ func mapSectionOrdinals(sections []model.Section) []dbmodel.Section {
 			Heading:    s.Heading.Ordinal,
 			Paragraphs: mapParagraphOrdinals(s.Paragraphs),
 			Sections:   mapSectionOrdinals(s.Sections),
+			Letter:     mapLetter(s.Letter),
+			Reflection: mapReflection(s.Reflection),
 		}
 	}
 	return results
 }
 
+func mapLetter(letter *model.Letter) *dbmodel.Letter {
+	if letter == nil {
+		return nil
+	}
+	return &dbmodel.Letter{
+		Number:    letter.Number,
+		Sender:    letter.Sender,
+		Recipient: letter.Recipient,
+		Date:      letter.Date,
+	}
+}
+
+func mapReflection(reflection *model.Reflection) *dbmodel.Reflection {
+	if reflection == nil {
+		return nil
+	}
+	return &dbmodel.Reflection{
+		Number: reflection.Number,
+		Dating: reflection.Dating,
+	}
+}
+
 func mapParagraphOrdinals(paragraphs []model.Paragraph) []int32 {
 	results := make([]int32, len(paragraphs))
 	for i, p := range paragraphs {
@@ -55,72 +82,113 @@ func mapParagraphOrdinals(paragraphs []model.Paragraph) []int32 {
 func mapContents(works []model.Work) []dbmodel.Content {
 	contents := []dbmodel.Content{}
 	for _, w := range works {
-		addParagraphs(w.Paragraphs, &contents, w.Code)
-		addSections(w.Sections, &contents, w.Code)
+		addParagraphs(w.Paragraphs, &contents, w.Code, nil)
+		addSections(w.Sections, &contents, w.Code, nil)
 		addFootnotes(w.Footnotes, &contents, w.Code)
 		addSummaries(w.Summaries, &contents, w.Code)
 	}
 	return contents
 }
 
-func addParagraphs(paragraphs []model.Paragraph, contents *[]dbmodel.Content, workCode string) {
+func addParagraphs(paragraphs []model.Paragraph, contents *[]dbmodel.Content, workCode string, kind *dbmodel.Kind) {
 	for _, p := range paragraphs {
+		text, origPageByIndex := extractOrigPages(p.Text)
 		*contents = append(*contents, dbmodel.Content{
-			Type:       dbmodel.Paragraph,
-			Ordinal:    p.Ordinal,
-			FmtText:    p.Text,
-			SearchText: util.RemoveTags(p.Text),
-			Pages:      p.Pages,
-			FnRefs:     p.FnRefs,
-			SummaryRef: p.SummaryRef,
-			WorkCode:   workCode,
+			Type:            dbmodel.Paragraph,
+			Kind:            kind,
+			Ordinal:         p.Ordinal,
+			FmtText:         text,
+			SearchText:      util.RemoveTags(text),
+			OrigPageByIndex: origPageByIndex,
+			Pages:           p.Pages,
+			FnRefs:          p.FnRefs,
+			SummaryRef:      p.SummaryRef,
+			WorkCode:        workCode,
 		})
 	}
 }
 
-func addSections(sections []model.Section, contents *[]dbmodel.Content, workCode string) {
+// addSections adds the headings and paragraphs of the sections; the kind of a letter or Reflexion section is inherited by its subsections
+func addSections(sections []model.Section, contents *[]dbmodel.Content, workCode string, kind *dbmodel.Kind) {
 	for _, s := range sections {
+		secKind := kind
+		if s.Letter != nil {
+			k := dbmodel.LetterKind
+			secKind = &k
+		} else if s.Reflection != nil {
+			k := dbmodel.ReflectionKind
+			secKind = &k
+		}
 		h := s.Heading
+		text, origPageByIndex := extractOrigPages(h.Text)
 		*contents = append(*contents, dbmodel.Content{
-			Type:       dbmodel.Heading,
-			Ordinal:    h.Ordinal,
-			FmtText:    h.Text,
-			TocText:    &h.TocText,
-			SearchText: util.RemoveTags(h.Text),
-			Pages:      h.Pages,
-			FnRefs:     h.FnRefs,
-			WorkCode:   workCode,
+			Type:            dbmodel.Heading,
+			Kind:            secKind,
+			Ordinal:         h.Ordinal,
+			FmtText:         text,
+			TocText:         &h.TocText,
+			SearchText:      util.RemoveTags(text),
+			OrigPageByIndex: origPageByIndex,
+			Pages:           h.Pages,
+			FnRefs:          h.FnRefs,
+			WorkCode:        workCode,
 		})
-		addParagraphs(s.Paragraphs, contents, workCode)
-		addSections(s.Sections, contents, workCode)
+		addParagraphs(s.Paragraphs, contents, workCode, secKind)
+		addSections(s.Sections, contents, workCode, secKind)
 	}
 }
 
 func addFootnotes(footnotes []model.Footnote, contents *[]dbmodel.Content, workCode string) {
 	for _, f := range footnotes {
+		text, origPageByIndex := extractOrigPages(f.Text)
 		*contents = append(*contents, dbmodel.Content{
-			Type:       dbmodel.Footnote,
-			Ordinal:    f.Ordinal,
-			Ref:        &f.Ref,
-			FmtText:    f.Text,
-			SearchText: util.RemoveTags(f.Text),
-			Pages:      f.Pages,
-			WorkCode:   workCode,
+			Type:            dbmodel.Footnote,
+			Ordinal:         f.Ordinal,
+			Ref:             &f.Ref,
+			FmtText:         text,
+			SearchText:      util.RemoveTags(text),
+			OrigPageByIndex: origPageByIndex,
+			Pages:           f.Pages,
+			WorkCode:        workCode,
 		})
 	}
 }
 
 func addSummaries(summaries []model.Summary, contents *[]dbmodel.Content, workCode string) {
 	for _, s := range summaries {
+		text, origPageByIndex := extractOrigPages(s.Text)
 		*contents = append(*contents, dbmodel.Content{
-			Type:       dbmodel.Summary,
-			Ordinal:    s.Ordinal,
-			Ref:        &s.Ref,
-			FmtText:    s.Text,
-			SearchText: util.RemoveTags(s.Text),
-			Pages:      s.Pages,
-			FnRefs:     s.FnRefs,
-			WorkCode:   workCode,
+			Type:            dbmodel.Summary,
+			Ordinal:         s.Ordinal,
+			Ref:             &s.Ref,
+			FmtText:         text,
+			SearchText:      util.RemoveTags(text),
+			OrigPageByIndex: origPageByIndex,
+			Pages:           s.Pages,
+			FnRefs:          s.FnRefs,
+			WorkCode:        workCode,
 		})
 	}
 }
+
+var origPageRegex = regexp.MustCompile(util.OrigPageMatch)
+
+// extractOrigPages removes the markers of the original pages from a text and returns the text and the indices of the pages in it; the space after (or, at the end of a sentence, before) a marker is removed with it, so that the text is the same as without the op elements
+func extractOrigPages(text string) (string, []dbmodel.IndexPagePair) {
+	result := []dbmodel.IndexPagePair{}
+	for {
+		match := origPageRegex.FindStringSubmatchIndex(text)
+		if match == nil {
+			return text, result
+		}
+		page := text[match[2]:match[3]]
+		start, end := match[0], match[1]
+		if end < len(text) && text[end] == ' ' {
+			end++
+		} else if start > 0 && text[start-1] == ' ' {
+			start--
+		}
+		text = text[:start] + text[end:]
+		result = append(result, dbmodel.IndexPagePair{I: int32(utf8.RuneCountInString(text[:start])), Page: page})
+	}
+}
@@ -245,6 +245,78 @@ func TestFlattening(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "kinds of letters and Reflexionen",
+			volume: model.Volume{VolumeNumber: 10, Title: "vol title"},
+			works: []model.Work{
+				{
+					Sections: []model.Section{
+						{
+							Heading:    model.Heading{Ordinal: 1},
+							Paragraphs: []model.Paragraph{{Ordinal: 2}},
+							Sections: []model.Section{{
+								Heading:    model.Heading{Ordinal: 3},
+								Paragraphs: []model.Paragraph{{Ordinal: 4}},
+								Sections:   []model.Section{},
+							}},
+							Letter: &model.Letter{Number: "12", Sender: "Kant", Recipient: "Lambert", Date: "1765-12-31"},
+						},
+						{
+							Heading:    model.Heading{Ordinal: 5},
+							Paragraphs: []model.Paragraph{},
+							Sections:   []model.Section{},
+							Reflection: &model.Reflection{Number: "4275", Dating: "1770-71"},
+						},
+						{
+							Heading:    model.Heading{Ordinal: 6},
+							Paragraphs: []model.Paragraph{},
+							Sections:   []model.Section{},
+						},
+					},
+				},
+			},
+			expVolume: dbmodel.Volume{
+				VolumeNumber: 10,
+				Title:        "vol title",
+				Works: []dbmodel.Work{
+					{
+						Ordinal:    1,
+						Paragraphs: []int32{},
+						Sections: []dbmodel.Section{
+							{
+								Heading:    1,
+								Paragraphs: []int32{2},
+								Sections: []dbmodel.Section{{
+									Heading:    3,
+									Paragraphs: []int32{4},
+									Sections:   []dbmodel.Section{},
+								}},
+								Letter: &dbmodel.Letter{Number: "12", Sender: "Kant", Recipient: "Lambert", Date: "1765-12-31"},
+							},
+							{
+								Heading:    5,
+								Paragraphs: []int32{},
+								Sections:   []dbmodel.Section{},
+								Reflection: &dbmodel.Reflection{Number: "4275", Dating: "1770-71"},
+							},
+							{
+								Heading:    6,
+								Paragraphs: []int32{},
+								Sections:   []dbmodel.Section{},
+							},
+						},
+					},
+				},
+			},
+			expContent: []dbmodel.Content{
+				{Type: dbmodel.Heading, Kind: kindPtr(dbmodel.LetterKind), Ordinal: 1},
+				{Type: dbmodel.Paragraph, Kind: kindPtr(dbmodel.LetterKind), Ordinal: 2},
+				{Type: dbmodel.Heading, Kind: kindPtr(dbmodel.LetterKind), Ordinal: 3},
+				{Type: dbmodel.Paragraph, Kind: kindPtr(dbmodel.LetterKind), Ordinal: 4},
+				{Type: dbmodel.Heading, Kind: kindPtr(dbmodel.ReflectionKind), Ordinal: 5},
+				{Type: dbmodel.Heading, Ordinal: 6},
+			},
+		},
 		{
 			name:   "remove tags from search text",
 			volume: model.Volume{VolumeNumber: 2, Title: "vol title"},
@@ -274,6 +346,36 @@ func TestFlattening(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "extract original pages",
+			volume: model.Volume{VolumeNumber: 2, Title: "vol title"},
+			works: []model.Work{
+				{
+					Paragraphs: []model.Paragraph{{Ordinal: 1, Text: "<ks-meta-origpage>XVI</ks-meta-origpage> Über <ks-meta-line>2</ks-meta-line>Vernunft <ks-meta-origpage>1</ks-meta-origpage> hat. Ende <ks-meta-origpage>2</ks-meta-origpage>."}},
+					Sections:   []model.Section{},
+				},
+			},
+			expVolume: dbmodel.Volume{
+				VolumeNumber: 2,
+				Title:        "vol title",
+				Works: []dbmodel.Work{
+					{
+						Ordinal:    1,
+						Paragraphs: []int32{1},
+						Sections:   []dbmodel.Section{},
+					},
+				},
+			},
+			expContent: []dbmodel.Content{
+				{
+					Type:            dbmodel.Paragraph,
+					Ordinal:         1,
+					FmtText:         "Über <ks-meta-line>2</ks-meta-line>Vernunft hat. Ende.",
+					SearchText:      "Über Vernunft hat. Ende.",
+					OrigPageByIndex: []dbmodel.IndexPagePair{{I: 0, Page: "XVI"}, {I: 44, Page: "1"}, {I: 53, Page: "2"}},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -284,3 +386,7 @@ func TestFlattening(t *testing.T) {
 		})
 	}
 }
+
+func kindPtr(kind dbmodel.Kind) *dbmodel.Kind {
+	return &kind
+}
@@ -3,6 +3,7 @@ package metadataextraction
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"unicode"
@@ -21,6 +22,7 @@ func ExtractMetadata(contents []model.Content) errs.UploadError {
 			return errs.New(nil, fmt.Errorf("unable to create word index map: %v", err.Error()))
 		}
 		c.WordIndexMap = wordIndexMap
+		c.Sentences = findSentences(c.SearchText)
 		pageByIndex, err := findNumberByIndex(c.FmtText, util.PageMatch)
 		if err != nil {
 			return errs.New(nil, fmt.Errorf("unable to create index->page map: %v", err.Error()))
@@ -31,10 +33,64 @@ func ExtractMetadata(contents []model.Content) errs.UploadError {
 			return errs.New(nil, fmt.Errorf("unable to create index->line map: %v", err.Error()))
 		}
 		c.LineByIndex = lineByIndex
+		c.OrigPages = findOrigPages(c.OrigPageByIndex)
+		c.ForeignTexts, c.Languages = findForeignTexts(c.FmtText)
 	}
 	return errs.Nil()
 }
 
+// ExtractPersons finds the names of persons in the formatted texts; the names are normalized with the aliases, which map the spellings of a name to the normalized name
+func ExtractPersons(contents []model.Content, aliases map[string]string) {
+	re := regexp.MustCompile(util.NameMatch)
+	for i := range contents {
+		c := &contents[i]
+		persons := []string{}
+		for _, match := range re.FindAllStringSubmatch(c.FmtText, -1) {
+			name := util.RemoveTags(match[1])
+			if alias, ok := aliases[name]; ok {
+				name = alias
+			}
+			if name != "" {
+				persons = append(persons, name)
+			}
+		}
+		if len(persons) > 0 {
+			slices.Sort(persons)
+			c.Persons = slices.Compact(persons)
+		}
+	}
+}
+
+// findOrigPages returns the pages of the original edition that start in the text
+func findOrigPages(origPageByIndex []model.IndexPagePair) []string {
+	pages := []string{}
+	for _, p := range origPageByIndex {
+		pages = append(pages, p.Page)
+	}
+	return pages
+}
+
+// findForeignTexts returns the foreign language passages of the text and the sorted languages of these passages; passages without a language are ignored
+func findForeignTexts(fmtText string) ([]model.ForeignText, []string) {
+	var texts []model.ForeignText
+	var langs []string
+	langRe := regexp.MustCompile(util.LangAttrMatch)
+	for _, match := range regexp.MustCompile(util.LangMatch).FindAllStringSubmatch(fmtText, -1) {
+		langMatch := langRe.FindStringSubmatch(match[1])
+		if langMatch == nil {
+			continue
+		}
+		lang := strings.ToLower(strings.TrimSpace(langMatch[1]))
+		if lang == "" {
+			continue
+		}
+		texts = append(texts, model.ForeignText{Lang: lang, Text: util.RemoveTags(match[2])})
+		langs = append(langs, lang)
+	}
+	slices.Sort(langs)
+	return texts, slices.Compact(langs)
+}
+
 func findWordIndexMap(rawText string, fmtText string) (map[int32]int32, error) {
 	rawWords := extractWordData(rawText)
 	fmtWords := extractWordData(util.MaskTags(fmtText)) // we mask the tags here so that no word from a tag in fmtText may be accidentally matched to a normal word in rawText; this could happen if there are tags with attributes (like image or table tags)
@@ -107,3 +163,65 @@ func findNumberByIndex(text string, matcher string) ([]model.IndexNumberPair, er
 	}
 	return result, nil
 }
+
+const (
+	sentenceEndChars     = ".!?"
+	closingChars         = `"')]“”»«‘’`
+	sentenceOpeningChars = `"'(„“»«‚‘`
+)
+
+// lower case abbreviations that end with a period but usually don't end a sentence; single letters and numbers (e.g. "z. B.", "18. Jahrhundert") are handled separately
+var abbreviations = []string{"anm", "bzw", "ca", "hr", "hrn", "nr", "usw", "vgl"}
+
+// findSentences splits a text into sentences. A sentence ends with a sentence end char (optionally followed by closing quotes or brackets), if it is followed by whitespace and a char that can start a sentence, i.e. an upper case letter or an opening quote. This is a heuristic and may fail for unknown abbreviations followed by a noun.
+func findSentences(text string) []string {
+	sentences := []string{}
+	runes := []rune(text)
+	start := 0
+	for i, r := range runes {
+		if !strings.ContainsRune(sentenceEndChars, r) || (r == '.' && isAbbreviation(runes[start:i])) {
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && strings.ContainsRune(closingChars, runes[end]) {
+			end++
+		}
+		next := end
+		for next < len(runes) && unicode.IsSpace(runes[next]) {
+			next++
+		}
+		if next == end || next == len(runes) || !startsSentence(runes[next]) {
+			continue
+		}
+		sentences = appendSentence(sentences, runes[start:end])
+		start = next
+	}
+	return appendSentence(sentences, runes[start:])
+}
+
+func isAbbreviation(precedingText []rune) bool {
+	wordStart := len(precedingText)
+	for wordStart > 0 && (unicode.IsLetter(precedingText[wordStart-1]) || unicode.IsDigit(precedingText[wordStart-1])) {
+		wordStart--
+	}
+	word := precedingText[wordStart:]
+	if len(word) == 0 {
+		return false
+	}
+	if len(word) == 1 || unicode.IsDigit(word[0]) {
+		return true
+	}
+	return slices.Contains(abbreviations, strings.ToLower(string(word)))
+}
+
+func startsSentence(r rune) bool {
+	return unicode.IsUpper(r) || strings.ContainsRune(sentenceOpeningChars, r)
+}
+
+func appendSentence(sentences []string, sentence []rune) []string {
+	s := strings.TrimSpace(string(sentence))
+	if s == "" {
+		return sentences
+	}
+	return append(sentences, s)
+}
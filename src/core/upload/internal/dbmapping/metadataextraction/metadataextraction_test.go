@@ -19,6 +19,12 @@ func TestMetadataExtraction(t *testing.T) {
 	expContent := []dbmodel.Content{{
 		FmtText:    fmtText,
 		SearchText: searchText,
+		Sentences: []string{
+			"Immanuel Kant was an 18th-century German philosopher who shaped modern thought.",
+			"In his Critique of Pure Reason, he argued that knowledge arises from both experience and the mind’s structures.",
+			"His idea of the categorical imperative emphasized moral duty over outcomes.",
+			"Kant remains a foundational figure in ethics and epistemology.",
+		},
 		PageByIndex: []dbmodel.IndexNumberPair{
 			{I: 333, Num: 18},
 			{I: 497, Num: 19},
@@ -88,3 +94,158 @@ func TestMetadataExtraction(t *testing.T) {
 	assert.False(t, err.HasError)
 	testutil.AssertDbContents(t, expContent, content)
 }
+
+func TestExtractPersons(t *testing.T) {
+	aliases := map[string]string{"Leibnitz": "Leibniz"}
+	testCases := []struct {
+		name     string
+		fmtText  string
+		expected []string
+	}{
+		{
+			name:     "no names",
+			fmtText:  "Ich denke.",
+			expected: nil,
+		},
+		{
+			name:     "single name",
+			fmtText:  "Wie <ks-fmt-name>Hume</ks-fmt-name> sagt.",
+			expected: []string{"Hume"},
+		},
+		{
+			name:     "name with alias and nested formatting",
+			fmtText:  "Nach <ks-fmt-name><ks-fmt-emph>Leibnitz</ks-fmt-emph></ks-fmt-name> und <ks-fmt-name>Wolff</ks-fmt-name>.",
+			expected: []string{"Leibniz", "Wolff"},
+		},
+		{
+			name:     "duplicate names",
+			fmtText:  "<ks-fmt-name>Leibnitz</ks-fmt-name>, <ks-fmt-name>Leibniz</ks-fmt-name> und <ks-fmt-name>Hume</ks-fmt-name>.",
+			expected: []string{"Hume", "Leibniz"},
+		},
+		{
+			name:     "empty name",
+			fmtText:  "Ein <ks-fmt-name><ks-meta-line>2</ks-meta-line></ks-fmt-name> Name.",
+			expected: nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			contents := []dbmodel.Content{{FmtText: tc.fmtText}}
+			ExtractPersons(contents, aliases)
+			assert.Equal(t, tc.expected, contents[0].Persons)
+		})
+	}
+}
+
+func TestFindForeignTexts(t *testing.T) {
+	testCases := []struct {
+		name          string
+		fmtText       string
+		expectedTexts []dbmodel.ForeignText
+		expectedLangs []string
+	}{
+		{
+			name:    "no foreign texts",
+			fmtText: "Ich denke.",
+		},
+		{
+			name:          "multiple languages",
+			fmtText:       `Die <ks-fmt-lang lang="lat">ratio <ks-fmt-emph>pura</ks-fmt-emph></ks-fmt-lang> und <ks-fmt-lang lang="Gr" alphabet="griechisch">λόγος</ks-fmt-lang> und <ks-fmt-lang lang="lat">intellectus</ks-fmt-lang>.`,
+			expectedTexts: []dbmodel.ForeignText{{Lang: "lat", Text: "ratio pura"}, {Lang: "gr", Text: "λόγος"}, {Lang: "lat", Text: "intellectus"}},
+			expectedLangs: []string{"gr", "lat"},
+		},
+		{
+			name:    "foreign text without language",
+			fmtText: `Ein <ks-fmt-lang alphabet="griechisch">λόγος</ks-fmt-lang>.`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			texts, langs := findForeignTexts(tc.fmtText)
+			assert.Equal(t, tc.expectedTexts, texts)
+			assert.Equal(t, tc.expectedLangs, langs)
+		})
+	}
+}
+
+func TestFindOrigPages(t *testing.T) {
+	testCases := []struct {
+		name            string
+		origPageByIndex []dbmodel.IndexPagePair
+		expected        []string
+	}{
+		{
+			name:     "no original pages",
+			expected: []string{},
+		},
+		{
+			name:            "multiple original pages",
+			origPageByIndex: []dbmodel.IndexPagePair{{I: 0, Page: "XVI"}, {I: 12, Page: "1"}},
+			expected:        []string{"XVI", "1"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, findOrigPages(tc.origPageByIndex))
+		})
+	}
+}
+
+func TestFindSentences(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "single sentence",
+			text:     "Ich denke.",
+			expected: []string{"Ich denke."},
+		},
+		{
+			name:     "empty text",
+			text:     "",
+			expected: []string{},
+		},
+		{
+			name:     "different sentence endings",
+			text:     "Was kann ich wissen? Was soll ich thun! Was darf ich hoffen.",
+			expected: []string{"Was kann ich wissen?", "Was soll ich thun!", "Was darf ich hoffen."},
+		},
+		{
+			name:     "closing quote after sentence end",
+			text:     "Er sagte: „Habe Muth.“ Das ist der Wahlspruch.",
+			expected: []string{"Er sagte: „Habe Muth.“", "Das ist der Wahlspruch."},
+		},
+		{
+			name:     "next sentence starts with opening quote",
+			text:     "Er sagte es. „Habe Muth.“",
+			expected: []string{"Er sagte es.", "„Habe Muth.“"},
+		},
+		{
+			name:     "lowercase continuation",
+			text:     "Das ist wahr. aber nicht immer.",
+			expected: []string{"Das ist wahr. aber nicht immer."},
+		},
+		{
+			name:     "abbreviations",
+			text:     "Dies gilt z. B. Für Raum und Zeit, vgl. Kritik S. 32. Anm. Dazu mehr.",
+			expected: []string{"Dies gilt z. B. Für Raum und Zeit, vgl. Kritik S. 32. Anm. Dazu mehr."},
+		},
+		{
+			name:     "ordinal number",
+			text:     "Im 18. Jahrhundert lebte Kant. Er starb 1804.",
+			expected: []string{"Im 18. Jahrhundert lebte Kant.", "Er starb 1804."},
+		},
+		{
+			name:     "ellipsis",
+			text:     "Und so weiter... Das Ende.",
+			expected: []string{"Und so weiter...", "Das Ende."},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, findSentences(tc.text))
+		})
+	}
+}
@@ -3,6 +3,8 @@ package internal
 //go:generate mockgen -source=$GOFILE -destination=mocks/xml_mapper_mock.go -package=mocks
 
 import (
+	"fmt"
+
 	"github.com/frhorschig/kant-search-backend/common/errs"
 	"github.com/frhorschig/kant-search-backend/core/upload/internal/common/model"
 	"github.com/frhorschig/kant-search-backend/core/upload/internal/dbmapping/flattening"
@@ -17,27 +19,43 @@ import (
 	dbmodel "github.com/frhorschig/kant-search-backend/dataaccess/model"
 )
 
+// Phase is a step of the processing of an uploaded volume
+type Phase string
+
+const (
+	TreeMapping Phase = "treeMapping"
+	TextMapping Phase = "textMapping"
+	References  Phase = "references"
+	Ordering    Phase = "ordering"
+	Indexing    Phase = "indexing" // done by the upload processor after the mapping
+)
+
 type XmlMapper interface {
-	MapXml(volNr int32, xml string) (dbmodel.Volume, []dbmodel.Content, errs.UploadError)
+	// MapXml calls onPhase at the start of each mapping phase
+	MapXml(volNr int32, xml string, onPhase func(Phase)) (dbmodel.Volume, []dbmodel.Content, errs.UploadError)
 }
 
 type xmlMapperImpl struct {
-	metadata metadata.Metadata
+	metadata      metadata.Metadata
+	personAliases metadata.PersonAliases
 }
 
-func NewXmlMapper(metadata metadata.Metadata) XmlMapper {
+func NewXmlMapper(metadata metadata.Metadata, personAliases metadata.PersonAliases) XmlMapper {
 	impl := xmlMapperImpl{
-		metadata: metadata,
+		metadata:      metadata,
+		personAliases: personAliases,
 	}
 	return &impl
 }
 
-func (rec *xmlMapperImpl) MapXml(volNr int32, xml string) (dbmodel.Volume, []dbmodel.Content, errs.UploadError) {
+func (rec *xmlMapperImpl) MapXml(volNr int32, xml string, onPhase func(Phase)) (dbmodel.Volume, []dbmodel.Content, errs.UploadError) {
 	// map xml to model
+	onPhase(TreeMapping)
 	works, fns, summs, err := treemapping.MapTree(xml)
 	if err.HasError {
 		return dbmodel.Volume{}, nil, err
 	}
+	onPhase(TextMapping)
 	err = textmapping.MapText(works, fns, summs)
 	if err.HasError {
 		return dbmodel.Volume{}, nil, err
@@ -46,12 +64,14 @@ func (rec *xmlMapperImpl) MapXml(volNr int32, xml string) (dbmodel.Volume, []dbm
 	if err.HasError {
 		return dbmodel.Volume{}, nil, err
 	}
+	onPhase(References)
 	err = referencemapping.MapReferences(works, fns, summs)
 	if err.HasError {
 		return dbmodel.Volume{}, nil, err
 	}
 
 	// add non-xml metadata to model
+	onPhase(Ordering)
 	err = ordering.Order(works)
 	if err.HasError {
 		return dbmodel.Volume{}, nil, err
@@ -68,5 +88,10 @@ func (rec *xmlMapperImpl) MapXml(volNr int32, xml string) (dbmodel.Volume, []dbm
 	if err.HasError {
 		return dbmodel.Volume{}, nil, err
 	}
+	aliases, aliasErr := rec.personAliases.Read()
+	if aliasErr != nil {
+		return dbmodel.Volume{}, nil, errs.New(nil, fmt.Errorf("unable to read the person aliases: %v", aliasErr))
+	}
+	dbmetadataextraction.ExtractPersons(contents, aliases)
 	return dbVol, contents, errs.Nil()
 }
@@ -24,8 +24,10 @@ func TestXmlMapping(t *testing.T) {
 			{Code: "C2", Siglum: util.StrPtr("S2")},
 		},
 	}
-	xmlMapper := NewXmlMapper(md)
+	aliases := mocks.NewMockPersonAliases(ctrl)
+	xmlMapper := NewXmlMapper(md, aliases)
 	md.EXPECT().Read(volMd.VolumeNumber).Return(volMd, nil)
+	aliases.EXPECT().Read().Return(map[string]string{"Leibnitz": "Leibniz"}, nil)
 
 	const xml = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
 <kant_abt1>
@@ -42,7 +44,7 @@ func TestXmlMapping(t *testing.T) {
       <seite nr="002"/>
       <h2><zeile nr="01"/>heading 1.2</h2>
       <p><zeile nr="02"/>paragraph 2<fr seite="002" nr="1"/></p>
-      <p><zeile nr="03"/>paragraph 3</p>
+      <p><zeile nr="03"/>paragraph 3 <name>Leibnitz</name></p>
 
       <hj>5678</hj>
       <seite nr="003"/>
@@ -77,7 +79,10 @@ func TestXmlMapping(t *testing.T) {
   <band>
 </kant_abt1>`
 
-	vol, contents, err := xmlMapper.MapXml(2, xml)
+	phases := []Phase{}
+	vol, contents, err := xmlMapper.MapXml(2, xml, func(phase Phase) {
+		phases = append(phases, phase)
+	})
 
 	if err.DomainError != nil {
 		println("ERROR: ", err.DomainError.Error())
@@ -88,6 +93,7 @@ func TestXmlMapping(t *testing.T) {
 		return
 	}
 	assert.False(t, err.HasError)
+	assert.Equal(t, []Phase{TreeMapping, TextMapping, References, Ordering}, phases)
 	testutil.AssertDbVolume(t, model.Volume{
 		VolumeNumber: 2,
 		Title:        "vol 2 title",
@@ -158,13 +164,14 @@ func TestXmlMapping(t *testing.T) {
 		{
 			Type:         model.Paragraph,
 			Ordinal:      6,
-			FmtText:      "<ks-meta-line>3</ks-meta-line> paragraph 3",
-			SearchText:   "paragraph 3",
+			FmtText:      "<ks-meta-line>3</ks-meta-line> paragraph 3 <ks-fmt-name>Leibnitz</ks-fmt-name>",
+			SearchText:   "paragraph 3 Leibnitz",
 			Pages:        []int32{2},
+			Persons:      []string{"Leibniz"},
 			SummaryRef:   util.StrPtr("2.3"),
 			PageByIndex:  []model.IndexNumberPair{},
 			LineByIndex:  []model.IndexNumberPair{{I: 0, Num: 3}},
-			WordIndexMap: map[int32]int32{0: 31},
+			WordIndexMap: map[int32]int32{0: 31, 12: 56},
 			WorkCode:     "C1",
 		},
 		{
@@ -54,6 +54,10 @@ func (rec *metadataImpl) Read(volNr int32) (VolumeMetadata, error) {
 		return VolumeMetadata{}, fmt.Errorf("error unmarshaling JSON: %w", err)
 	}
 
-	// we can just use volNr-1 as index here, because from api/upload/upload.go we know that the number is between 1 and 9
-	return volumes[volNr-1], nil
+	for _, v := range volumes {
+		if v.VolumeNumber == volNr {
+			return v, nil
+		}
+	}
+	return VolumeMetadata{}, fmt.Errorf("no metadata found for volume %d", volNr)
 }
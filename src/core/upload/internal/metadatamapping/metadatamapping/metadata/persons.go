@@ -0,0 +1,51 @@
+package metadata
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/persons_mock.go -package=mocks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// PersonAliases maps the spellings of person names in the texts to their normalized name, e.g. "Leibnitz" to "Leibniz"
+type PersonAliases interface {
+	// Read returns an empty map if there is no alias file, because the aliases are optional
+	Read() (map[string]string, error)
+}
+
+type personAliasesImpl struct {
+	aliasesPath string
+}
+
+func NewPersonAliases(configPath string) PersonAliases {
+	impl := personAliasesImpl{
+		aliasesPath: configPath + "/person-aliases.json",
+	}
+	return &impl
+}
+
+func (rec *personAliasesImpl) Read() (map[string]string, error) {
+	file, err := os.Open(rec.aliasesPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	bytes, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	aliases := map[string]string{}
+	err = json.Unmarshal(bytes, &aliases)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+	return aliases, nil
+}
@@ -23,6 +23,9 @@ func MapMetadata(volume *model.Volume, works []model.Work, metadata metadata.Met
 }
 
 func addWorkMetadata(volNr int32, works []model.Work, metadata metadata.VolumeMetadata) errs.UploadError {
+	if len(works) != len(metadata.Works) {
+		return errs.New(fmt.Errorf("the volume has %d works, but the volume metadata contains %d works", len(works), len(metadata.Works)), nil)
+	}
 	for i := range works {
 		work := &works[i]
 		workMd := metadata.Works[i]
@@ -52,6 +52,16 @@ func TestMetadataMapping(t *testing.T) {
 			works:    []model.Work{{}},
 			expError: "the year",
 		},
+		{
+			name: "work count mismatch error",
+			metadata: metadata.VolumeMetadata{
+				VolumeNumber: 10,
+				Works:        []metadata.WorkMetadata{{Code: "code 1", Year: util.StrPtr("1234")}},
+			},
+			volume:   model.Volume{VolumeNumber: 10},
+			works:    []model.Work{{}, {}},
+			expError: "the volume has 2 works, but the volume metadata contains 1 works",
+		},
 		{
 			name:        "error from metadata",
 			metadataErr: errors.New("test err"),
@@ -173,7 +173,7 @@ func fremdsprache(elem *etree.Element) (string, errs.UploadError) {
 	if err.HasError {
 		return "", err
 	}
-	return util.FmtLang(extracted), errs.Nil()
+	return util.FmtLang(extractForeignLangAttrs(elem), extracted), errs.Nil()
 }
 
 func gesperrt(elem *etree.Element) (string, errs.UploadError) {
@@ -169,7 +169,7 @@ func TestEm2(t *testing.T) {
 			name:     "Text with fremdsprache child element",
 			text:     "Test text",
 			child:    elem("fremdsprache", nil, "fremdspracheText", nil),
-			expected: util.FmtEmph2("Test text " + util.FmtLang("fremdspracheText")),
+			expected: util.FmtEmph2("Test text " + util.FmtLang("", "fremdspracheText")),
 		},
 		{
 			name:     "Text with gesperrt child element",
@@ -387,103 +387,103 @@ func TestFremdsprache(t *testing.T) {
 		{
 			name:     "Pure text",
 			text:     "Some foreing language text",
-			expected: util.FmtLang("Some foreing language text"),
+			expected: util.FmtLang("", "Some foreing language text"),
 		},
 		{
 			name:     "Text with language attributes",
 			text:     "Some foreign language text",
 			attrs:    map[string]string{"sprache": "language", "zeichen": "some alphabet", "umschrift": "transcribed text"},
-			expected: util.FmtLang("Some foreign language text"),
+			expected: util.FmtLang(` lang="language" alphabet="some alphabet" transcript="transcribed text"`, "Some foreign language text"),
 		},
 		{
 			name:     "Text with bild child element",
 			text:     "Test text",
 			child:    elem("bild", map[string]string{"src": "src", "beschreibung": "desc"}, "", nil),
-			expected: "Test text " + util.FmtImgRef("src", "desc"),
+			expected: util.FmtLang("", "Test text "+util.FmtImgRef("src", "desc")),
 		},
 		{
 			name:     "Text with bildverweis child element",
 			text:     "Test text",
 			child:    elem("bildverweis", map[string]string{"src": "src", "beschreibung": "desc"}, "", nil),
-			expected: "Test text " + util.FmtImgRef("src", "desc"),
+			expected: util.FmtLang("", "Test text "+util.FmtImgRef("src", "desc")),
 		},
 		{
 			name:     "Text with em1 child element",
 			text:     "Test text",
 			child:    elem("em1", nil, "em1Text", nil),
-			expected: util.FmtLang("Test text " + util.FmtEmph("em1Text")),
+			expected: util.FmtLang("", "Test text "+util.FmtEmph("em1Text")),
 		},
 		{
 			name:     "Text with em2 child element",
 			text:     "Test text",
 			child:    elem("em2", nil, "em2Text", nil),
-			expected: util.FmtLang("Test text " + util.FmtEmph2("em2Text")),
+			expected: util.FmtLang("", "Test text "+util.FmtEmph2("em2Text")),
 		},
 		{
 			name:     "Text with fett child element",
 			text:     "Test text",
 			child:    elem("fett", nil, "fettText", nil),
-			expected: util.FmtLang("Test text " + util.FmtBold("fettText")),
+			expected: util.FmtLang("", "Test text "+util.FmtBold("fettText")),
 		},
 		{
 			name:     "Text with formel child element",
 			text:     "Test text",
 			child:    elem("formel", nil, "formelText", nil),
-			expected: util.FmtLang("Test text " + util.FmtFormula("formelText")),
+			expected: util.FmtLang("", "Test text "+util.FmtFormula("formelText")),
 		},
 		{
 			name:     "Text with fr child element",
 			text:     "Test text",
 			child:    elem("fr", map[string]string{"seite": "1", "nr": "2"}, "", nil),
-			expected: util.FmtLang("Test text " + util.FmtFnRef(1, 2)),
+			expected: util.FmtLang("", "Test text "+util.FmtFnRef(1, 2)),
 		},
 		{
 			name:     "Text with fremdsprache child element",
 			text:     "Test text",
 			child:    elem("fremdsprache", nil, "fremdspracheText", nil),
-			expected: util.FmtLang("Test text " + util.FmtLang("fremdspracheText")),
+			expected: util.FmtLang("", "Test text "+util.FmtLang("", "fremdspracheText")),
 		},
 		{
 			name:     "Text with gesperrt child element",
 			text:     "Test text",
 			child:    elem("gesperrt", nil, "gesperrtText", nil),
-			expected: util.FmtLang("Test text " + util.FmtTracked("gesperrtText")),
+			expected: util.FmtLang("", "Test text "+util.FmtTracked("gesperrtText")),
 		},
 		{
 			name:     "Text with name child element",
 			text:     "Test text",
 			child:    elem("name", nil, "nameText", nil),
-			expected: util.FmtLang("Test text " + util.FmtName("nameText")),
+			expected: util.FmtLang("", "Test text "+util.FmtName("nameText")),
 		},
 		{
 			name:     "Text with romzahl child element",
 			text:     "Test text",
 			child:    elem("romzahl", nil, "2.", nil),
-			expected: util.FmtLang("Test text II."),
+			expected: util.FmtLang("", "Test text II."),
 		},
 		{
 			name:     "Text with seite child element",
 			text:     "Test text",
 			child:    elem("seite", map[string]string{"nr": "384"}, "", nil),
-			expected: util.FmtLang("Test text " + util.FmtPage(384)),
+			expected: util.FmtLang("", "Test text "+util.FmtPage(384)),
 		},
 		{
 			name:     "Text with trenn child element",
 			text:     "Test text",
 			child:    elem("trenn", nil, "trennText", nil),
-			expected: util.FmtLang("Test text"),
+			expected: util.FmtLang("", "Test text"),
 		},
 		{
 			name:     "Text with zeile child element",
 			text:     "Test text",
 			child:    elem("zeile", map[string]string{"nr": "328"}, "", nil),
-			expected: util.FmtLang("Test text " + util.FmtLine(328)),
+			expected: util.FmtLang("", "Test text "+util.FmtLine(328)),
 		},
 		{
 			name:     "Text with leading and trailing spaces",
 			text:     "   Test text       ",
 			child:    nil,
-			expected: util.FmtLang("Test text"),
+			expected: util.FmtLang("", "Test text"),
 		},
 		{
 			name:        "Text with unknown child element",
@@ -83,7 +83,7 @@ func hx(elem *etree.Element) (string, string, errs.UploadError) {
 				tocTitle += elText
 				textTitle += elText
 			case "op":
-				continue
+				textTitle += op(el)
 			case "romzahl":
 				elText, err = romzahl(el)
 				tocTitle += elText
@@ -130,7 +130,7 @@ func hu(elem *etree.Element) (string, errs.UploadError) {
 		case "name":
 			return name(el)
 		case "op":
-			return "", errs.Nil()
+			return op(el), errs.Nil()
 		case "romzahl":
 			return romzahl(el)
 		case "seite":
@@ -172,7 +172,7 @@ func p(elem *etree.Element) (string, errs.UploadError) {
 		case "name":
 			return name(el)
 		case "op":
-			return "", errs.Nil()
+			return op(el), errs.Nil()
 		case "romzahl":
 			return romzahl(el)
 		case "table":
@@ -198,6 +198,15 @@ func seite(elem *etree.Element) (string, errs.UploadError) {
 	return util.FmtPage(page), errs.Nil()
 }
 
+// op is the start of a page of the original edition of the work, markers without a page number are ignored
+func op(elem *etree.Element) string {
+	page := strings.TrimSpace(elem.SelectAttrValue("nr", ""))
+	if page == "" {
+		return ""
+	}
+	return util.FmtOrigPage(page)
+}
+
 func table(elem *etree.Element) (string, errs.UploadError) {
 	switchFn := func(el *etree.Element) (string, errs.UploadError) {
 		switch el.Tag {
@@ -240,7 +249,7 @@ func td(elem *etree.Element) (string, errs.UploadError) {
 		case "name":
 			return name(el)
 		case "op":
-			return "", errs.Nil()
+			return op(el), errs.Nil()
 		case "p":
 			return p(el)
 		case "romzahl":
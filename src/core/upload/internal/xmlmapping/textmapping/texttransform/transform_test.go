@@ -41,8 +41,8 @@ func TestHx(t *testing.T) {
 			name:              "Text with fremdsprache child element",
 			text:              "<h5>Test text</h5>",
 			child:             elem("fremdsprache", nil, "fremdspracheText", nil),
-			expectedTocTitle:  "Test text " + util.FmtLang("fremdspracheText"),
-			expectedTextTitle: "<ks-fmt-h4>Test text " + util.FmtLang("fremdspracheText") + "</ks-fmt-h4>",
+			expectedTocTitle:  "Test text fremdspracheText",
+			expectedTextTitle: "<ks-fmt-h4>Test text " + util.FmtLang("", "fremdspracheText") + "</ks-fmt-h4>",
 		},
 		{
 			name:              "Text with gesperrt child element",
@@ -75,9 +75,9 @@ func TestHx(t *testing.T) {
 		{
 			name:              "Text with op child element",
 			text:              "<h2>Test text</h2>",
-			child:             elem("op", nil, "opText", nil),
+			child:             elem("op", map[string]string{"nr": "132"}, "", nil),
 			expectedTocTitle:  "Test text",
-			expectedTextTitle: "<ks-fmt-h1>Test text</ks-fmt-h1>",
+			expectedTextTitle: "<ks-fmt-h1>Test text " + util.FmtOrigPage("132") + "</ks-fmt-h1>",
 		},
 		{
 			name:              "Text with romzahl child element",
@@ -170,7 +170,7 @@ func TestHu(t *testing.T) {
 			name:     "Text with fremdsprache child element",
 			text:     "Test text",
 			child:    elem("fremdsprache", nil, "fremdspracheText", nil),
-			expected: "Test text " + util.FmtLang("fremdspracheText"),
+			expected: "Test text " + util.FmtLang("", "fremdspracheText"),
 		},
 		{
 			name:     "Text with gesperrt child element",
@@ -187,6 +187,12 @@ func TestHu(t *testing.T) {
 		{
 			name:     "Text with op child element",
 			text:     "Test text",
+			child:    elem("op", map[string]string{"nr": "XVI"}, "", nil),
+			expected: "Test text " + util.FmtOrigPage("XVI"),
+		},
+		{
+			name:     "Text with op child element without page number",
+			text:     "Test text",
 			child:    elem("op", nil, "opText", nil),
 			expected: "Test text",
 		},
@@ -305,7 +311,7 @@ func TestP(t *testing.T) {
 			name:     "Text with fremdsprache child element",
 			text:     "Test text",
 			child:    elem("fremdsprache", nil, "fremdspracheText", nil),
-			expected: "Test text " + util.FmtLang("fremdspracheText"),
+			expected: "Test text " + util.FmtLang("", "fremdspracheText"),
 		},
 		{
 			name:     "Text with gesperrt child element",
@@ -322,6 +328,12 @@ func TestP(t *testing.T) {
 		{
 			name:     "Text with op child element",
 			text:     "Test text",
+			child:    elem("op", map[string]string{"nr": "XVI"}, "", nil),
+			expected: "Test text " + util.FmtOrigPage("XVI"),
+		},
+		{
+			name:     "Text with op child element without page number",
+			text:     "Test text",
 			child:    elem("op", nil, "opText", nil),
 			expected: "Test text",
 		},
@@ -593,7 +605,7 @@ func TestTd(t *testing.T) {
 			name:     "Text with fremdsprache child element",
 			text:     "Test text",
 			child:    elem("fremdsprache", nil, "fremdspracheText", nil),
-			expected: "<td>Test text " + util.FmtLang("fremdspracheText") + "</td>",
+			expected: "<td>Test text " + util.FmtLang("", "fremdspracheText") + "</td>",
 		},
 		{
 			name:     "Text with gesperrt child element",
@@ -610,8 +622,8 @@ func TestTd(t *testing.T) {
 		{
 			name:     "Text with op child element",
 			text:     "Test text",
-			child:    elem("op", nil, "opText", nil),
-			expected: "<td>Test text</td>",
+			child:    elem("op", map[string]string{"nr": "132"}, "", nil),
+			expected: "<td>Test text " + util.FmtOrigPage("132") + "</td>",
 		},
 		{
 			name:     "Text with p child element",
@@ -29,74 +29,144 @@ func MapTree(xml string) ([]model.Work, []model.Footnote, []model.Summary, errs.
 	return works, footnotes, summaries, errs.Nil()
 }
 
-func findWorks(hauptteil *etree.Element) ([]model.Work, errs.UploadError) {
-	works := []model.Work{}
-	currentYear := ""
-	sectionList := make([]*[]model.Section, 10)
-	var currPars *[]model.Paragraph = nil
-	pagePrefix := ""
+// workTree is the state of the mapping of the hauptteil elements to works and sections
+type workTree struct {
+	works       []model.Work
+	currentYear string
+	sectionList []*[]model.Section
+	currPars    *[]model.Paragraph
+	pagePrefix  string
+	// the original page markers since the last heading or paragraph, unlike page markers they are kept across work titles
+	origPagePrefix string
+	// the metadata of the letter or Reflexion whose element is being mapped, it is added to the next section
+	letter     *model.Letter
+	reflection *model.Reflection
+	// the letter or Reflexion element whose child elements are being mapped
+	container *etree.Element
+}
 
+func findWorks(hauptteil *etree.Element) ([]model.Work, errs.UploadError) {
+	tree := workTree{
+		works:       []model.Work{},
+		sectionList: make([]*[]model.Section, 10),
+	}
 	for _, el := range hauptteil.ChildElements() {
-		elStr, err := elemToString(el)
-		if err != nil {
-			return nil, errs.New(nil, fmt.Errorf(writingErrorMsg, el.Tag, err))
+		if err := tree.addElement(el); err.HasError {
+			return nil, err
 		}
+	}
+	return tree.works, errs.Nil()
+}
 
-		switch el.Tag {
-		case "hj":
-			currentYear = strings.TrimSpace(el.Text())
+func (tree *workTree) addElement(el *etree.Element) errs.UploadError {
+	elStr, err := elemToString(el)
+	if err != nil {
+		return errs.New(nil, fmt.Errorf(writingErrorMsg, el.Tag, err))
+	}
 
-		case "h1":
-			work := model.Work{
-				Title:      elStr,
-				Year:       currentYear,
-				Paragraphs: []model.Paragraph{},
-				Sections:   []model.Section{},
-			}
-			works = append(works, work)
-			w := &works[len(works)-1]
-			currPars = &w.Paragraphs
-			updateSectionList(&sectionList, &w.Sections, 1)
-
-		case "h2", "h3", "h4", "h5", "h6", "h7", "h8", "h9":
-			if pagePrefix != "" {
-				i := strings.Index(elStr, ">")
-				elStr = elStr[0:i+1] + pagePrefix + elStr[i+1:]
-				pagePrefix = ""
-			}
-			sec := model.Section{
-				Heading:    model.Heading{Text: elStr},
-				Paragraphs: []model.Paragraph{},
-				Sections:   []model.Section{},
-			}
-			lvl := int(el.Tag[1] - '0')
-			if sectionList[lvl-1] == nil {
-				return nil, errs.New(fmt.Errorf("the difference between the heading level %s for heading '%s' and the heading before is greater than 1", el.Tag, sec.Heading.Text), nil)
+	switch el.Tag {
+	case "hj":
+		tree.currentYear = strings.TrimSpace(el.Text())
+
+	case "h1":
+		work := model.Work{
+			Title:      elStr,
+			Year:       tree.currentYear,
+			Paragraphs: []model.Paragraph{},
+			Sections:   []model.Section{},
+		}
+		tree.works = append(tree.works, work)
+		w := &tree.works[len(tree.works)-1]
+		tree.currPars = &w.Paragraphs
+		updateSectionList(&tree.sectionList, &w.Sections, 1)
+
+	case "h2", "h3", "h4", "h5", "h6", "h7", "h8", "h9":
+		elStr = tree.addPagePrefix(elStr)
+		sec := model.Section{
+			Heading:    model.Heading{Text: elStr},
+			Paragraphs: []model.Paragraph{},
+			Sections:   []model.Section{},
+			Letter:     tree.letter,
+			Reflection: tree.reflection,
+		}
+		tree.letter = nil
+		tree.reflection = nil
+		lvl := int(el.Tag[1] - '0')
+		if tree.sectionList[lvl-1] == nil {
+			return errs.New(fmt.Errorf("the difference between the heading level %s for heading '%s' and the heading before is greater than 1", el.Tag, sec.Heading.Text), nil)
+		}
+		*tree.sectionList[lvl-1] = append(*tree.sectionList[lvl-1], sec)
+		s := &((*(tree.sectionList[lvl-1]))[len(*tree.sectionList[lvl-1])-1])
+		tree.currPars = &s.Paragraphs
+		updateSectionList(&tree.sectionList, &s.Sections, lvl)
+
+	case "hu", "p", "table":
+		if tree.letter != nil || tree.reflection != nil {
+			// the paragraph would otherwise be added to the section before the letter or Reflexion
+			return errs.New(fmt.Errorf("the element '%s' with the number '%s' has a paragraph before its first heading", tree.container.Tag, tree.container.SelectAttrValue("nr", "")), nil)
+		}
+		elStr = tree.addPagePrefix(elStr)
+		*tree.currPars = append(*tree.currPars, model.Paragraph{Text: elStr})
+
+	case "brief":
+		return tree.addContainer(el, func() {
+			tree.letter = &model.Letter{
+				Number:    el.SelectAttrValue("nr", ""),
+				Sender:    el.SelectAttrValue("absender", ""),
+				Recipient: el.SelectAttrValue("empfaenger", ""),
+				Date:      el.SelectAttrValue("datum", ""),
 			}
-			*sectionList[lvl-1] = append(*sectionList[lvl-1], sec)
-			s := &((*(sectionList[lvl-1]))[len(*sectionList[lvl-1])-1])
-			currPars = &s.Paragraphs
-			updateSectionList(&sectionList, &s.Sections, lvl)
-
-		case "hu", "p", "table":
-			if pagePrefix != "" {
-				i := strings.Index(elStr, ">")
-				elStr = elStr[0:i+1] + pagePrefix + elStr[i+1:]
-				pagePrefix = ""
+		})
+
+	case "reflexion":
+		return tree.addContainer(el, func() {
+			tree.reflection = &model.Reflection{
+				Number: el.SelectAttrValue("nr", ""),
+				Dating: el.SelectAttrValue("datierung", ""),
 			}
-			*currPars = append(*currPars, model.Paragraph{Text: elStr})
+		})
 
-		case "op":
-			continue
+	case "op":
+		tree.origPagePrefix += elStr
 
-		case "seite":
-			pagePrefix = elStr
+	case "seite":
+		tree.pagePrefix = elStr
 
-		default:
-			return nil, errs.New(fmt.Errorf("unknown tag '%s' in hauptteil element", el.Tag), nil)
+	default:
+		return errs.New(fmt.Errorf("unknown tag '%s' in hauptteil element", el.Tag), nil)
+	}
+	return errs.Nil()
+}
+
+// addContainer maps the child elements of a letter or Reflexion element like the elements of the hauptteil, the metadata set by setMetadata is added to the first section of the children, which must start before the first paragraph
+func (tree *workTree) addContainer(el *etree.Element, setMetadata func()) errs.UploadError {
+	if tree.container != nil {
+		return errs.New(fmt.Errorf("the element '%s' with the number '%s' is nested in another letter or Reflexion", el.Tag, el.SelectAttrValue("nr", "")), nil)
+	}
+	tree.container = el
+	setMetadata()
+	for _, child := range el.ChildElements() {
+		if err := tree.addElement(child); err.HasError {
+			return err
 		}
 	}
-	return works, errs.Nil()
+	tree.container = nil
+	if tree.letter != nil || tree.reflection != nil {
+		return errs.New(fmt.Errorf("the element '%s' with the number '%s' has no heading", el.Tag, el.SelectAttrValue("nr", "")), nil)
+	}
+	return errs.Nil()
+}
+
+func (tree *workTree) addPagePrefix(elStr string) string {
+	prefix := tree.pagePrefix + tree.origPagePrefix
+	if prefix == "" {
+		return elStr
+	}
+	i := strings.Index(elStr, ">")
+	elStr = elStr[0:i+1] + prefix + elStr[i+1:]
+	tree.pagePrefix = ""
+	tree.origPagePrefix = ""
+	return elStr
 }
 
 func findFootnotes(fussnoten *etree.Element) ([]model.Footnote, errs.UploadError) {
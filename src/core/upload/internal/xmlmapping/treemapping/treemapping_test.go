@@ -206,11 +206,12 @@ func TestTreeMapping(t *testing.T) {
 			},
 		},
 		{
-			name: "op is ignored",
+			name: "op is added to the next heading or paragraph",
 			xmlMain: `
 				<op nr="2"/>
                 <h1> work 1 </h1>
 				<op nr="3"/>
+				<seite nr="5"/>
                 <h2> heading 2 </h2>
 				<op nr="4"/>
 				<p> paragraph </p>`,
@@ -218,14 +219,53 @@ func TestTreeMapping(t *testing.T) {
 				{
 					Title: "<h1> work 1 </h1>",
 					Sections: []model.Section{{
-						Heading: model.Heading{Text: "<h2> heading 2 </h2>"},
+						Heading: model.Heading{Text: `<h2><seite nr="5"/><op nr="2"/><op nr="3"/> heading 2 </h2>`},
 						Paragraphs: []model.Paragraph{{
-							Text: "<p> paragraph </p>",
+							Text: `<p><op nr="4"/> paragraph </p>`,
 						}},
 					}},
 				},
 			},
 		},
+		{
+			name: "letters and Reflexionen",
+			xmlMain: `
+                <h1> work 1 </h1>
+				<brief nr="12" absender="Kant" empfaenger="Lambert" datum="1765-12-31">
+					<seite nr="51"/>
+					<h2> letter 12 </h2>
+					<p> paragraph 1 </p>
+					<h3> postscript </h3>
+				</brief>
+				<reflexion nr="4275" datierung="1770-71">
+					<h2> reflexion 4275 </h2>
+					<p> paragraph 2 </p>
+				</reflexion>
+				<h2> heading 2 </h2>`,
+			expWorks: []model.Work{
+				{
+					Title: "<h1> work 1 </h1>",
+					Sections: []model.Section{
+						{
+							Heading:    model.Heading{Text: "<h2><seite nr=\"51\"/> letter 12 </h2>"},
+							Paragraphs: []model.Paragraph{{Text: "<p> paragraph 1 </p>"}},
+							Sections: []model.Section{{
+								Heading: model.Heading{Text: "<h3> postscript </h3>"},
+							}},
+							Letter: &model.Letter{Number: "12", Sender: "Kant", Recipient: "Lambert", Date: "1765-12-31"},
+						},
+						{
+							Heading:    model.Heading{Text: "<h2> reflexion 4275 </h2>"},
+							Paragraphs: []model.Paragraph{{Text: "<p> paragraph 2 </p>"}},
+							Reflection: &model.Reflection{Number: "4275", Dating: "1770-71"},
+						},
+						{
+							Heading: model.Heading{Text: "<h2> heading 2 </h2>"},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "map footnotes and summaries",
 			xmlFootnotes: `
@@ -251,6 +291,28 @@ func TestTreeMapping(t *testing.T) {
 		        <h4> heading 4 </h4>`,
 			expectError: true,
 		},
+		{
+			name: "error on letter without heading",
+			xmlMain: `
+		        <h1> work 1 </h1>
+		        <brief nr="12"><p> paragraph </p></brief>`,
+			expectError: true,
+		},
+		{
+			name: "error on paragraph before the heading of a letter",
+			xmlMain: `
+		        <h1> work 1 </h1>
+		        <h2> heading 2 </h2>
+		        <brief nr="12"><p> paragraph </p><h2> letter 12 </h2></brief>`,
+			expectError: true,
+		},
+		{
+			name: "error on nested Reflexion",
+			xmlMain: `
+		        <h1> work 1 </h1>
+		        <reflexion nr="1"><reflexion nr="2"><h2> heading 2 </h2></reflexion></reflexion>`,
+			expectError: true,
+		},
 	}
 
 	for _, tc := range testCases {
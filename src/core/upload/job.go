@@ -0,0 +1,234 @@
+package upload
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/job_mock.go -package=mocks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/frhorschig/kant-search-backend/core/upload/internal"
+	"github.com/rs/zerolog/log"
+)
+
+type JobStatus string
+
+const (
+	Queued    JobStatus = "queued"
+	Running   JobStatus = "running"
+	Succeeded JobStatus = "succeeded"
+	Failed    JobStatus = "failed"
+)
+
+// Job is the asynchronous processing of an uploaded volume
+type Job struct {
+	ID           string
+	VolumeNumber int32
+	Status       JobStatus
+	Phase        Phase // empty while the job is queued
+	Progress     int32 // in percent
+	Warnings     []string
+	Error        *JobError // only for failed jobs
+	Created      time.Time
+	Finished     *time.Time
+}
+
+type JobError struct {
+	IsDomainError bool // the error is caused by the uploaded data, otherwise it is a technical error
+	Message       string
+}
+
+var (
+	ErrQueueFull        = errors.New("the upload queue is full")
+	ErrVolumeInProgress = errors.New("the volume is already queued or processed")
+)
+
+// the progress at the start of each phase, the indexing gets the largest share because it takes the most time
+const indexingStart int32 = 40
+
+var progressByPhase = map[Phase]int32{
+	internal.TreeMapping: 0,
+	internal.TextMapping: 10,
+	internal.References:  20,
+	internal.Ordering:    30,
+	internal.Indexing:    indexingStart,
+}
+
+// finished jobs are removed after this time, so that the job list doesn't grow indefinitely
+const finishedJobRetention = 24 * time.Hour
+
+type JobManager interface {
+	// Submit queues the upload of a volume; it returns ErrQueueFull or ErrVolumeInProgress if the upload can't be queued
+	Submit(volNr int32, xml string) (Job, error)
+	// Get returns a copy of the job with the ID, the bool is false if there is no such job
+	Get(id string) (Job, bool)
+}
+
+type jobManagerImpl struct {
+	uploadProcessor UploadProcessor
+	queue           chan queuedJob
+	mutex           sync.Mutex
+	jobs            map[string]*Job
+}
+
+type queuedJob struct {
+	id    string
+	volNr int32
+	xml   string
+}
+
+// NewJobManager starts the given number of workers that process the queued jobs; at most queueSize jobs can wait for a worker
+func NewJobManager(uploadProcessor UploadProcessor, workers int, queueSize int) JobManager {
+	manager := jobManagerImpl{
+		uploadProcessor: uploadProcessor,
+		queue:           make(chan queuedJob, queueSize),
+		jobs:            map[string]*Job{},
+	}
+	for range max(workers, 1) {
+		go manager.work()
+	}
+	return &manager
+}
+
+func (rec *jobManagerImpl) Submit(volNr int32, xml string) (Job, error) {
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+
+	rec.removeOldJobs()
+	for _, j := range rec.jobs {
+		// the same volume must not be processed concurrently, because the old data is deleted before the new data is inserted
+		if j.VolumeNumber == volNr && (j.Status == Queued || j.Status == Running) {
+			return Job{}, ErrVolumeInProgress
+		}
+	}
+	id, err := createJobId()
+	if err != nil {
+		return Job{}, fmt.Errorf("unable to create job ID: %w", err)
+	}
+	job := &Job{
+		ID:           id,
+		VolumeNumber: volNr,
+		Status:       Queued,
+		Warnings:     []string{},
+		Created:      time.Now(),
+	}
+	select {
+	case rec.queue <- queuedJob{id: id, volNr: volNr, xml: xml}:
+		rec.jobs[id] = job
+		return copyJob(job), nil
+	default:
+		return Job{}, ErrQueueFull
+	}
+}
+
+func (rec *jobManagerImpl) Get(id string) (Job, bool) {
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+	job, ok := rec.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return copyJob(job), true
+}
+
+func (rec *jobManagerImpl) work() {
+	for qj := range rec.queue {
+		rec.runJob(qj)
+	}
+}
+
+// runJob processes a queued job; a panic of the upload processor fails the job with a technical error, so that the worker keeps processing the queue
+func (rec *jobManagerImpl) runJob(qj queuedJob) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().Msgf("panic while processing volume %d in job %s: %v", qj.volNr, qj.id, r)
+			rec.update(qj.id, func(job *Job) {
+				now := time.Now()
+				job.Finished = &now
+				job.Status = Failed
+				job.Error = &JobError{IsDomainError: false, Message: fmt.Sprintf("panic while processing volume %d: %v", qj.volNr, r)}
+			})
+		}
+	}()
+	rec.update(qj.id, func(job *Job) { job.Status = Running })
+	progress := &jobProgress{manager: rec, id: qj.id}
+	err := rec.uploadProcessor.Process(context.Background(), qj.volNr, qj.xml, progress)
+	rec.update(qj.id, func(job *Job) {
+		now := time.Now()
+		job.Finished = &now
+		if !err.HasError {
+			job.Status = Succeeded
+			job.Progress = 100
+			return
+		}
+		job.Status = Failed
+		if err.DomainError != nil {
+			log.Error().Msgf("error processing volume %d in job %s: %v", qj.volNr, qj.id, err.DomainError)
+			job.Error = &JobError{IsDomainError: true, Message: err.DomainError.Error()}
+		} else {
+			log.Error().Err(err.TechnicalError).Msgf("error processing volume %d in job %s", qj.volNr, qj.id)
+			job.Error = &JobError{IsDomainError: false, Message: err.TechnicalError.Error()}
+		}
+	})
+}
+
+func (rec *jobManagerImpl) update(id string, update func(job *Job)) {
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+	if job, ok := rec.jobs[id]; ok {
+		update(job)
+	}
+}
+
+// removeOldJobs removes the jobs that have been finished for longer than the retention period, the caller must hold the lock
+func (rec *jobManagerImpl) removeOldJobs() {
+	for id, j := range rec.jobs {
+		if j.Finished != nil && time.Since(*j.Finished) > finishedJobRetention {
+			delete(rec.jobs, id)
+		}
+	}
+}
+
+// jobProgress updates the job with the ID from the progress reported by the upload processor
+type jobProgress struct {
+	manager *jobManagerImpl
+	id      string
+}
+
+func (rec *jobProgress) SetPhase(phase Phase) {
+	rec.manager.update(rec.id, func(job *Job) {
+		job.Phase = phase
+		job.Progress = progressByPhase[phase]
+	})
+}
+
+func (rec *jobProgress) SetProgress(percent int32) {
+	rec.manager.update(rec.id, func(job *Job) { job.Progress = percent })
+}
+
+func (rec *jobProgress) AddWarning(msg string) {
+	rec.manager.update(rec.id, func(job *Job) { job.Warnings = append(job.Warnings, msg) })
+}
+
+func createJobId() (string, error) {
+	bytes := make([]byte, 8)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func copyJob(job *Job) Job {
+	c := *job
+	c.Warnings = slices.Clone(job.Warnings)
+	if job.Error != nil {
+		e := *job.Error
+		c.Error = &e
+	}
+	return c
+}
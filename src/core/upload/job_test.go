@@ -0,0 +1,162 @@
+//go:build unit
+// +build unit
+
+package upload
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/frhorschig/kant-search-backend/common/errs"
+	"github.com/frhorschig/kant-search-backend/core/upload/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobManager(t *testing.T) {
+	t.Run("Successful job", func(t *testing.T) {
+		// GIVEN
+		processor := &testProcessor{process: func(volNr int32, progress Progress) errs.UploadError {
+			progress.SetPhase(internal.TreeMapping)
+			progress.AddWarning("a warning")
+			progress.SetPhase(internal.Indexing)
+			return errs.Nil()
+		}}
+		sut := NewJobManager(processor, 1, 1)
+		// WHEN
+		job, err := sut.Submit(3, "xml")
+		// THEN
+		assert.Nil(t, err)
+		assert.NotEmpty(t, job.ID)
+		assert.Equal(t, int32(3), job.VolumeNumber)
+		finished := waitForStatus(t, sut, job.ID, Succeeded)
+		assert.Equal(t, internal.Indexing, finished.Phase)
+		assert.Equal(t, int32(100), finished.Progress)
+		assert.Equal(t, []string{"a warning"}, finished.Warnings)
+		assert.Nil(t, finished.Error)
+		assert.NotNil(t, finished.Finished)
+	})
+
+	t.Run("Failed jobs", func(t *testing.T) {
+		// GIVEN
+		processor := &testProcessor{process: func(volNr int32, progress Progress) errs.UploadError {
+			progress.SetPhase(internal.TextMapping)
+			if volNr == 1 {
+				return errs.New(fmt.Errorf("domain error"), nil)
+			}
+			return errs.New(nil, fmt.Errorf("technical error"))
+		}}
+		sut := NewJobManager(processor, 2, 2)
+		// WHEN
+		domainJob, _ := sut.Submit(1, "xml")
+		technicalJob, _ := sut.Submit(2, "xml")
+		// THEN
+		failed := waitForStatus(t, sut, domainJob.ID, Failed)
+		assert.Equal(t, internal.TextMapping, failed.Phase)
+		assert.Equal(t, int32(10), failed.Progress)
+		assert.Equal(t, &JobError{IsDomainError: true, Message: "domain error"}, failed.Error)
+		failed = waitForStatus(t, sut, technicalJob.ID, Failed)
+		assert.Equal(t, &JobError{IsDomainError: false, Message: "technical error"}, failed.Error)
+	})
+
+	t.Run("Panicking processor", func(t *testing.T) {
+		// GIVEN
+		processor := &testProcessor{process: func(volNr int32, progress Progress) errs.UploadError {
+			if volNr == 1 {
+				panic("test panic")
+			}
+			return errs.Nil()
+		}}
+		sut := NewJobManager(processor, 1, 2)
+		// WHEN
+		panicJob, _ := sut.Submit(1, "xml")
+		nextJob, _ := sut.Submit(2, "xml")
+		// THEN
+		failed := waitForStatus(t, sut, panicJob.ID, Failed)
+		assert.Equal(t, &JobError{IsDomainError: false, Message: "panic while processing volume 1: test panic"}, failed.Error)
+		assert.NotNil(t, failed.Finished)
+		// the worker keeps processing the queue
+		waitForStatus(t, sut, nextJob.ID, Succeeded)
+	})
+
+	t.Run("Volume in progress and full queue", func(t *testing.T) {
+		// GIVEN
+		release := make(chan bool)
+		processor := &testProcessor{process: func(volNr int32, progress Progress) errs.UploadError {
+			<-release
+			return errs.Nil()
+		}}
+		sut := NewJobManager(processor, 1, 1)
+		running, _ := sut.Submit(1, "xml")
+		waitForStatus(t, sut, running.ID, Running)
+		queued, _ := sut.Submit(2, "xml")
+		// WHEN
+		_, errInProgress := sut.Submit(1, "xml")
+		_, errQueueFull := sut.Submit(3, "xml")
+		// THEN
+		assert.ErrorIs(t, errInProgress, ErrVolumeInProgress)
+		assert.ErrorIs(t, errQueueFull, ErrQueueFull)
+		job, _ := sut.Get(queued.ID)
+		assert.Equal(t, Queued, job.Status)
+		close(release)
+		waitForStatus(t, sut, queued.ID, Succeeded)
+		// the volume can be uploaded again after the job is finished
+		_, err := sut.Submit(1, "xml")
+		assert.Nil(t, err)
+	})
+
+	t.Run("Unknown job", func(t *testing.T) {
+		// GIVEN
+		sut := NewJobManager(&testProcessor{}, 1, 1)
+		// WHEN
+		_, ok := sut.Get("unknown")
+		// THEN
+		assert.False(t, ok)
+	})
+
+	t.Run("Old finished jobs are removed", func(t *testing.T) {
+		// GIVEN
+		old := time.Now().Add(-2 * finishedJobRetention)
+		recent := time.Now()
+		sut := &jobManagerImpl{
+			uploadProcessor: &testProcessor{},
+			queue:           make(chan queuedJob, 1),
+			jobs: map[string]*Job{
+				"old":     {ID: "old", Status: Succeeded, Finished: &old},
+				"recent":  {ID: "recent", Status: Failed, Finished: &recent},
+				"running": {ID: "running", Status: Running},
+			},
+		}
+		// WHEN
+		sut.Submit(1, "xml")
+		// THEN
+		_, oldOk := sut.Get("old")
+		_, recentOk := sut.Get("recent")
+		_, runningOk := sut.Get("running")
+		assert.False(t, oldOk)
+		assert.True(t, recentOk)
+		assert.True(t, runningOk)
+	})
+}
+
+func waitForStatus(t *testing.T, sut JobManager, id string, status JobStatus) Job {
+	var job Job
+	assert.Eventually(t, func() bool {
+		job, _ = sut.Get(id)
+		return job.Status == status
+	}, time.Second, time.Millisecond)
+	return job
+}
+
+type testProcessor struct {
+	process func(volNr int32, progress Progress) errs.UploadError
+}
+
+func (rec *testProcessor) Process(ctx context.Context, volNr int32, xml string, progress Progress) errs.UploadError {
+	return rec.process(volNr, progress)
+}
+
+func (rec *testProcessor) Preview(ctx context.Context, volNr int32, xml string) (Preview, errs.UploadError) {
+	return Preview{}, errs.Nil()
+}
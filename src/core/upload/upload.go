@@ -4,51 +4,166 @@ package upload
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/frhorschig/kant-search-backend/common/errs"
 	"github.com/frhorschig/kant-search-backend/core/upload/internal"
+	"github.com/frhorschig/kant-search-backend/core/upload/internal/common/util"
 	"github.com/frhorschig/kant-search-backend/core/upload/internal/metadatamapping/metadatamapping/metadata"
 	"github.com/frhorschig/kant-search-backend/dataaccess"
 	"github.com/frhorschig/kant-search-backend/dataaccess/model"
 )
 
 type UploadProcessor interface {
-	Process(ctx context.Context, volNum int32, xml string) errs.UploadError
+	Process(ctx context.Context, volNum int32, xml string, progress Progress) errs.UploadError
+	// Preview maps the volume like Process, but doesn't store it
+	Preview(ctx context.Context, volNum int32, xml string) (Preview, errs.UploadError)
 }
 
+// Preview is the result of a dry-run upload, the volume as it would be stored
+type Preview struct {
+	Volume   model.Volume
+	TocTexts map[string]map[int32]string    // the TOC texts of the headings by work code and ordinal
+	Counts   map[string]model.ContentCounts // by work code, every work of the volume is contained
+	Warnings []string                       // problems that don't prevent the upload or that have to be fixed before it
+}
+
+// Progress receives the state of an upload while it is processed
+type Progress interface {
+	SetPhase(phase Phase)
+	SetProgress(percent int32)
+	AddWarning(msg string)
+}
+
+// Phase is a step of the processing of an uploaded volume
+type Phase = internal.Phase
+
+// the contents are inserted in batches, so that large volumes don't exceed the request size limit of the database and the progress of the indexing can be reported
+const insertBatchSize = 1000
+
 type uploadProcessorImpl struct {
 	volumeRepo  dataaccess.VolumeRepo
 	contentRepo dataaccess.ContentRepo
+	imageRepo   dataaccess.ImageRepo
 	xmlMapper   internal.XmlMapper
 }
 
-func NewUploadProcessor(volumeRepo dataaccess.VolumeRepo, contentRepo dataaccess.ContentRepo, configPath string) UploadProcessor {
+var imgSrcRegex = regexp.MustCompile(util.ImgSrcMatch)
+
+func NewUploadProcessor(volumeRepo dataaccess.VolumeRepo, contentRepo dataaccess.ContentRepo, imageRepo dataaccess.ImageRepo, configPath string) UploadProcessor {
 	processor := uploadProcessorImpl{
 		volumeRepo:  volumeRepo,
 		contentRepo: contentRepo,
-		xmlMapper:   internal.NewXmlMapper(metadata.NewMetadata(configPath)),
+		imageRepo:   imageRepo,
+		xmlMapper:   internal.NewXmlMapper(metadata.NewMetadata(configPath), metadata.NewPersonAliases(configPath)),
 	}
 	return &processor
 }
 
-func (rec *uploadProcessorImpl) Process(ctx context.Context, volNr int32, xml string) errs.UploadError {
-	volume, contents, err := rec.xmlMapper.MapXml(volNr, xml)
+func (rec *uploadProcessorImpl) Process(ctx context.Context, volNr int32, xml string, progress Progress) errs.UploadError {
+	volume, contents, err := rec.xmlMapper.MapXml(volNr, xml, progress.SetPhase)
 	if err.HasError {
 		return err
 	}
+	progress.SetPhase(internal.Indexing)
+	err = validateImages(ctx, rec.imageRepo, contents)
+	if err.HasError {
+		return err
+	}
+	volume.Version = createVersion(xml)
 	errDelete := deleteExistingData(ctx, rec.volumeRepo, rec.contentRepo, volNr)
 	if errDelete != nil {
 		return errs.New(nil, errDelete)
 	}
 
-	err = insertNewData(ctx, rec.volumeRepo, rec.contentRepo, &volume, contents)
+	err = insertNewData(ctx, rec.volumeRepo, rec.contentRepo, &volume, contents, progress)
 	if err.HasError {
-		deleteExistingData(ctx, rec.volumeRepo, rec.contentRepo, volNr) // ignore the potential delete error, because here the insertion error is the more interesting one
+		if errDelete := deleteExistingData(ctx, rec.volumeRepo, rec.contentRepo, volNr); errDelete != nil {
+			// the insertion error is the more interesting one, so the delete error is only reported as a warning
+			progress.AddWarning(fmt.Sprintf("unable to delete the partially inserted data of volume %d: %v", volNr, errDelete))
+		}
 		return err
 	}
 	return errs.Nil()
 }
 
+func (rec *uploadProcessorImpl) Preview(ctx context.Context, volNr int32, xml string) (Preview, errs.UploadError) {
+	volume, contents, err := rec.xmlMapper.MapXml(volNr, xml, func(Phase) {})
+	if err.HasError {
+		return Preview{}, err
+	}
+	volume.Version = createVersion(xml)
+
+	preview := Preview{
+		Volume:   volume,
+		TocTexts: map[string]map[int32]string{},
+		Counts:   map[string]model.ContentCounts{},
+		Warnings: []string{},
+	}
+	for _, w := range volume.Works {
+		preview.TocTexts[w.Code] = map[int32]string{}
+		preview.Counts[w.Code] = model.ContentCounts{}
+	}
+	for _, c := range contents {
+		preview.Counts[c.WorkCode][c.Type]++
+		if c.Type == model.Heading && c.TocText != nil {
+			preview.TocTexts[c.WorkCode][c.Ordinal] = *c.TocText
+		}
+	}
+	for _, w := range volume.Works {
+		if preview.Counts[w.Code][model.Paragraph] == 0 {
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf("the work '%s' has no paragraphs", w.Code))
+		}
+	}
+
+	// missing images would make the upload fail, but in a preview they are just reported, because they can still be uploaded before the volume
+	err = validateImages(ctx, rec.imageRepo, contents)
+	if err.TechnicalError != nil {
+		return Preview{}, err
+	}
+	if err.DomainError != nil {
+		preview.Warnings = append(preview.Warnings, err.DomainError.Error())
+	}
+	return preview, errs.Nil()
+}
+
+func createVersion(xml string) string {
+	hash := sha256.Sum256([]byte(xml))
+	return hex.EncodeToString(hash[:8])
+}
+
+// validateImages checks that all images referenced by the contents exist, so the images must be uploaded before the volume
+func validateImages(ctx context.Context, imageRepo dataaccess.ImageRepo, contents []model.Content) errs.UploadError {
+	srcs := []string{}
+	for _, c := range contents {
+		for _, match := range imgSrcRegex.FindAllStringSubmatch(c.FmtText, -1) {
+			srcs = append(srcs, match[1])
+		}
+	}
+	slices.Sort(srcs)
+	srcs = slices.Compact(srcs)
+
+	missing := []string{}
+	for _, src := range srcs {
+		ok, err := imageRepo.Exists(ctx, src)
+		if err != nil {
+			return errs.New(nil, err)
+		}
+		if !ok {
+			missing = append(missing, src)
+		}
+	}
+	if len(missing) > 0 {
+		return errs.New(fmt.Errorf("the referenced images %s don't exist, they must be uploaded before the volume", strings.Join(missing, ", ")), nil)
+	}
+	return errs.Nil()
+}
+
 func deleteExistingData(ctx context.Context, volRepo dataaccess.VolumeRepo, contentRepo dataaccess.ContentRepo, volNr int32) error {
 	vol, err := volRepo.GetByVolumeNumber(ctx, volNr)
 	if err != nil {
@@ -70,12 +185,16 @@ func deleteExistingData(ctx context.Context, volRepo dataaccess.VolumeRepo, cont
 	return nil
 }
 
-func insertNewData(ctx context.Context, volRepo dataaccess.VolumeRepo, contentRepo dataaccess.ContentRepo, volume *model.Volume, contents []model.Content) errs.UploadError {
-	err := contentRepo.Insert(ctx, contents)
-	if err != nil {
-		return errs.New(nil, err)
+func insertNewData(ctx context.Context, volRepo dataaccess.VolumeRepo, contentRepo dataaccess.ContentRepo, volume *model.Volume, contents []model.Content, progress Progress) errs.UploadError {
+	for start := 0; start < len(contents); start += insertBatchSize {
+		end := min(start+insertBatchSize, len(contents))
+		err := contentRepo.Insert(ctx, contents[start:end])
+		if err != nil {
+			return errs.New(nil, err)
+		}
+		progress.SetProgress(indexingStart + (100-indexingStart)*int32(end)/int32(len(contents)))
 	}
-	err = volRepo.Insert(ctx, volume)
+	err := volRepo.Insert(ctx, volume)
 	if err != nil {
 		return errs.New(nil, err)
 	}
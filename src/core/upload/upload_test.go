@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/frhorschig/kant-search-backend/common/errs"
+	"github.com/frhorschig/kant-search-backend/core/upload/internal"
 	"github.com/frhorschig/kant-search-backend/core/upload/internal/mocks"
 	dbMocks "github.com/frhorschig/kant-search-backend/dataaccess/mocks"
 	dbmodel "github.com/frhorschig/kant-search-backend/dataaccess/model"
@@ -23,11 +24,13 @@ func TestUploadProcessErrors(t *testing.T) {
 	defer ctrl.Finish()
 	volumeRepo := dbMocks.NewMockVolumeRepo(ctrl)
 	contentRepo := dbMocks.NewMockContentRepo(ctrl)
+	imageRepo := dbMocks.NewMockImageRepo(ctrl)
 	xmlMapper := mocks.NewMockXmlMapper(ctrl)
 
 	sut := &uploadProcessorImpl{
 		volumeRepo:  volumeRepo,
 		contentRepo: contentRepo,
+		imageRepo:   imageRepo,
 		xmlMapper:   xmlMapper,
 	}
 	wCode := "code"
@@ -41,7 +44,7 @@ func TestUploadProcessErrors(t *testing.T) {
 			name: "MapXml fails",
 			mockSetup: func(vr *dbMocks.MockVolumeRepo, cr *dbMocks.MockContentRepo, xm *mocks.MockXmlMapper) {
 				gomock.InOrder(
-					xm.EXPECT().MapXml(gomock.Any(), gomock.Any()).
+					xm.EXPECT().MapXml(gomock.Any(), gomock.Any(), gomock.Any()).
 						Return(dbmodel.Volume{}, nil, errs.New(nil, testErr)),
 				)
 			},
@@ -110,14 +113,229 @@ func TestUploadProcessErrors(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockSetup(volumeRepo, contentRepo, xmlMapper)
-			err := sut.Process(context.Background(), 1, "xml")
+			err := sut.Process(context.Background(), 1, "xml", &testProgress{})
 			assert.True(t, err.HasError)
 		})
 	}
 }
 
+func TestUploadProcess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	volumeRepo := dbMocks.NewMockVolumeRepo(ctrl)
+	contentRepo := dbMocks.NewMockContentRepo(ctrl)
+	imageRepo := dbMocks.NewMockImageRepo(ctrl)
+	xmlMapper := mocks.NewMockXmlMapper(ctrl)
+	sut := &uploadProcessorImpl{
+		volumeRepo:  volumeRepo,
+		contentRepo: contentRepo,
+		imageRepo:   imageRepo,
+		xmlMapper:   xmlMapper,
+	}
+
+	versions := []string{}
+	for _, xml := range []string{"xml", "xml", "changed xml"} {
+		// GIVEN
+		mockXmlMapper(xmlMapper, "code")
+		volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(1)).Return(nil, nil)
+		contentRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(nil)
+		volumeRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, vol *dbmodel.Volume) error {
+			versions = append(versions, vol.Version)
+			return nil
+		})
+		// WHEN
+		err := sut.Process(context.Background(), 1, xml, &testProgress{})
+		// THEN
+		assert.False(t, err.HasError)
+	}
+	assert.Len(t, versions[0], 16)
+	assert.Equal(t, versions[0], versions[1])
+	assert.NotEqual(t, versions[0], versions[2])
+}
+
+func TestUploadProcessImages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	volumeRepo := dbMocks.NewMockVolumeRepo(ctrl)
+	contentRepo := dbMocks.NewMockContentRepo(ctrl)
+	imageRepo := dbMocks.NewMockImageRepo(ctrl)
+	xmlMapper := mocks.NewMockXmlMapper(ctrl)
+	sut := &uploadProcessorImpl{
+		volumeRepo:  volumeRepo,
+		contentRepo: contentRepo,
+		imageRepo:   imageRepo,
+		xmlMapper:   xmlMapper,
+	}
+	contents := []dbmodel.Content{
+		{FmtText: `Text <ks-meta-imgref src="fig1.png" desc="Figur 1"/> and <ks-meta-imgref src="fig2.png" desc="Figur 2"/>`},
+		{FmtText: `<ks-meta-imgref src="fig1.png" desc="Figur 1"/>`},
+	}
+
+	t.Run("Missing image", func(t *testing.T) {
+		// GIVEN
+		xmlMapper.EXPECT().MapXml(gomock.Any(), gomock.Any(), gomock.Any()).Return(dbmodel.Volume{}, contents, errs.Nil())
+		imageRepo.EXPECT().Exists(gomock.Any(), "fig1.png").Return(true, nil)
+		imageRepo.EXPECT().Exists(gomock.Any(), "fig2.png").Return(false, nil)
+		// WHEN
+		err := sut.Process(context.Background(), 1, "xml", &testProgress{})
+		// THEN
+		assert.True(t, err.HasError)
+		assert.NotNil(t, err.DomainError)
+		assert.Contains(t, err.DomainError.Error(), "fig2.png")
+		assert.NotContains(t, err.DomainError.Error(), "fig1.png")
+	})
+
+	t.Run("Existing images", func(t *testing.T) {
+		// GIVEN
+		xmlMapper.EXPECT().MapXml(gomock.Any(), gomock.Any(), gomock.Any()).Return(dbmodel.Volume{}, contents, errs.Nil())
+		imageRepo.EXPECT().Exists(gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+		volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(1)).Return(nil, nil)
+		contentRepo.EXPECT().Insert(gomock.Any(), contents).Return(nil)
+		volumeRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(nil)
+		// WHEN
+		err := sut.Process(context.Background(), 1, "xml", &testProgress{})
+		// THEN
+		assert.False(t, err.HasError)
+	})
+
+	t.Run("Image check fails", func(t *testing.T) {
+		// GIVEN
+		xmlMapper.EXPECT().MapXml(gomock.Any(), gomock.Any(), gomock.Any()).Return(dbmodel.Volume{}, contents, errs.Nil())
+		imageRepo.EXPECT().Exists(gomock.Any(), "fig1.png").Return(false, fmt.Errorf("disk error"))
+		// WHEN
+		err := sut.Process(context.Background(), 1, "xml", &testProgress{})
+		// THEN
+		assert.True(t, err.HasError)
+		assert.NotNil(t, err.TechnicalError)
+	})
+}
+
+func TestUploadProcessProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	volumeRepo := dbMocks.NewMockVolumeRepo(ctrl)
+	contentRepo := dbMocks.NewMockContentRepo(ctrl)
+	imageRepo := dbMocks.NewMockImageRepo(ctrl)
+	xmlMapper := mocks.NewMockXmlMapper(ctrl)
+	sut := &uploadProcessorImpl{
+		volumeRepo:  volumeRepo,
+		contentRepo: contentRepo,
+		imageRepo:   imageRepo,
+		xmlMapper:   xmlMapper,
+	}
+	contents := make([]dbmodel.Content, 1500)
+
+	t.Run("Phases and batched insertion", func(t *testing.T) {
+		// GIVEN
+		xmlMapper.EXPECT().MapXml(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(volNr int32, xml string, onPhase func(Phase)) (dbmodel.Volume, []dbmodel.Content, errs.UploadError) {
+				onPhase(internal.TreeMapping)
+				onPhase(internal.Ordering)
+				return dbmodel.Volume{}, contents, errs.Nil()
+			})
+		volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(1)).Return(nil, nil)
+		gomock.InOrder(
+			contentRepo.EXPECT().Insert(gomock.Any(), contents[:1000]).Return(nil),
+			contentRepo.EXPECT().Insert(gomock.Any(), contents[1000:]).Return(nil),
+			volumeRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(nil),
+		)
+		progress := &testProgress{}
+		// WHEN
+		err := sut.Process(context.Background(), 1, "xml", progress)
+		// THEN
+		assert.False(t, err.HasError)
+		assert.Equal(t, []Phase{internal.TreeMapping, internal.Ordering, internal.Indexing}, progress.phases)
+		assert.Equal(t, []int32{80, 100}, progress.progress)
+		assert.Empty(t, progress.warnings)
+	})
+
+	t.Run("Warning on failed cleanup", func(t *testing.T) {
+		// GIVEN
+		mockXmlMapper(xmlMapper, "code")
+		volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(1)).Return(nil, nil)
+		contentRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(fmt.Errorf("insert error"))
+		volumeRepo.EXPECT().GetByVolumeNumber(gomock.Any(), int32(1)).Return(nil, fmt.Errorf("delete error"))
+		progress := &testProgress{}
+		// WHEN
+		err := sut.Process(context.Background(), 1, "xml", progress)
+		// THEN
+		assert.True(t, err.HasError)
+		assert.Contains(t, err.TechnicalError.Error(), "insert error")
+		assert.Len(t, progress.warnings, 1)
+		assert.Contains(t, progress.warnings[0], "delete error")
+	})
+}
+
+func TestUploadPreview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	volumeRepo := dbMocks.NewMockVolumeRepo(ctrl)
+	contentRepo := dbMocks.NewMockContentRepo(ctrl)
+	imageRepo := dbMocks.NewMockImageRepo(ctrl)
+	xmlMapper := mocks.NewMockXmlMapper(ctrl)
+	sut := &uploadProcessorImpl{
+		volumeRepo:  volumeRepo,
+		contentRepo: contentRepo,
+		imageRepo:   imageRepo,
+		xmlMapper:   xmlMapper,
+	}
+	volume := dbmodel.Volume{VolumeNumber: 1, Works: []dbmodel.Work{{Code: "W1"}, {Code: "W2"}}}
+	tocText := "heading"
+	contents := []dbmodel.Content{
+		{WorkCode: "W1", Type: dbmodel.Heading, Ordinal: 1, TocText: &tocText},
+		{WorkCode: "W1", Type: dbmodel.Paragraph, Ordinal: 2, FmtText: `<ks-meta-imgref src="fig1.png" desc="Figur 1"/>`},
+		{WorkCode: "W1", Type: dbmodel.Paragraph, Ordinal: 3},
+		{WorkCode: "W1", Type: dbmodel.Footnote, Ordinal: 4},
+		{WorkCode: "W2", Type: dbmodel.Heading, Ordinal: 1, TocText: &tocText},
+	}
+
+	t.Run("Preview without storing", func(t *testing.T) {
+		// GIVEN
+		xmlMapper.EXPECT().MapXml(int32(1), "xml", gomock.Any()).Return(volume, contents, errs.Nil())
+		imageRepo.EXPECT().Exists(gomock.Any(), "fig1.png").Return(false, nil)
+		// WHEN
+		preview, err := sut.Preview(context.Background(), 1, "xml")
+		// THEN
+		assert.False(t, err.HasError)
+		assert.Equal(t, int32(1), preview.Volume.VolumeNumber)
+		assert.Len(t, preview.Volume.Version, 16)
+		assert.Equal(t, map[string]dbmodel.ContentCounts{
+			"W1": {dbmodel.Heading: 1, dbmodel.Paragraph: 2, dbmodel.Footnote: 1},
+			"W2": {dbmodel.Heading: 1},
+		}, preview.Counts)
+		assert.Equal(t, map[string]map[int32]string{
+			"W1": {1: "heading"},
+			"W2": {1: "heading"},
+		}, preview.TocTexts)
+		assert.Len(t, preview.Warnings, 2)
+		assert.Contains(t, preview.Warnings[0], "'W2' has no paragraphs")
+		assert.Contains(t, preview.Warnings[1], "fig1.png")
+	})
+
+	t.Run("Mapping error", func(t *testing.T) {
+		// GIVEN
+		xmlMapper.EXPECT().MapXml(gomock.Any(), gomock.Any(), gomock.Any()).Return(dbmodel.Volume{}, nil, errs.New(fmt.Errorf("unknown tag"), nil))
+		// WHEN
+		_, err := sut.Preview(context.Background(), 1, "xml")
+		// THEN
+		assert.True(t, err.HasError)
+		assert.Contains(t, err.DomainError.Error(), "unknown tag")
+	})
+
+	t.Run("Image check fails", func(t *testing.T) {
+		// GIVEN
+		xmlMapper.EXPECT().MapXml(gomock.Any(), gomock.Any(), gomock.Any()).Return(volume, contents, errs.Nil())
+		imageRepo.EXPECT().Exists(gomock.Any(), "fig1.png").Return(false, fmt.Errorf("disk error"))
+		// WHEN
+		_, err := sut.Preview(context.Background(), 1, "xml")
+		// THEN
+		assert.True(t, err.HasError)
+		assert.NotNil(t, err.TechnicalError)
+	})
+}
+
 func mockXmlMapper(mapper *mocks.MockXmlMapper, wCode string) {
-	mapper.EXPECT().MapXml(gomock.Any(), gomock.Any()).Return(
+	mapper.EXPECT().MapXml(gomock.Any(), gomock.Any(), gomock.Any()).Return(
 		dbmodel.Volume{},
 		[]dbmodel.Content{{}},
 		errs.Nil(),
@@ -132,3 +350,21 @@ func mockDeletion(vr *dbMocks.MockVolumeRepo, cr *dbMocks.MockContentRepo, wCode
 	cr.EXPECT().DeleteByWork(gomock.Any(), wCode).Return(nil)
 	vr.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
 }
+
+type testProgress struct {
+	phases   []Phase
+	progress []int32
+	warnings []string
+}
+
+func (rec *testProgress) SetPhase(phase Phase) {
+	rec.phases = append(rec.phases, phase)
+}
+
+func (rec *testProgress) SetProgress(percent int32) {
+	rec.progress = append(rec.progress, percent)
+}
+
+func (rec *testProgress) AddWarning(msg string) {
+	rec.warnings = append(rec.warnings, msg)
+}
@@ -0,0 +1,90 @@
+package dataaccess
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/alignment_repo_mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/create"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/refresh"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+)
+
+type AlignmentRepo interface {
+	Insert(ctx context.Context, data *model.Alignment) error
+	Get(ctx context.Context, workCode string, otherWorkCode string) (*model.Alignment, error)
+}
+
+type alignmentRepoImpl struct {
+	dbClient  *elasticsearch.TypedClient
+	indexName string
+}
+
+func NewAlignmentRepo(dbClient *elasticsearch.TypedClient) AlignmentRepo {
+	repo := &alignmentRepoImpl{
+		dbClient:  dbClient,
+		indexName: "alignments",
+	}
+	err := createAlignmentIndex(repo.dbClient, repo.indexName)
+	if err != nil {
+		panic(err)
+	}
+	return repo
+}
+
+func createAlignmentIndex(es *elasticsearch.TypedClient, name string) error {
+	ctx := context.Background()
+	ok, err := es.Indices.Exists(name).Do(ctx)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+
+	res, err := es.Indices.Create(name).Request(&create.Request{
+		Mappings: model.AlignmentMapping,
+	}).Do(ctx)
+	if err != nil {
+		return err
+	}
+	if !res.Acknowledged {
+		return fmt.Errorf("creation of index '%s' not acknowledged", name)
+	}
+	return err
+}
+
+// Insert replaces an existing alignment of the same works
+func (rec *alignmentRepoImpl) Insert(ctx context.Context, data *model.Alignment) error {
+	_, err := rec.dbClient.Index(rec.indexName).
+		Id(alignmentId(data.WorkCode, data.OtherWorkCode)).
+		Document(data).
+		Refresh(refresh.True).
+		Do(ctx)
+	return err
+}
+
+// Get returns nil if there is no alignment of the works
+func (rec *alignmentRepoImpl) Get(ctx context.Context, workCode string, otherWorkCode string) (*model.Alignment, error) {
+	res, err := rec.dbClient.Get(rec.indexName, alignmentId(workCode, otherWorkCode)).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Found {
+		return nil, nil
+	}
+
+	var alignment model.Alignment
+	err = json.Unmarshal(res.Source_, &alignment)
+	if err != nil {
+		return nil, err
+	}
+	return &alignment, nil
+}
+
+func alignmentId(workCode string, otherWorkCode string) string {
+	return workCode + ":" + otherWorkCode
+}
@@ -0,0 +1,61 @@
+//go:build integration
+// +build integration
+
+package dataaccess
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/frhorschig/kant-search-backend/common/util"
+	"github.com/frhorschig/kant-search-backend/dataaccess/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlignmentRepo(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	repo := NewAlignmentRepo(dbClient)
+	alignment := model.Alignment{
+		WorkCode:      "KRV_A",
+		OtherWorkCode: "KRV_B",
+		Version:       "v1-v1",
+		Pairs: []model.AlignedPair{
+			{Ordinal: util.Int32Ptr(2), OtherOrdinal: util.Int32Ptr(2), Similarity: 0.9},
+			{Ordinal: util.Int32Ptr(3)},
+			{OtherOrdinal: util.Int32Ptr(3)},
+		},
+	}
+
+	// WHEN Get unknown alignment
+	res, err := repo.Get(ctx, "KRV_A", "KRV_B")
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+
+	// WHEN Insert
+	err = repo.Insert(ctx, &alignment)
+	// THEN
+	assert.Nil(t, err)
+	// WHEN Get
+	res, err = repo.Get(ctx, "KRV_A", "KRV_B")
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, alignment, *res)
+	// WHEN Get in reverse order
+	res, err = repo.Get(ctx, "KRV_B", "KRV_A")
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+
+	// WHEN Insert replacement
+	alignment.Version = "v1-v2"
+	alignment.Pairs = alignment.Pairs[:1]
+	err = repo.Insert(ctx, &alignment)
+	// THEN
+	assert.Nil(t, err)
+	res, err = repo.Get(ctx, "KRV_A", "KRV_B")
+	assert.Nil(t, err)
+	assert.Equal(t, alignment, *res)
+}
@@ -1,6 +1,12 @@
 package dataaccess
 
-import "github.com/elastic/go-elasticsearch/v8/typedapi/types"
+import (
+	"context"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/rs/zerolog/log"
+)
 
 func createTermQuery(fieldName string, value any) *types.Query {
 	return &types.Query{
@@ -9,3 +15,15 @@ func createTermQuery(fieldName string, value any) *types.Query {
 		},
 	}
 }
+
+// warnOnOutdatedMapping logs a warning if an existing index was created with an older mapping; such an index must be deleted and all volumes must be uploaded again
+func warnOnOutdatedMapping(es *elasticsearch.TypedClient, name string, isCurrent func(properties map[string]types.Property) bool) {
+	res, err := es.Indices.GetMapping().Index(name).Do(context.Background())
+	if err != nil {
+		log.Warn().Err(err).Msgf("unable to check the mapping of index '%s'", name)
+		return
+	}
+	if !isCurrent(res[name].Mappings.Properties) {
+		log.Warn().Msgf("the mapping of index '%s' is outdated, delete the index and upload all volumes again", name)
+	}
+}
@@ -7,63 +7,113 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"unicode"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/deletebyquery"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/msearch"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/mtermvectors"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/create"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/lowercasetokenfilterlanguages"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/operationtype"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
 	"github.com/frhorschig/kant-search-backend/common/util"
 	"github.com/frhorschig/kant-search-backend/dataaccess/model"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/text/unicode/norm"
 )
 
-const analyzerPrefix = "searchText."
+const (
+	analyzerPrefix         = "searchText."
+	sentenceAnalyzerPrefix = "sentences."
+	foreignAnalyzerPrefix  = "foreignTexts.text."
+)
 
 type ContentRepo interface {
 	Insert(ctx context.Context, data []model.Content) error
-	GetFootnotesByWork(ctx context.Context, workCode string, ordinals []int32) ([]model.Content, error)
-	GetHeadingsByWork(ctx context.Context, workCode string, ordinals []int32) ([]model.Content, error)
-	GetParagraphsByWork(ctx context.Context, workCode string, ordinals []int32) ([]model.Content, error)
-	GetSummariesByWork(ctx context.Context, workCode string, ordinals []int32) ([]model.Content, error)
+	GetFootnotesByWork(ctx context.Context, workCode string, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error)
+	GetHeadingsByWork(ctx context.Context, workCode string, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error)
+	GetParagraphsByWork(ctx context.Context, workCode string, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error)
+	GetSummariesByWork(ctx context.Context, workCode string, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error)
+	GetByWork(ctx context.Context, workCode string, cTypes []model.Type, from *int32, to *int32) ([]model.Content, error)
+	GetByRefs(ctx context.Context, workCode string, cTypes []model.Type, refs []string) ([]model.Content, error)
+	GetByPage(ctx context.Context, workCodes []string, page int32) ([]model.Content, error)
+	GetByOrigPage(ctx context.Context, workCode string, origPage string) ([]model.Content, error)
+	GetPageRange(ctx context.Context, workCodes []string) (*model.PageRange, error)
+	CountByWorks(ctx context.Context, workCodes []string) (map[string]model.ContentCounts, error)
+	GetTermsByWork(ctx context.Context, workCode string, analyzers []model.Analyzer) ([]model.TextTerms, error)
+	GetWithPersons(ctx context.Context) ([]model.Content, error)
 	DeleteByWork(ctx context.Context, workCode string) error
 	Search(ctx context.Context, ast *model.SearchTermNode, options model.SearchOptions) ([]model.SearchResult, error)
+	SearchBatch(ctx context.Context, asts []*model.SearchTermNode, options model.SearchOptions, countOnly bool) ([]model.BatchSearchResult, error)
 }
 
 const resultsSize = 10000
 
+// termVectorsBatchSize is the number of contents whose term vectors are read with a single request
+const termVectorsBatchSize = 500
+
+// MaxPageLimit is the maximum number of contents of a single page
+const MaxPageLimit = resultsSize - 1
+
+const (
+	greekLowercase  = "greekLowercase"
+	greekFolding    = "greekFolding"
+	umlautExpansion = "umlautExpansion"
+)
+
+// FoldingConfig configures the analyzers for folded text. The configuration is only applied when the index is created, so changing it requires a recreation of the index.
+type FoldingConfig struct {
+	UseIcu        bool // use the icu_folding filter instead of asciifolding, requires the analysis-icu plugin
+	ExpandUmlauts bool // replace umlauts by their two-letter spelling (e.g. "ä" -> "ae") instead of removing the diaeresis
+}
+
 type contentRepoImpl struct {
 	dbClient  *elasticsearch.TypedClient
 	indexName string
 }
 
-func NewContentRepo(dbClient *elasticsearch.TypedClient) ContentRepo {
+func NewContentRepo(dbClient *elasticsearch.TypedClient, folding FoldingConfig) ContentRepo {
 	repo := &contentRepoImpl{
 		dbClient:  dbClient,
 		indexName: "contents",
 	}
-	err := createContentIndex(repo.dbClient, repo.indexName)
+	err := createContentIndex(repo.dbClient, repo.indexName, folding)
 	if err != nil {
 		panic(err)
 	}
 	return repo
 }
 
-func createContentIndex(es *elasticsearch.TypedClient, name string) error {
+func createContentIndex(es *elasticsearch.TypedClient, name string, folding FoldingConfig) error {
 	ctx := context.Background()
 	ok, err := es.Indices.Exists(name).Do(ctx)
 	if err != nil {
 		return err
 	}
 	if ok {
+		warnOnOutdatedMapping(es, name, func(properties map[string]types.Property) bool {
+			_, refOk := properties["ref"].(*types.KeywordProperty)
+			_, personsOk := properties["persons"].(*types.KeywordProperty)
+			_, languagesOk := properties["languages"].(*types.KeywordProperty)
+			_, foreignTextsOk := properties["foreignTexts"].(*types.NestedProperty)
+			_, kindOk := properties["kind"].(*types.KeywordProperty)
+			_, origPagesOk := properties["origPages"].(*types.KeywordProperty)
+			_, pagesOk := properties["pages"].(*types.IntegerNumberProperty)
+			searchTextOk := hasSubfields(properties["searchText"], model.FoldedNoStemming, model.FoldedGermanStemming, model.CaseSensitive)
+			sentencesOk := hasSubfields(properties["sentences"], model.NoStemming, model.GermanStemming, model.FoldedNoStemming, model.FoldedGermanStemming, model.CaseSensitive)
+			return refOk && personsOk && languagesOk && foreignTextsOk && kindOk && searchTextOk && sentencesOk && origPagesOk && pagesOk
+		})
 		return nil
 	}
 
 	res, err := es.Indices.Create(name).Request(&create.Request{
 		Mappings: model.ContentMapping,
-		Settings: buildSettings(),
+		Settings: buildSettings(folding),
 	}).Do(ctx)
 	if err != nil {
 		return err
@@ -74,7 +124,30 @@ func createContentIndex(es *elasticsearch.TypedClient, name string) error {
 	return err
 }
 
-func buildSettings() *types.IndexSettings {
+// hasSubfields checks if the property is a text field with a subfield for each of the analyzers
+func hasSubfields(property types.Property, analyzers ...model.Analyzer) bool {
+	text, ok := property.(*types.TextProperty)
+	if !ok {
+		return false
+	}
+	for _, a := range analyzers {
+		if _, ok := text.Fields[string(a)]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func buildSettings(folding FoldingConfig) *types.IndexSettings {
+	foldingFilter := "asciifolding"
+	if folding.UseIcu {
+		foldingFilter = "icu_folding"
+	}
+	charFilters := []string{greekFolding}
+	if folding.ExpandUmlauts {
+		charFilters = append(charFilters, umlautExpansion)
+	}
+
 	return &types.IndexSettings{
 		Analysis: &types.IndexSettingsAnalysis{
 			Analyzer: map[string]types.Analyzer{
@@ -86,17 +159,62 @@ func buildSettings() *types.IndexSettings {
 					Tokenizer: "standard",
 					Filter:    []string{"lowercase", string(model.GermanStemming)},
 				},
+				string(model.FoldedNoStemming): &types.CustomAnalyzer{
+					CharFilter: charFilters,
+					Tokenizer:  "standard",
+					Filter:     []string{greekLowercase, foldingFilter},
+				},
+				string(model.FoldedGermanStemming): &types.CustomAnalyzer{
+					CharFilter: charFilters,
+					Tokenizer:  "standard",
+					Filter:     []string{greekLowercase, foldingFilter, string(model.GermanStemming)},
+				},
+				string(model.CaseSensitive): &types.CustomAnalyzer{
+					Tokenizer: "standard",
+				},
+			},
+			CharFilter: map[string]types.CharFilter{
+				greekFolding: &types.MappingCharFilter{
+					Type:     "mapping",
+					Mappings: greekFoldingMappings(),
+				},
+				umlautExpansion: &types.MappingCharFilter{
+					Type: "mapping",
+					Mappings: []string{
+						"ä => ae", "ö => oe", "ü => ue",
+						"Ä => Ae", "Ö => Oe", "Ü => Ue",
+						"ß => ss",
+					},
+				},
 			},
 			Filter: map[string]types.TokenFilter{
 				string(model.GermanStemming): &types.StemmerTokenFilter{
 					Type:     "stemmer",
 					Language: util.StrPtr("german"),
 				},
+				// the greek lowercase filter also removes the accents of the monotonic greek letters, which are not handled by the asciifolding filter
+				greekLowercase: &types.LowercaseTokenFilter{
+					Type:     "lowercase",
+					Language: &lowercasetokenfilterlanguages.Greek,
+				},
 			},
 		},
 	}
 }
 
+// greekFoldingMappings maps the polytonic greek letters (U+1F00–U+1FFF) to their base letters, because they are neither folded by the asciifolding filter nor by the greek lowercase filter
+func greekFoldingMappings() []string {
+	mappings := []string{}
+	for r := rune(0x1F00); r <= 0x1FFF; r++ {
+		base := []rune(norm.NFD.String(string(r)))[0]
+		if base == r || !unicode.Is(unicode.Greek, base) || !unicode.IsLetter(base) {
+			continue
+		}
+		mappings = append(mappings, fmt.Sprintf("%c => %c", r, base))
+	}
+	return mappings
+}
+
 func (rec *contentRepoImpl) Insert(ctx context.Context, data []model.Content) error {
 	insert := rec.dbClient.Bulk().Index(rec.indexName)
 	for _, c := range data {
@@ -119,59 +237,402 @@ func (rec *contentRepoImpl) Insert(ctx context.Context, data []model.Content) er
 	return nil
 }
 
-func (rec *contentRepoImpl) GetFootnotesByWork(ctx context.Context, workCode string, ordinals []int32) ([]model.Content, error) {
+func (rec *contentRepoImpl) GetFootnotesByWork(ctx context.Context, workCode string, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error) {
 	query := createContentQuery(
 		workCode,
 		[]model.Type{model.Footnote},
 	)
-	return getContents(ctx, query, ordinals, rec.dbClient)
+	return rec.getContentPage(ctx, query, ordinals, cursor, limit)
 }
 
-func (rec *contentRepoImpl) GetHeadingsByWork(ctx context.Context, workCode string, ordinals []int32) ([]model.Content, error) {
+func (rec *contentRepoImpl) GetHeadingsByWork(ctx context.Context, workCode string, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error) {
 	query := createContentQuery(
 		workCode,
 		[]model.Type{model.Heading},
 	)
-	return getContents(ctx, query, ordinals, rec.dbClient)
+	return rec.getContentPage(ctx, query, ordinals, cursor, limit)
 }
 
-func (rec *contentRepoImpl) GetParagraphsByWork(ctx context.Context, workCode string, ordinals []int32) ([]model.Content, error) {
+func (rec *contentRepoImpl) GetParagraphsByWork(ctx context.Context, workCode string, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error) {
 	query := createContentQuery(
 		workCode,
 		[]model.Type{model.Paragraph},
 	)
-	return getContents(ctx, query, ordinals, rec.dbClient)
+	return rec.getContentPage(ctx, query, ordinals, cursor, limit)
 }
 
-func (rec *contentRepoImpl) GetSummariesByWork(ctx context.Context, workCode string, ordinals []int32) ([]model.Content, error) {
+func (rec *contentRepoImpl) GetSummariesByWork(ctx context.Context, workCode string, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error) {
 	query := createContentQuery(
 		workCode,
 		[]model.Type{model.Summary},
 	)
-	return getContents(ctx, query, ordinals, rec.dbClient)
+	return rec.getContentPage(ctx, query, ordinals, cursor, limit)
 }
 
-func getContents(ctx context.Context, contentQuery *types.Query, ordinals []int32, dbClient *elasticsearch.TypedClient) ([]model.Content, error) {
+// getContentPage returns at most limit contents sorted by their ordinal, starting after the cursor ordinal if it is given
+func (rec *contentRepoImpl) getContentPage(ctx context.Context, query *types.Query, ordinals []model.OrdinalRange, cursor *int32, limit int) (*model.ContentPage, error) {
+	if limit < 1 || limit > MaxPageLimit {
+		return nil, fmt.Errorf("invalid limit %d, must be between 1 and %d", limit, MaxPageLimit)
+	}
 	if len(ordinals) > 0 {
-		contentQuery.Bool.Filter = append(
-			contentQuery.Bool.Filter,
-			createOrdinalQuery(ordinals),
-		)
+		query.Bool.Filter = append(query.Bool.Filter, createOrdinalQuery(ordinals))
+	}
+	// one more content than requested is fetched to find out if there is a next page
+	contents, err := rec.getSortedContents(ctx, query, cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.ContentPage{Contents: contents}
+	if len(contents) > limit {
+		page.Contents = contents[:limit]
+		page.NextCursor = util.Int32Ptr(page.Contents[limit-1].Ordinal)
 	}
-	res, err := dbClient.Search().
+	return page, nil
+}
+
+// GetByWork returns all contents of the given types sorted by their ordinal; from and to are optional inclusive ordinal bounds
+func (rec *contentRepoImpl) GetByWork(ctx context.Context, workCode string, cTypes []model.Type, from *int32, to *int32) ([]model.Content, error) {
+	query := createContentQuery(workCode, cTypes)
+	if from != nil || to != nil {
+		query.Bool.Filter = append(query.Bool.Filter, createOrdinalQuery([]model.OrdinalRange{{From: from, To: to}}))
+	}
+	return rec.getAllSortedContents(ctx, query)
+}
+
+// GetByRefs returns the contents of the given types that have one of the given refs, sorted by their ordinal
+func (rec *contentRepoImpl) GetByRefs(ctx context.Context, workCode string, cTypes []model.Type, refs []string) ([]model.Content, error) {
+	if len(refs) == 0 {
+		return []model.Content{}, nil
+	}
+	query := createContentQuery(workCode, cTypes)
+	query.Bool.Filter = append(query.Bool.Filter, types.Query{Terms: &types.TermsQuery{
+		TermsQuery: map[string]types.TermsQueryField{
+			"ref": refs,
+		},
+	}})
+	return rec.getAllSortedContents(ctx, query)
+}
+
+func (rec *contentRepoImpl) getAllSortedContents(ctx context.Context, query *types.Query) ([]model.Content, error) {
+	result := []model.Content{}
+	var cursor *int32
+	for {
+		contents, err := rec.getSortedContents(ctx, query, cursor, resultsSize)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, contents...)
+		if len(contents) < resultsSize {
+			return result, nil
+		}
+		cursor = util.Int32Ptr(contents[len(contents)-1].Ordinal)
+	}
+}
+
+// getSortedContents expects a query that is restricted to a single work, because the ordinals are only unique within a work
+func (rec *contentRepoImpl) getSortedContents(ctx context.Context, query *types.Query, cursor *int32, size int) ([]model.Content, error) {
+	request := &search.Request{
+		Query: query,
+		Sort:  createSortOptions(),
+		Size:  util.IntPtr(size),
+	}
+	if cursor != nil {
+		request.SearchAfter = []types.FieldValue{*cursor}
+	}
+	res, err := rec.dbClient.Search().Index(rec.indexName).
+		AllowPartialSearchResults(false).
+		Request(request).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalContents(res.Hits.Hits)
+}
+
+// GetByPage returns the contents of all types that are (partially) on the given page, sorted by their ordinal
+func (rec *contentRepoImpl) GetByPage(ctx context.Context, workCodes []string, page int32) ([]model.Content, error) {
+	res, err := rec.dbClient.Search().Index(rec.indexName).
 		AllowPartialSearchResults(false).
 		Request(&search.Request{
-			Query: contentQuery,
-			Size:  util.IntPtr(resultsSize),
+			Query: &types.Query{
+				Bool: &types.BoolQuery{
+					Filter: []types.Query{
+						createWorkCodesQuery(workCodes),
+						*createTermQuery("pages", page),
+					},
+				},
+			},
+			Sort: createSortOptions(),
+			Size: util.IntPtr(resultsSize),
 		}).Do(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return unmarshalContents(res.Hits.Hits)
+}
 
+// GetByOrigPage returns the contents of all types in which the given page of the original edition starts, sorted by their ordinal
+func (rec *contentRepoImpl) GetByOrigPage(ctx context.Context, workCode string, origPage string) ([]model.Content, error) {
+	res, err := rec.dbClient.Search().Index(rec.indexName).
+		AllowPartialSearchResults(false).
+		Request(&search.Request{
+			Query: &types.Query{
+				Bool: &types.BoolQuery{
+					Filter: []types.Query{
+						*createTermQuery("workCode", workCode),
+						*createTermQuery("origPages", origPage),
+					},
+				},
+			},
+			Sort: createSortOptions(),
+			Size: util.IntPtr(resultsSize),
+		}).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalContents(res.Hits.Hits)
+}
+
+// GetPageRange returns the first and the last page of the contents of the given works, or nil if there are no contents
+func (rec *contentRepoImpl) GetPageRange(ctx context.Context, workCodes []string) (*model.PageRange, error) {
+	res, err := rec.dbClient.Search().Index(rec.indexName).
+		AllowPartialSearchResults(false).
+		TypedKeys(true).
+		Request(&search.Request{
+			Query: &types.Query{
+				Bool: &types.BoolQuery{
+					Filter: []types.Query{createWorkCodesQuery(workCodes)},
+				},
+			},
+			Aggregations: map[string]types.Aggregations{
+				"first": {Min: &types.MinAggregation{Field: util.StrPtr("pages")}},
+				"last":  {Max: &types.MaxAggregation{Field: util.StrPtr("pages")}},
+			},
+			Size: util.IntPtr(0),
+		}).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	first, ok := res.Aggregations["first"].(*types.MinAggregate)
+	if !ok {
+		return nil, errors.New("missing aggregation of the first page")
+	}
+	last, ok := res.Aggregations["last"].(*types.MaxAggregate)
+	if !ok {
+		return nil, errors.New("missing aggregation of the last page")
+	}
+	if first.Value == nil || last.Value == nil {
+		return nil, nil
+	}
+	return &model.PageRange{
+		First: int32(*first.Value),
+		Last:  int32(*last.Value),
+	}, nil
+}
+
+// CountByWorks returns the number of contents of each type by work code; works without contents are missing in the result
+func (rec *contentRepoImpl) CountByWorks(ctx context.Context, workCodes []string) (map[string]model.ContentCounts, error) {
+	if len(workCodes) == 0 {
+		return map[string]model.ContentCounts{}, nil
+	}
+	res, err := rec.dbClient.Search().Index(rec.indexName).
+		AllowPartialSearchResults(false).
+		TypedKeys(true).
+		Request(&search.Request{
+			Query: &types.Query{
+				Bool: &types.BoolQuery{
+					Filter: []types.Query{createWorkCodesQuery(workCodes)},
+				},
+			},
+			Aggregations: map[string]types.Aggregations{
+				"works": {
+					Terms: &types.TermsAggregation{Field: util.StrPtr("workCode"), Size: util.IntPtr(len(workCodes))},
+					Aggregations: map[string]types.Aggregations{
+						"types": {Terms: &types.TermsAggregation{Field: util.StrPtr("type")}},
+					},
+				},
+			},
+			Size: util.IntPtr(0),
+		}).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	works, ok := res.Aggregations["works"].(*types.StringTermsAggregate)
+	if !ok {
+		return nil, errors.New("missing aggregation of the works")
+	}
+	workBuckets, ok := works.Buckets.([]types.StringTermsBucket)
+	if !ok {
+		return nil, errors.New("unexpected format of the work buckets")
+	}
+	counts := make(map[string]model.ContentCounts)
+	for _, wb := range workBuckets {
+		cTypes, ok := wb.Aggregations["types"].(*types.StringTermsAggregate)
+		if !ok {
+			return nil, errors.New("missing aggregation of the content types")
+		}
+		typeBuckets, ok := cTypes.Buckets.([]types.StringTermsBucket)
+		if !ok {
+			return nil, errors.New("unexpected format of the content type buckets")
+		}
+		workCounts := model.ContentCounts{}
+		for _, tb := range typeBuckets {
+			workCounts[model.Type(fmt.Sprint(tb.Key))] = int32(tb.DocCount)
+		}
+		counts[fmt.Sprint(wb.Key)] = workCounts
+	}
+	return counts, nil
+}
+
+// GetTermsByWork returns the terms of the search texts of all contents of the work, sorted by the ordinal of the contents; the terms are read from the term vectors of the fields of the given analyzers
+func (rec *contentRepoImpl) GetTermsByWork(ctx context.Context, workCode string, analyzers []model.Analyzer) ([]model.TextTerms, error) {
+	ids, err := rec.getIdsByWork(ctx, workCode)
+	if err != nil {
+		return nil, err
+	}
+	fields := []string{}
+	for _, a := range analyzers {
+		fields = append(fields, analyzerPrefix+string(a))
+	}
+
+	result := []model.TextTerms{}
+	for start := 0; start < len(ids); start += termVectorsBatchSize {
+		end := min(start+termVectorsBatchSize, len(ids))
+		res, err := rec.dbClient.Mtermvectors().Index(rec.indexName).
+			Fields(fields...).
+			Positions(true).
+			Offsets(false).
+			Payloads(false).
+			TermStatistics(false).
+			FieldStatistics(false).
+			Request(&mtermvectors.Request{Ids: ids[start:end]}).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range res.Docs {
+			if doc.Error != nil {
+				return nil, fmt.Errorf("unable to read the term vectors of content %s: %s", util.StrVal(doc.Id_), util.StrVal(doc.Error.Reason))
+			}
+			terms := model.TextTerms{}
+			for _, a := range analyzers {
+				terms[a] = sortTermsByPosition(doc.TermVectors[analyzerPrefix+string(a)])
+			}
+			result = append(result, terms)
+		}
+	}
+	return result, nil
+}
+
+func (rec *contentRepoImpl) getIdsByWork(ctx context.Context, workCode string) ([]string, error) {
+	ids := []string{}
+	var cursor *int32
+	for {
+		request := &search.Request{
+			Query: &types.Query{
+				Bool: &types.BoolQuery{
+					Filter: []types.Query{createWorkCodeQuery(workCode)},
+				},
+			},
+			Sort:    createSortOptions(),
+			Size:    util.IntPtr(resultsSize),
+			Source_: types.SourceFilter{Includes: []string{"ordinal"}},
+		}
+		if cursor != nil {
+			request.SearchAfter = []types.FieldValue{*cursor}
+		}
+		res, err := rec.dbClient.Search().Index(rec.indexName).
+			AllowPartialSearchResults(false).
+			Request(request).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var c model.Content
+		for _, hit := range res.Hits.Hits {
+			err := json.Unmarshal(hit.Source_, &c)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, util.StrVal(hit.Id_))
+		}
+		if len(res.Hits.Hits) < resultsSize {
+			return ids, nil
+		}
+		cursor = util.Int32Ptr(c.Ordinal)
+	}
+}
+
+// GetWithPersons returns the contents of all works that name at least one person, sorted by their work code and ordinal; only the work code, type, ordinal and persons of the contents are read
+func (rec *contentRepoImpl) GetWithPersons(ctx context.Context) ([]model.Content, error) {
+	result := []model.Content{}
+	var cursor []types.FieldValue
+	for {
+		request := &search.Request{
+			Query: &types.Query{
+				Bool: &types.BoolQuery{
+					Filter: []types.Query{{Exists: &types.ExistsQuery{Field: "persons"}}},
+				},
+			},
+			Sort: []types.SortCombinations{
+				types.SortOptions{
+					SortOptions: map[string]types.FieldSort{
+						"workCode": {Order: &sortorder.Asc},
+					},
+				},
+				types.SortOptions{
+					SortOptions: map[string]types.FieldSort{
+						"ordinal": {Order: &sortorder.Asc},
+					},
+				},
+			},
+			Size:        util.IntPtr(resultsSize),
+			Source_:     types.SourceFilter{Includes: []string{"workCode", "type", "ordinal", "persons"}},
+			SearchAfter: cursor,
+		}
+		res, err := rec.dbClient.Search().Index(rec.indexName).
+			AllowPartialSearchResults(false).
+			Request(request).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		contents, err := unmarshalContents(res.Hits.Hits)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, contents...)
+		if len(contents) < resultsSize {
+			return result, nil
+		}
+		last := contents[len(contents)-1]
+		cursor = []types.FieldValue{last.WorkCode, last.Ordinal}
+	}
+}
+
+// sortTermsByPosition returns the terms of a term vector in the order of their positions; positions without a term (e.g. of removed stop words) are empty strings
+func sortTermsByPosition(tv types.TermVector) []string {
+	length := 0
+	for _, term := range tv.Terms {
+		for _, token := range term.Tokens {
+			length = max(length, token.Position+1)
+		}
+	}
+	terms := make([]string, length)
+	for text, term := range tv.Terms {
+		for _, token := range term.Tokens {
+			terms[token.Position] = text
+		}
+	}
+	return terms
+}
+
+func unmarshalContents(hits []types.Hit) ([]model.Content, error) {
 	contents := []model.Content{}
-	for _, hit := range res.Hits.Hits {
+	for _, hit := range hits {
 		var c model.Content
-		err = json.Unmarshal(hit.Source_, &c)
+		err := json.Unmarshal(hit.Source_, &c)
 		if err != nil {
 			return nil, err
 		}
@@ -204,30 +665,16 @@ func (rec *contentRepoImpl) DeleteByWork(ctx context.Context, workCode string) e
 }
 
 func (rec *contentRepoImpl) Search(ctx context.Context, ast *model.SearchTermNode, options model.SearchOptions) ([]model.SearchResult, error) {
-	analyzer := model.NoStemming
-	if options.WithStemming {
-		analyzer = model.GermanStemming
-	}
-	searchQuery, err := createSearchQuery(ast, analyzer)
+	query, analyzer, err := createFullSearchQuery(ast, options)
 	if err != nil {
 		return nil, err
 	}
-	if searchQuery == nil {
-		// empty search term (== nil searchQueries) is catched in api layer, so if this is the case, the error is technical, not a user error
-		return nil, errors.New("search AST must not be nil")
-	}
-	optionQueries := createOptionQueries(options)
 
 	res, err := rec.dbClient.Search().Index(rec.indexName).
 		AllowPartialSearchResults(false).
 		Request(
 			&search.Request{
-				Query: &types.Query{
-					Bool: &types.BoolQuery{
-						Must:   []types.Query{*searchQuery},
-						Filter: optionQueries,
-					},
-				},
+				Query:     query,
 				Sort:      createSortOptions(),
 				Highlight: createHighlightOptions(analyzer),
 				Size:      util.IntPtr(10000),
@@ -235,11 +682,125 @@ func (rec *contentRepoImpl) Search(ctx context.Context, ast *model.SearchTermNod
 	if err != nil {
 		return nil, err
 	}
+	return mapSearchHits(res.Hits.Hits, analyzer)
+}
+
+func (rec *contentRepoImpl) SearchBatch(ctx context.Context, asts []*model.SearchTermNode, options model.SearchOptions, countOnly bool) ([]model.BatchSearchResult, error) {
+	if len(asts) == 0 {
+		return []model.BatchSearchResult{}, nil
+	}
+
+	// the results of all queries share the size of a single search, so that a batch doesn't return more hits than a single search
+	size := max(resultsSize/len(asts), 1)
+	req := msearch.Request{}
+	var analyzer model.Analyzer
+	for _, ast := range asts {
+		query, a, err := createFullSearchQuery(ast, options)
+		if err != nil {
+			return nil, err
+		}
+		analyzer = a // all queries share the same options and therefore the same analyzer
+		body := types.MultisearchBody{
+			Query:          query,
+			TrackTotalHits: true,
+		}
+		if countOnly {
+			body.Size = util.IntPtr(0)
+		} else {
+			body.Sort = createSortOptions()
+			body.Highlight = createHighlightOptions(analyzer)
+			body.Size = util.IntPtr(size)
+		}
+		req = append(req,
+			types.MultisearchHeader{AllowPartialSearchResults: util.FalsePtr()},
+			body,
+		)
+	}
+
+	res, err := rec.dbClient.Msearch().Index(rec.indexName).Request(&req).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Responses) != len(asts) {
+		return nil, fmt.Errorf("expected %d msearch responses, but got %d", len(asts), len(res.Responses))
+	}
+
+	results := []model.BatchSearchResult{}
+	for _, item := range res.Responses {
+		switch r := item.(type) {
+		case *types.MultiSearchItem:
+			result := model.BatchSearchResult{Results: []model.SearchResult{}}
+			if r.Hits.Total != nil {
+				result.Count = int32(r.Hits.Total.Value)
+			}
+			if !countOnly {
+				hits, err := mapSearchHits(r.Hits.Hits, analyzer)
+				if err != nil {
+					return nil, err
+				}
+				result.Results = hits
+				result.Truncated = int32(len(hits)) < result.Count
+			}
+			results = append(results, result)
+		case *types.ErrorResponseBase:
+			// a failed query (e.g. because it has too many clauses) doesn't fail the other queries of the batch
+			reason := ""
+			if r.Error.Reason != nil {
+				reason = *r.Error.Reason
+			}
+			results = append(results, model.BatchSearchResult{
+				Results: []model.SearchResult{},
+				Error:   fmt.Errorf("msearch item failed with status %d: %s", r.Status, reason),
+			})
+		default:
+			return nil, fmt.Errorf("unknown msearch response item type %T", item)
+		}
+	}
+	return results, nil
+}
+
+func createFullSearchQuery(ast *model.SearchTermNode, options model.SearchOptions) (*types.Query, model.Analyzer, error) {
+	analyzer := selectAnalyzer(options)
+	cfg := searchConfig{analyzer: analyzer}
+	if options.WithinLanguages {
+		cfg.languages = options.Languages
+	}
+	searchQuery, err := createSearchQuery(ast, cfg)
+	if err != nil {
+		return nil, "", err
+	}
+	if searchQuery == nil {
+		// empty search term (== nil searchQueries) is catched in api layer, so if this is the case, the error is technical, not a user error
+		return nil, "", errors.New("search AST must not be nil")
+	}
+	return &types.Query{
+		Bool: &types.BoolQuery{
+			Must:   []types.Query{*searchQuery},
+			Filter: createOptionQueries(options),
+		},
+	}, analyzer, nil
+}
+
+func selectAnalyzer(options model.SearchOptions) model.Analyzer {
+	switch {
+	case options.CaseSensitive:
+		return model.CaseSensitive
+	case options.Folded && options.WithStemming:
+		return model.FoldedGermanStemming
+	case options.Folded:
+		return model.FoldedNoStemming
+	case options.WithStemming:
+		return model.GermanStemming
+	default:
+		return model.NoStemming
+	}
+}
 
+func mapSearchHits(hits []types.Hit, analyzer model.Analyzer) ([]model.SearchResult, error) {
 	results := []model.SearchResult{}
-	for _, hit := range res.Hits.Hits {
+	for _, hit := range hits {
 		var c model.Content
-		err = json.Unmarshal(hit.Source_, &c)
+		err := json.Unmarshal(hit.Source_, &c)
 		if err != nil {
 			return nil, err
 		}
@@ -284,24 +845,33 @@ func createContentQuery(workCode string, cType []model.Type) *types.Query {
 	}
 }
 
-func createSearchQuery(node *model.SearchTermNode, analyzer model.Analyzer) (*types.Query, error) {
+// searchConfig contains the options that affect the queries of the search terms
+type searchConfig struct {
+	analyzer  model.Analyzer
+	languages []string // if not empty, words and phrases only match in the foreign language passages of these languages
+}
+
+func createSearchQuery(node *model.SearchTermNode, cfg searchConfig) (*types.Query, error) {
 	if node == nil {
 		return nil, nil
 	}
 	if node.Token.IsAnd {
-		return createAndQuery(node, analyzer)
+		return createAndQuery(node, cfg)
 	}
 	if node.Token.IsOr {
-		return createOrQuery(node, analyzer)
+		return createOrQuery(node, cfg)
 	}
 	if node.Token.IsNot {
-		return createNotQuery(node, analyzer)
+		return createNotQuery(node, cfg)
+	}
+	if node.Token.IsSameSentence {
+		return createSameSentenceQuery(node, cfg)
 	}
-	if node.Token.IsWord {
-		return createTextMatchQuery(node.Token.Text, analyzer), nil
+	if node.Token.IsWord || node.Token.IsPhrase {
+		return createOperandQuery(node.Token, cfg), nil
 	}
-	if node.Token.IsPhrase {
-		return createPhraseQuery(node.Token.Text, analyzer), nil
+	if node.Token.IsPerson {
+		return createPersonQuery(node.Token.Text), nil
 	}
 	return nil, errors.New("invalid token type")
 }
@@ -336,26 +906,70 @@ func createTypeQuery(cType []model.Type) types.Query {
 	}}
 }
 
-func createOrdinalQuery(ordinals []int32) types.Query {
-	values := make([]interface{}, len(ordinals))
-	for i, v := range ordinals {
-		values[i] = v
+// createOrdinalQuery matches ordinals in any of the ranges; overlapping and adjacent ranges are merged to keep the number of range queries low
+func createOrdinalQuery(ordinals []model.OrdinalRange) types.Query {
+	queries := []types.Query{}
+	for _, r := range mergeOrdinalRanges(ordinals) {
+		rangeQuery := types.NumberRangeQuery{}
+		if r.From != nil {
+			gte := types.Float64(*r.From)
+			rangeQuery.Gte = &gte
+		}
+		if r.To != nil {
+			lte := types.Float64(*r.To)
+			rangeQuery.Lte = &lte
+		}
+		queries = append(queries, types.Query{
+			Range: map[string]types.RangeQuery{
+				"ordinal": rangeQuery,
+			},
+		})
 	}
 	return types.Query{
-		Terms: &types.TermsQuery{
-			TermsQuery: map[string]types.TermsQueryField{
-				"ordinal": values,
-			},
+		Bool: &types.BoolQuery{
+			Should:             queries,
+			MinimumShouldMatch: 1,
 		},
 	}
 }
 
-func createAndQuery(node *model.SearchTermNode, analyzer model.Analyzer) (*types.Query, error) {
-	q1, err := createSearchQuery(node.Left, analyzer)
+func mergeOrdinalRanges(ordinals []model.OrdinalRange) []model.OrdinalRange {
+	sorted := slices.Clone(ordinals)
+	slices.SortFunc(sorted, func(a, b model.OrdinalRange) int {
+		return int(lowerBound(a)) - int(lowerBound(b))
+	})
+	merged := []model.OrdinalRange{}
+	for _, r := range sorted {
+		if len(merged) > 0 {
+			last := &merged[len(merged)-1]
+			if last.To == nil {
+				break // the last range is open, so it contains all following ranges
+			}
+			if int64(lowerBound(r)) <= int64(*last.To)+1 {
+				if r.To == nil || *r.To > *last.To {
+					last.To = r.To
+				}
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+func lowerBound(r model.OrdinalRange) int32 {
+	if r.From == nil {
+		return math.MinInt32
+	}
+	return *r.From
+}
+
+func createAndQuery(node *model.SearchTermNode, cfg searchConfig) (*types.Query, error) {
+	q1, err := createSearchQuery(node.Left, cfg)
 	if err != nil {
 		return nil, err
 	}
-	q2, err := createSearchQuery(node.Right, analyzer)
+	q2, err := createSearchQuery(node.Right, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -367,12 +981,12 @@ func createAndQuery(node *model.SearchTermNode, analyzer model.Analyzer) (*types
 	}}, nil
 }
 
-func createOrQuery(node *model.SearchTermNode, analyzer model.Analyzer) (*types.Query, error) {
-	q1, err := createSearchQuery(node.Left, analyzer)
+func createOrQuery(node *model.SearchTermNode, cfg searchConfig) (*types.Query, error) {
+	q1, err := createSearchQuery(node.Left, cfg)
 	if err != nil {
 		return nil, err
 	}
-	q2, err := createSearchQuery(node.Right, analyzer)
+	q2, err := createSearchQuery(node.Right, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -384,13 +998,13 @@ func createOrQuery(node *model.SearchTermNode, analyzer model.Analyzer) (*types.
 	}}, nil
 }
 
-func createNotQuery(node *model.SearchTermNode, analyzer model.Analyzer) (*types.Query, error) {
-	q1, err := createSearchQuery(node.Left, analyzer)
+func createNotQuery(node *model.SearchTermNode, cfg searchConfig) (*types.Query, error) {
+	q1, err := createSearchQuery(node.Left, cfg)
 	if err != nil {
 		return nil, err
 	}
 	if q1 == nil {
-		q2, err := createSearchQuery(node.Right, analyzer)
+		q2, err := createSearchQuery(node.Right, cfg)
 		if err != nil {
 			return nil, err
 		}
@@ -406,18 +1020,124 @@ func createNotQuery(node *model.SearchTermNode, analyzer model.Analyzer) (*types
 	}}, nil
 }
 
-func createPhraseQuery(phrase string, analyzer model.Analyzer) *types.Query {
+func createSameSentenceQuery(node *model.SearchTermNode, cfg searchConfig) (*types.Query, error) {
+	operands, err := collectSameSentenceOperands(node)
+	if err != nil {
+		return nil, err
+	}
+	intervals := []types.Intervals{}
+	highlightQueries := []types.Query{}
+	for _, op := range operands {
+		match := &types.IntervalsMatch{Query: op.Text}
+		if op.IsPhrase {
+			match.MaxGaps = util.IntPtr(0)
+			match.Ordered = util.TruePtr()
+		}
+		intervals = append(intervals, types.Intervals{Match: match})
+		highlightQueries = append(highlightQueries, *createTokenQuery(op, analyzerPrefix+string(cfg.analyzer)))
+	}
+	field := sentenceAnalyzerPrefix + string(cfg.analyzer)
+	if len(cfg.languages) > 0 {
+		// a foreign language passage is a single value, so the operands must occur in the same passage
+		field = foreignAnalyzerPrefix + string(cfg.analyzer)
+	}
+	query := types.Query{
+		Intervals: map[string]types.IntervalsQuery{
+			field: {
+				AllOf: &types.IntervalsAllOf{
+					Intervals: intervals,
+					MaxGaps:   util.IntPtr(model.SentenceGap - 1),
+					Ordered:   util.FalsePtr(),
+				},
+			},
+		},
+	}
+	if len(cfg.languages) > 0 {
+		return createForeignQuery(query, highlightQueries, cfg.languages), nil
+	}
+	// the should clauses don't affect which documents match, but they add the operands to the highlighting, which is done on the searchText field and not on the sentences field
+	return &types.Query{Bool: &types.BoolQuery{
+		Must:   []types.Query{query},
+		Should: highlightQueries,
+	}}, nil
+}
+
+func collectSameSentenceOperands(node *model.SearchTermNode) ([]*model.Token, error) {
+	if node == nil {
+		return nil, errors.New("same sentence nodes must have both a left and a right child")
+	}
+	if node.Token.IsWord || node.Token.IsPhrase {
+		return []*model.Token{node.Token}, nil
+	}
+	if !node.Token.IsSameSentence {
+		return nil, errors.New("the operands of same sentence nodes must be words or phrases")
+	}
+	left, err := collectSameSentenceOperands(node.Left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := collectSameSentenceOperands(node.Right)
+	if err != nil {
+		return nil, err
+	}
+	return append(left, right...), nil
+}
+
+func createOperandQuery(token *model.Token, cfg searchConfig) *types.Query {
+	query := createTokenQuery(token, analyzerPrefix+string(cfg.analyzer))
+	if len(cfg.languages) == 0 {
+		return query
+	}
+	return createForeignQuery(*createTokenQuery(token, foreignAnalyzerPrefix+string(cfg.analyzer)), []types.Query{*query}, cfg.languages)
+}
+
+// createForeignQuery matches the query against the foreign language passages of the languages; like in same sentence queries, the should clauses only add the highlighting of the searchText field
+func createForeignQuery(query types.Query, highlightQueries []types.Query, languages []string) *types.Query {
+	return &types.Query{Bool: &types.BoolQuery{
+		Must: []types.Query{{
+			Nested: &types.NestedQuery{
+				Path: "foreignTexts",
+				Query: &types.Query{Bool: &types.BoolQuery{
+					Must: []types.Query{query},
+					Filter: []types.Query{{Terms: &types.TermsQuery{
+						TermsQuery: map[string]types.TermsQueryField{
+							"foreignTexts.lang": languages,
+						},
+					}}},
+				}},
+			},
+		}},
+		Should: highlightQueries,
+	}}
+}
+
+func createTokenQuery(token *model.Token, field string) *types.Query {
+	if token.IsPhrase {
+		return createPhraseQuery(token.Text, field)
+	}
+	return createTextMatchQuery(token.Text, field)
+}
+
+func createPhraseQuery(phrase string, field string) *types.Query {
 	return &types.Query{
 		MatchPhrase: map[string]types.MatchPhraseQuery{
-			analyzerPrefix + string(analyzer): {Query: phrase},
+			field: {Query: phrase},
 		},
 	}
 }
 
-func createTextMatchQuery(term string, analyzer model.Analyzer) *types.Query {
+func createTextMatchQuery(term string, field string) *types.Query {
 	return &types.Query{
 		Match: map[string]types.MatchQuery{
-			analyzerPrefix + string(analyzer): {Query: term},
+			field: {Query: term},
+		},
+	}
+}
+
+func createPersonQuery(name string) *types.Query {
+	return &types.Query{
+		Term: map[string]types.TermQuery{
+			"persons": {Value: name, CaseInsensitive: util.TruePtr()},
 		},
 	}
 }
@@ -433,10 +1153,25 @@ func createOptionQueries(opts model.SearchOptions) []types.Query {
 	if opts.IncludeFootnotes {
 		tps = append(tps, model.Footnote)
 	}
-	return []types.Query{
+	queries := []types.Query{
 		createWorkCodesQuery(opts.WorkCodes),
 		createTypeQuery(tps),
 	}
+	if len(opts.Languages) > 0 {
+		queries = append(queries, types.Query{Terms: &types.TermsQuery{
+			TermsQuery: map[string]types.TermsQueryField{
+				"languages": opts.Languages,
+			},
+		}})
+	}
+	if len(opts.Kinds) > 0 {
+		queries = append(queries, types.Query{Terms: &types.TermsQuery{
+			TermsQuery: map[string]types.TermsQueryField{
+				"kind": opts.Kinds,
+			},
+		}})
+	}
+	return queries
 }
 
 func createWorkCodesQuery(workCodes []string) types.Query {
@@ -16,7 +16,7 @@ import (
 func TestContentInsertGetDelete(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
-	sut := NewContentRepo(dbClient)
+	sut := NewContentRepo(dbClient, FoldingConfig{})
 
 	workCode := "work123"
 	contents := []model.Content{
@@ -44,6 +44,7 @@ func TestContentInsertGetDelete(t *testing.T) {
 			FmtText:    "formatted text 3",
 			SearchText: "search text 3",
 			Pages:      []int32{4, 5},
+			OrigPages:  []string{"XVI", "7"},
 			FnRefs:     []string{"fn3.4", "fn4.5"},
 			WorkCode:   workCode,
 		},
@@ -54,6 +55,7 @@ func TestContentInsertGetDelete(t *testing.T) {
 			SearchText: "search text 4",
 			Pages:      []int32{4, 5},
 			FnRefs:     []string{"fn3.4", "fn4.5"},
+			Persons:    []string{"Hume", "Leibniz"},
 			WorkCode:   workCode,
 		},
 		{
@@ -74,38 +76,140 @@ func TestContentInsertGetDelete(t *testing.T) {
 	refreshContents(t)
 
 	// WHEN Get footnote
-	fns, err := sut.GetFootnotesByWork(ctx, workCode, []int32{})
+	fns, err := sut.GetFootnotesByWork(ctx, workCode, []model.OrdinalRange{}, nil, MaxPageLimit)
 	// THEN
 	assert.Nil(t, err)
-	assert.Len(t, fns, 1)
-	assert.Equal(t, contents[0].SearchText, fns[0].SearchText)
+	assert.Len(t, fns.Contents, 1)
+	assert.Equal(t, contents[0].SearchText, fns.Contents[0].SearchText)
+	assert.Nil(t, fns.NextCursor)
 	// WHEN Get heading
-	heads, err := sut.GetHeadingsByWork(ctx, workCode, []int32{})
+	heads, err := sut.GetHeadingsByWork(ctx, workCode, []model.OrdinalRange{}, nil, MaxPageLimit)
 	// THEN
 	assert.Nil(t, err)
-	assert.Len(t, heads, 1)
-	assert.Equal(t, contents[1].SearchText, heads[0].SearchText)
+	assert.Len(t, heads.Contents, 1)
+	assert.Equal(t, contents[1].SearchText, heads.Contents[0].SearchText)
 	// WHEN Get paragraphs
-	pars, err := sut.GetParagraphsByWork(ctx, workCode, []int32{})
+	parPage, err := sut.GetParagraphsByWork(ctx, workCode, []model.OrdinalRange{}, nil, MaxPageLimit)
 	// THEN
 	assert.Nil(t, err)
-	assert.Len(t, heads, 1)
-	assert.ElementsMatch(t,
-		[]string{contents[2].SearchText, contents[3].SearchText},
-		[]string{pars[0].SearchText, pars[1].SearchText},
-	)
+	assert.Len(t, parPage.Contents, 2)
+	assert.Equal(t, contents[2].SearchText, parPage.Contents[0].SearchText)
+	assert.Equal(t, contents[3].SearchText, parPage.Contents[1].SearchText)
+	// WHEN Get first page of paragraphs
+	parPage, err = sut.GetParagraphsByWork(ctx, workCode, []model.OrdinalRange{}, nil, 1)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, parPage.Contents, 1)
+	assert.Equal(t, contents[2].SearchText, parPage.Contents[0].SearchText)
+	assert.Equal(t, util.Int32Ptr(3), parPage.NextCursor)
+	// WHEN Get next page of paragraphs
+	parPage, err = sut.GetParagraphsByWork(ctx, workCode, []model.OrdinalRange{}, parPage.NextCursor, 1)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, parPage.Contents, 1)
+	assert.Equal(t, contents[3].SearchText, parPage.Contents[0].SearchText)
+	assert.Nil(t, parPage.NextCursor)
+	// WHEN Get paragraphs with invalid limit
+	parPage, err = sut.GetParagraphsByWork(ctx, workCode, []model.OrdinalRange{}, nil, 0)
+	// THEN
+	assert.NotNil(t, err)
+	assert.Nil(t, parPage)
 	// WHEN Get single paragraph
-	pars, err = sut.GetParagraphsByWork(ctx, workCode, []int32{4})
+	parPage, err = sut.GetParagraphsByWork(ctx, workCode, []model.OrdinalRange{{From: util.Int32Ptr(4), To: util.Int32Ptr(4)}}, nil, MaxPageLimit)
 	// THEN
 	assert.Nil(t, err)
-	assert.Len(t, pars, 1)
-	assert.Equal(t, contents[3].SearchText, pars[0].SearchText)
+	assert.Len(t, parPage.Contents, 1)
+	assert.Equal(t, contents[3].SearchText, parPage.Contents[0].SearchText)
+	// WHEN Get paragraphs by overlapping and open ranges
+	parPage, err = sut.GetParagraphsByWork(ctx, workCode, []model.OrdinalRange{
+		{From: util.Int32Ptr(1), To: util.Int32Ptr(3)},
+		{From: nil, To: util.Int32Ptr(2)},
+	}, nil, MaxPageLimit)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, parPage.Contents, 1)
+	assert.Equal(t, contents[2].SearchText, parPage.Contents[0].SearchText)
+	// WHEN Get paragraphs by open range
+	parPage, err = sut.GetParagraphsByWork(ctx, workCode, []model.OrdinalRange{{From: util.Int32Ptr(4), To: nil}}, nil, MaxPageLimit)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, parPage.Contents, 1)
+	assert.Equal(t, contents[3].SearchText, parPage.Contents[0].SearchText)
 	// WHEN Get summary
-	summ, err := sut.GetSummariesByWork(ctx, workCode, []int32{})
+	summ, err := sut.GetSummariesByWork(ctx, workCode, []model.OrdinalRange{}, nil, MaxPageLimit)
 	// THEN
 	assert.Nil(t, err)
-	assert.Len(t, summ, 1)
-	assert.Equal(t, contents[4].SearchText, summ[0].SearchText)
+	assert.Len(t, summ.Contents, 1)
+	assert.Equal(t, contents[4].SearchText, summ.Contents[0].SearchText)
+	// WHEN Get by work
+	all, err := sut.GetByWork(ctx, workCode, []model.Type{model.Heading, model.Paragraph, model.Footnote, model.Summary}, nil, nil)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, all, 5)
+	for i := range all {
+		assert.Equal(t, int32(i+1), all[i].Ordinal)
+	}
+	// WHEN Get by work with type and range
+	from, to := int32(2), int32(3)
+	pars, err := sut.GetByWork(ctx, workCode, []model.Type{model.Paragraph}, &from, &to)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, pars, 1)
+	assert.Equal(t, contents[2].SearchText, pars[0].SearchText)
+	// WHEN Get by refs
+	byRefs, err := sut.GetByRefs(ctx, workCode, []model.Type{model.Footnote, model.Summary}, []string{"A121", "A125", "A999"})
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, byRefs, 2)
+	assert.Equal(t, contents[0].SearchText, byRefs[0].SearchText)
+	assert.Equal(t, contents[4].SearchText, byRefs[1].SearchText)
+	// WHEN Get by refs with type filter
+	byRefs, err = sut.GetByRefs(ctx, workCode, []model.Type{model.Footnote}, []string{"A121", "A125"})
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, byRefs, 1)
+	assert.Equal(t, contents[0].SearchText, byRefs[0].SearchText)
+	// WHEN Get by page
+	byPage, err := sut.GetByPage(ctx, []string{workCode}, 2)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, byPage, 2)
+	assert.Equal(t, contents[0].SearchText, byPage[0].SearchText)
+	assert.Equal(t, contents[1].SearchText, byPage[1].SearchText)
+	// WHEN Get by original page
+	byOrigPage, err := sut.GetByOrigPage(ctx, workCode, "7")
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, byOrigPage, 1)
+	assert.Equal(t, contents[2].SearchText, byOrigPage[0].SearchText)
+	// WHEN Get page range
+	pageRange, err := sut.GetPageRange(ctx, []string{workCode})
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, &model.PageRange{First: 1, Last: 5}, pageRange)
+	// WHEN Count by works
+	counts, err := sut.CountByWorks(ctx, []string{workCode, "otherWork"})
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]model.ContentCounts{
+		workCode: {model.Footnote: 1, model.Heading: 1, model.Paragraph: 2, model.Summary: 1},
+	}, counts)
+	// WHEN Get terms by work
+	terms, err := sut.GetTermsByWork(ctx, workCode, []model.Analyzer{model.NoStemming, model.GermanStemming})
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, terms, 5)
+	assert.Equal(t, []string{"search", "text", "1"}, terms[0][model.NoStemming])
+	assert.Len(t, terms[0][model.GermanStemming], 3)
+	// WHEN Get with persons
+	persons, err := sut.GetWithPersons(ctx)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, persons, 1)
+	assert.Equal(t, int32(4), persons[0].Ordinal)
+	assert.Equal(t, model.Paragraph, persons[0].Type)
+	assert.Equal(t, []string{"Hume", "Leibniz"}, persons[0].Persons)
+	assert.Empty(t, persons[0].SearchText)
 
 	// WHEN Delete
 	err = sut.DeleteByWork(ctx, workCode)
@@ -114,31 +218,41 @@ func TestContentInsertGetDelete(t *testing.T) {
 	refreshContents(t)
 
 	// WHEN Get footnote
-	fns, err = sut.GetFootnotesByWork(ctx, workCode, []int32{})
+	fns, err = sut.GetFootnotesByWork(ctx, workCode, []model.OrdinalRange{}, nil, MaxPageLimit)
 	// THEN
 	assert.Nil(t, err)
-	assert.Len(t, fns, 0)
+	assert.Len(t, fns.Contents, 0)
 	// WHEN Get heading
-	heads, err = sut.GetHeadingsByWork(ctx, workCode, []int32{})
+	heads, err = sut.GetHeadingsByWork(ctx, workCode, []model.OrdinalRange{}, nil, MaxPageLimit)
 	// THEN
 	assert.Nil(t, err)
-	assert.Len(t, heads, 0)
+	assert.Len(t, heads.Contents, 0)
 	// WHEN Get paragraphs
-	pars, err = sut.GetParagraphsByWork(ctx, workCode, []int32{})
+	parPage, err = sut.GetParagraphsByWork(ctx, workCode, []model.OrdinalRange{}, nil, MaxPageLimit)
 	// THEN
 	assert.Nil(t, err)
-	assert.Len(t, heads, 0)
+	assert.Len(t, parPage.Contents, 0)
 	// WHEN Get summary
-	summ, err = sut.GetSummariesByWork(ctx, workCode, []int32{})
+	summ, err = sut.GetSummariesByWork(ctx, workCode, []model.OrdinalRange{}, nil, MaxPageLimit)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, summ.Contents, 0)
+	// WHEN Get page range
+	pageRange, err = sut.GetPageRange(ctx, []string{workCode})
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, pageRange)
+	// WHEN Count by works
+	counts, err = sut.CountByWorks(ctx, []string{workCode})
 	// THEN
 	assert.Nil(t, err)
-	assert.Len(t, summ, 0)
+	assert.Empty(t, counts)
 }
 
 func TestSearch(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
-	sut := NewContentRepo(dbClient)
+	sut := NewContentRepo(dbClient, FoldingConfig{})
 
 	workCode := "work123"
 	workCode2 := "456work"
@@ -226,6 +340,181 @@ func TestSearch(t *testing.T) {
 			},
 			hitCount: 1,
 		},
+		{
+			name: "test folded option",
+			dbInput: []model.Content{
+				{Type: model.Paragraph, SearchText: "ſchön", WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "schon", WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "λόγος", WorkCode: workCode},
+			},
+			searchTerms: &model.SearchTermNode{Token: newOr(), Left: &model.SearchTermNode{Token: newWord("schon")}, Right: &model.SearchTermNode{Token: newWord("λογος")}},
+			options: model.SearchOptions{
+				WorkCodes:         []string{workCode},
+				IncludeParagraphs: true,
+				Folded:            true,
+			},
+			hitCount: 3,
+		},
+		{
+			name: "test folded option with polytonic greek",
+			dbInput: []model.Content{
+				{Type: model.Paragraph, SearchText: "ἐπιστήμη", WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "Ψυχῇ", WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "ψυχρός", WorkCode: workCode},
+			},
+			searchTerms: &model.SearchTermNode{Token: newOr(), Left: &model.SearchTermNode{Token: newWord("επιστημη")}, Right: &model.SearchTermNode{Token: newWord("ψυχη")}},
+			options: model.SearchOptions{
+				WorkCodes:         []string{workCode},
+				IncludeParagraphs: true,
+				Folded:            true,
+			},
+			hitCount: 2,
+		},
+		{
+			name: "test not folded option",
+			dbInput: []model.Content{
+				{Type: model.Paragraph, SearchText: "ſchön", WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "schon", WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "λόγος", WorkCode: workCode},
+			},
+			searchTerms: &model.SearchTermNode{Token: newOr(), Left: &model.SearchTermNode{Token: newWord("schon")}, Right: &model.SearchTermNode{Token: newWord("λογος")}},
+			options: model.SearchOptions{
+				WorkCodes:         []string{workCode},
+				IncludeParagraphs: true,
+			},
+			hitCount: 1,
+		},
+		{
+			name: "test case sensitive option",
+			dbInput: []model.Content{
+				{Type: model.Paragraph, SearchText: "Das Sein", WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "das muss sein", WorkCode: workCode},
+			},
+			searchTerms: &model.SearchTermNode{Token: newWord("Sein")},
+			options: model.SearchOptions{
+				WorkCodes:         []string{workCode},
+				IncludeParagraphs: true,
+				CaseSensitive:     true,
+			},
+			hitCount: 1,
+		},
+		{
+			name: "test case sensitive option with phrase",
+			dbInput: []model.Content{
+				{Type: model.Paragraph, SearchText: "Das Ich denkt", WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "ich denke", WorkCode: workCode},
+			},
+			searchTerms: &model.SearchTermNode{Token: newPhrase("Ich denkt")},
+			options: model.SearchOptions{
+				WorkCodes:         []string{workCode},
+				IncludeParagraphs: true,
+				CaseSensitive:     true,
+			},
+			hitCount: 1,
+		},
+		{
+			name: "test same sentence",
+			dbInput: []model.Content{
+				{Type: model.Paragraph, SearchText: "The dog barks. The cat sleeps.", Sentences: []string{"The dog barks.", "The cat sleeps."}, WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "The dog sees the cat. It barks.", Sentences: []string{"The dog sees the cat.", "It barks."}, WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "A cat and a dog.", Sentences: []string{"A cat and a dog."}, WorkCode: workCode},
+			},
+			searchTerms: &model.SearchTermNode{ // dog /s cat
+				Token: newSameSentence(),
+				Left:  &model.SearchTermNode{Token: newWord("dog")},
+				Right: &model.SearchTermNode{Token: newWord("cat")},
+			},
+			options: model.SearchOptions{
+				WorkCodes:         []string{workCode},
+				IncludeParagraphs: true,
+			},
+			hitCount: 2,
+		},
+		{
+			name: "test same sentence with phrase and chain",
+			dbInput: []model.Content{
+				{Type: model.Paragraph, SearchText: "The dog sees the black cat. It barks.", Sentences: []string{"The dog sees the black cat.", "It barks."}, WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "The dog barks at the black cat.", Sentences: []string{"The dog barks at the black cat."}, WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "The black dog barks at the cat.", Sentences: []string{"The black dog barks at the cat."}, WorkCode: workCode},
+			},
+			searchTerms: &model.SearchTermNode{ // dog /s "black cat" /s barks
+				Token: newSameSentence(),
+				Left: &model.SearchTermNode{
+					Token: newSameSentence(),
+					Left:  &model.SearchTermNode{Token: newWord("dog")},
+					Right: &model.SearchTermNode{Token: newPhrase("black cat")},
+				},
+				Right: &model.SearchTermNode{Token: newWord("barks")},
+			},
+			options: model.SearchOptions{
+				WorkCodes:         []string{workCode},
+				IncludeParagraphs: true,
+			},
+			hitCount: 1,
+		},
+		{
+			name: "test person filter",
+			dbInput: []model.Content{
+				{Type: model.Paragraph, SearchText: "monade text", Persons: []string{"Leibniz"}, WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "monade text", Persons: []string{"Hume"}, WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "other text", Persons: []string{"Leibniz"}, WorkCode: workCode},
+			},
+			searchTerms: &model.SearchTermNode{
+				Token: newAnd(),
+				Left:  &model.SearchTermNode{Token: newPerson("leibniz")},
+				Right: &model.SearchTermNode{Token: newWord("monade")},
+			},
+			options: model.SearchOptions{
+				WorkCodes:         []string{workCode},
+				IncludeParagraphs: true,
+			},
+			hitCount: 1,
+		},
+		{
+			name: "test languages filter",
+			dbInput: []model.Content{
+				{Type: model.Paragraph, SearchText: "ratio text", Languages: []string{"lat"}, ForeignTexts: []model.ForeignText{{Lang: "lat", Text: "ratio"}}, WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "ratio text", WorkCode: workCode},
+			},
+			searchTerms: &model.SearchTermNode{Token: newWord("text")},
+			options: model.SearchOptions{
+				WorkCodes:         []string{workCode},
+				IncludeParagraphs: true,
+				Languages:         []string{"lat", "gr"},
+			},
+			hitCount: 1,
+		},
+		{
+			name: "test within languages",
+			dbInput: []model.Content{
+				{Type: model.Paragraph, SearchText: "ratio vernunft", Languages: []string{"lat"}, ForeignTexts: []model.ForeignText{{Lang: "lat", Text: "ratio"}}, WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "ratio vernunft", Languages: []string{"fr"}, ForeignTexts: []model.ForeignText{{Lang: "fr", Text: "ratio"}}, WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "vernunft ratio", Languages: []string{"lat"}, ForeignTexts: []model.ForeignText{{Lang: "lat", Text: "vernunft"}}, WorkCode: workCode},
+			},
+			searchTerms: &model.SearchTermNode{Token: newWord("ratio")},
+			options: model.SearchOptions{
+				WorkCodes:         []string{workCode},
+				IncludeParagraphs: true,
+				Languages:         []string{"lat"},
+				WithinLanguages:   true,
+			},
+			hitCount: 1,
+		},
+		{
+			name: "test kinds filter",
+			dbInput: []model.Content{
+				{Type: model.Paragraph, SearchText: "brief text", Kind: kindPtr(model.LetterKind), WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "reflexion text", Kind: kindPtr(model.ReflectionKind), WorkCode: workCode},
+				{Type: model.Paragraph, SearchText: "werk text", WorkCode: workCode},
+			},
+			searchTerms: &model.SearchTermNode{Token: newWord("text")},
+			options: model.SearchOptions{
+				WorkCodes:         []string{workCode},
+				IncludeParagraphs: true,
+				Kinds:             []model.Kind{model.LetterKind},
+			},
+			hitCount: 1,
+		},
 		{
 			name: "test only !word term",
 			dbInput: []model.Content{
@@ -264,6 +553,56 @@ func TestSearch(t *testing.T) {
 	}
 }
 
+func TestSearchBatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	sut := NewContentRepo(dbClient, FoldingConfig{})
+
+	workCode := "work123"
+	err := sut.Insert(ctx, []model.Content{
+		{Type: model.Paragraph, Ordinal: 1, SearchText: "dog night bird", WorkCode: workCode},
+		{Type: model.Paragraph, Ordinal: 2, SearchText: "cat night bird", WorkCode: workCode},
+		{Type: model.Paragraph, Ordinal: 3, SearchText: "dog mouse", WorkCode: workCode},
+	})
+	if err != nil {
+		t.Fatal("content insertion failure")
+	}
+	refreshContents(t)
+	asts := []*model.SearchTermNode{
+		{Token: newWord("dog")},
+		{Token: newPhrase("night bird")},
+		{Token: newWord("horse")},
+	}
+	options := model.SearchOptions{WorkCodes: []string{workCode}, IncludeParagraphs: true}
+
+	// WHEN
+	result, err := sut.SearchBatch(ctx, asts, options, false)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, result, 3)
+	assert.Equal(t, int32(2), result[0].Count)
+	assert.Len(t, result[0].Results, 2)
+	assert.False(t, result[0].Truncated)
+	assert.Equal(t, int32(2), result[1].Count)
+	assert.Len(t, result[1].Results, 2)
+	assert.Equal(t, int32(0), result[2].Count)
+	assert.Len(t, result[2].Results, 0)
+
+	// WHEN count only
+	result, err = sut.SearchBatch(ctx, asts, options, true)
+	// THEN
+	assert.Nil(t, err)
+	assert.Len(t, result, 3)
+	assert.Equal(t, int32(2), result[0].Count)
+	assert.Len(t, result[0].Results, 0)
+	assert.False(t, result[0].Truncated)
+
+	err = sut.DeleteByWork(ctx, workCode)
+	if err != nil {
+		t.Fatal("content deletion failure")
+	}
+}
+
 func refreshContents(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -280,9 +619,18 @@ func newOr() *model.Token {
 func newNot() *model.Token {
 	return &model.Token{IsNot: true, Text: "!"}
 }
+func newSameSentence() *model.Token {
+	return &model.Token{IsSameSentence: true, Text: "/s"}
+}
 func newWord(text string) *model.Token {
 	return &model.Token{IsWord: true, Text: text}
 }
 func newPhrase(text string) *model.Token {
 	return &model.Token{IsPhrase: true, Text: text}
 }
+func newPerson(text string) *model.Token {
+	return &model.Token{IsPerson: true, Text: text}
+}
+func kindPtr(kind model.Kind) *model.Kind {
+	return &kind
+}
@@ -0,0 +1,89 @@
+package dataaccess
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/image_repo_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ImageRepo stores the images that are referenced by the texts as files in a directory; the image names are file names without a path
+type ImageRepo interface {
+	Insert(ctx context.Context, name string, data []byte) error
+	Get(ctx context.Context, name string) ([]byte, error)
+	Exists(ctx context.Context, name string) (bool, error)
+}
+
+type imageRepoImpl struct {
+	dir string
+}
+
+func NewImageRepo(dir string) ImageRepo {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		panic(err)
+	}
+	return &imageRepoImpl{dir: dir}
+}
+
+func (rec *imageRepoImpl) Insert(ctx context.Context, name string, data []byte) error {
+	path, err := rec.path(name)
+	if err != nil {
+		return err
+	}
+	// write to a temporary file first, so that a concurrent read never returns a partially written image
+	tmp, err := os.CreateTemp(rec.dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get returns nil if the image doesn't exist
+func (rec *imageRepoImpl) Get(ctx context.Context, name string) ([]byte, error) {
+	path, err := rec.path(name)
+	if err != nil {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+func (rec *imageRepoImpl) Exists(ctx context.Context, name string) (bool, error) {
+	path, err := rec.path(name)
+	if err != nil {
+		return false, nil
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return info.Mode().IsRegular(), nil
+}
+
+// path returns an error if the name would point to a file outside of the image directory
+func (rec *imageRepoImpl) path(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || strings.ContainsAny(name, `/\`) || name[0] == '.' {
+		return "", fmt.Errorf("invalid image name '%s'", name)
+	}
+	return filepath.Join(rec.dir, name), nil
+}
@@ -0,0 +1,76 @@
+//go:build unit
+// +build unit
+
+package dataaccess
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageInsertGet(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "images")
+	sut := NewImageRepo(dir)
+
+	// WHEN Get unknown image
+	data, err := sut.Get(ctx, "fig.png")
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, data)
+	// WHEN Exists unknown image
+	ok, err := sut.Exists(ctx, "fig.png")
+	// THEN
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	// WHEN Insert
+	err = sut.Insert(ctx, "fig.png", []byte("image"))
+	// THEN
+	assert.Nil(t, err)
+	// WHEN Get
+	data, err = sut.Get(ctx, "fig.png")
+	// THEN
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("image"), data)
+	// WHEN Exists
+	ok, err = sut.Exists(ctx, "fig.png")
+	// THEN
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	// WHEN Insert replacement
+	err = sut.Insert(ctx, "fig.png", []byte("new image"))
+	// THEN
+	assert.Nil(t, err)
+	data, _ = sut.Get(ctx, "fig.png")
+	assert.Equal(t, []byte("new image"), data)
+	entries, _ := os.ReadDir(dir)
+	assert.Len(t, entries, 1)
+}
+
+func TestImageInvalidNames(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	sut := NewImageRepo(filepath.Join(dir, "images"))
+	os.WriteFile(filepath.Join(dir, "secret.png"), []byte("secret"), 0644)
+
+	for _, name := range []string{"", "../secret.png", "sub/fig.png", `sub\fig.png`, ".hidden.png", "/", ".."} {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			err := sut.Insert(ctx, name, []byte("image"))
+			data, getErr := sut.Get(ctx, name)
+			ok, existsErr := sut.Exists(ctx, name)
+			// THEN
+			assert.NotNil(t, err)
+			assert.Nil(t, getErr)
+			assert.Nil(t, data)
+			assert.Nil(t, existsErr)
+			assert.False(t, ok)
+		})
+	}
+}
@@ -7,12 +7,14 @@ type SearchTermNode struct {
 }
 
 type Token struct {
-	IsAnd    bool
-	IsOr     bool
-	IsNot    bool
-	IsWord   bool
-	IsPhrase bool
-	Text     string
+	IsAnd          bool
+	IsOr           bool
+	IsNot          bool
+	IsSameSentence bool // the left and right child are words or phrases that must occur in the same sentence
+	IsWord         bool
+	IsPhrase       bool
+	IsPerson       bool // the text is the normalized name of a person named in the content
+	Text           string
 }
 
 type SearchOptions struct {
@@ -20,7 +22,12 @@ type SearchOptions struct {
 	IncludeParagraphs bool
 	IncludeFootnotes  bool
 	WithStemming      bool
+	Folded            bool // search in the accent, ligature and (optionally) umlaut folded text
+	CaseSensitive     bool // takes precedence over WithStemming and Folded
 	WorkCodes         []string
+	Languages         []string // only contents with foreign language passages of these languages
+	WithinLanguages   bool     // words and phrases must occur in the passages of Languages
+	Kinds             []Kind   // only headings and paragraphs of letters or Reflexionen
 }
 
 type SearchResult struct {
@@ -34,7 +41,43 @@ type SearchResult struct {
 	WordIndexMap  map[int32]int32
 }
 
+type BatchSearchResult struct {
+	Count     int32
+	Results   []SearchResult // empty if only the count is requested; all results of a batch are limited to the size of a single search
+	Truncated bool           // true if Results contains fewer hits than Count, because of the limited size
+	Error     error          // not nil if the query failed, the other queries of the batch are not affected
+}
+
+// OrdinalRange is an inclusive range of ordinals, a nil bound means that the range is open on this side
+type OrdinalRange struct {
+	From *int32
+	To   *int32
+}
+
+// ContentPage is a page of contents sorted by their ordinal; NextCursor is the ordinal after which the next page starts, or nil if this is the last page
+type ContentPage struct {
+	Contents   []Content
+	NextCursor *int32
+}
+
+// ContentCounts is the number of contents of each type
+type ContentCounts map[Type]int32
+
+// TextTerms are the terms of the words of a text by analyzer; the index of a term is the position of its word in the text
+type TextTerms map[Analyzer][]string
+
+type PageRange struct {
+	First int32
+	Last  int32
+}
+
 type IndexNumberPair struct {
 	I   int32
 	Num int32
 }
+
+// IndexPagePair is the index of the start of a page of an original edition; the page is a string, because the prefaces have roman page numbers
+type IndexPagePair struct {
+	I    int32
+	Page string
+}
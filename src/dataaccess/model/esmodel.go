@@ -2,6 +2,7 @@ package model
 
 import (
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/dynamicmapping"
 	"github.com/frhorschig/kant-search-backend/common/util"
 )
 
@@ -9,6 +10,7 @@ import (
 type Volume struct {
 	VolumeNumber int32  `json:"volumeNumber"`
 	Title        string `json:"title"`
+	Version      string `json:"version"` // hash of the uploaded XML, changes with every upload of a changed text
 	Works        []Work `json:"works"`
 }
 
@@ -23,16 +25,39 @@ type Work struct {
 }
 
 type Section struct {
-	Heading    int32     `json:"heading"`
-	Paragraphs []int32   `json:"paragraphs"`
-	Sections   []Section `json:"sections"`
+	Heading    int32       `json:"heading"`
+	Paragraphs []int32     `json:"paragraphs"`
+	Sections   []Section   `json:"sections"`
+	Letter     *Letter     `json:"letter,omitempty"`
+	Reflection *Reflection `json:"reflection,omitempty"`
+}
+
+// Letter is the metadata of a letter of the correspondence volumes
+type Letter struct {
+	Number    string `json:"number"`
+	Sender    string `json:"sender"`
+	Recipient string `json:"recipient"`
+	Date      string `json:"date"`
+}
+
+// Reflection is the metadata of a note ("Reflexion") of the handwritten remains
+type Reflection struct {
+	Number string `json:"number"`
+	Dating string `json:"dating"`
 }
 
 var VolumeMapping = &types.TypeMapping{
 	Properties: map[string]types.Property{
 		"volumeNumber": types.NewIntegerNumberProperty(),
 		"title":        &types.TextProperty{Index: util.FalsePtr()},
-		"works":        &types.ObjectProperty{Enabled: util.FalsePtr()},
+		"version":      &types.KeywordProperty{Index: util.FalsePtr()},
+		// only the work codes are indexed, the rest of the works (e.g. the section tree) is just stored
+		"works": &types.ObjectProperty{
+			Dynamic: &dynamicmapping.False,
+			Properties: map[string]types.Property{
+				"code": types.NewKeywordProperty(),
+			},
+		},
 	},
 }
 
@@ -46,35 +71,63 @@ const (
 	Summary   Type = "summary"
 )
 
+// Kind is the kind of text a heading or paragraph belongs to, if it isn't part of a regular work
+type Kind string
+
+const (
+	LetterKind     Kind = "letter"
+	ReflectionKind Kind = "reflection"
+)
+
 type Analyzer string
 
 const (
-	NoStemming     Analyzer = "noStemming"
-	GermanStemming Analyzer = "germanStemming"
+	NoStemming           Analyzer = "noStemming"
+	GermanStemming       Analyzer = "germanStemming"
+	FoldedNoStemming     Analyzer = "foldedNoStemming"
+	FoldedGermanStemming Analyzer = "foldedGermanStemming"
+	CaseSensitive        Analyzer = "caseSensitive"
 )
 
+// SentenceGap is the number of positions between two sentences in the sentences field; it must be greater than the number of words of the longest sentence, so that an intervals query with less gaps can never span two sentences.
+const SentenceGap = 1000
+
 // PageByIndex is a map of FmtText string indices (rune, not byte indices) of the start of ks-meta-page tags to the page number inside the tag. This field is used to determine the page where a search hit starts.
 // LineByIndex is a map of FmtText string indices (rune, not byte indices) of the start of ks-meta-line tags to the line number inside the tag. This fields is used to determine the line where a search hit starts.
+// OrigPageByIndex is a list of FmtText string indices (rune, not byte indices) where a page of the original edition starts, e.g. "132" of "KrV B 132". This field is used to resolve citations of the original editions.
 // WordIndexMap is a map of SearchString string indices of the words of the text to FmtText string indices (both rune, not byte indices) of the same words. For example, the [k, v] pair [28, 847] would mean that the word at index 28 of SearchText is the same word as the one at index 847 in FmtText. This field is used to map ES search hit highlights, which are added to SearchText, to FmtText.
 type Content struct {
 	// text data
-	FmtText    string  `json:"fmtText"`
-	TocText    *string `json:"tocText"` // only for headings
-	SearchText string  `json:"searchText"`
+	FmtText    string   `json:"fmtText"`
+	TocText    *string  `json:"tocText"` // only for headings
+	SearchText string   `json:"searchText"`
+	Sentences  []string `json:"sentences"` // SearchText split into sentences
 
 	// sort and filter fields
 	Type     Type   `json:"type"`
+	Kind     *Kind  `json:"kind"` // only for the headings and paragraphs of letters and Reflexionen
 	Ordinal  int32  `json:"ordinal"`
 	WorkCode string `json:"workCode"`
 
 	// metadata
-	Pages        []int32           `json:"pages"`
-	PageByIndex  []IndexNumberPair `json:"pageByIndex"`
-	LineByIndex  []IndexNumberPair `json:"lineByIndex"`
-	WordIndexMap map[int32]int32   `json:"wordIndexMap"`
-	FnRefs       []string          `json:"fnRefs"`     // not for footnotes
-	SummaryRef   *string           `json:"summaryRef"` // only for paragraphs
-	Ref          *string           `json:"ref"`        // for fns and summaries
+	Pages           []int32           `json:"pages"`
+	PageByIndex     []IndexNumberPair `json:"pageByIndex"`
+	LineByIndex     []IndexNumberPair `json:"lineByIndex"`
+	OrigPageByIndex []IndexPagePair   `json:"origPageByIndex"`
+	OrigPages       []string          `json:"origPages"` // the pages of OrigPageByIndex, for filtering
+	WordIndexMap    map[int32]int32   `json:"wordIndexMap"`
+	FnRefs          []string          `json:"fnRefs"`     // not for footnotes
+	SummaryRef      *string           `json:"summaryRef"` // only for paragraphs
+	Ref             *string           `json:"ref"`        // for fns and summaries
+	Persons         []string          `json:"persons"`    // normalized names of the persons named in the text
+	Languages       []string          `json:"languages"`  // languages of the foreign language passages
+	ForeignTexts    []ForeignText     `json:"foreignTexts"`
+}
+
+// ForeignText is a foreign language passage of a text, e.g. a Latin quote
+type ForeignText struct {
+	Lang string `json:"lang"`
+	Text string `json:"text"`
 }
 
 var ContentMapping = &types.TypeMapping{
@@ -82,26 +135,70 @@ var ContentMapping = &types.TypeMapping{
 		"fmtText": &types.TextProperty{Index: util.FalsePtr()},
 		"tocText": &types.TextProperty{Index: util.FalsePtr()},
 		"searchText": types.TextProperty{
-			Fields: map[string]types.Property{
-				string(NoStemming): &types.TextProperty{
-					Analyzer: util.StrPtr(string(NoStemming)),
-				},
-				string(GermanStemming): &types.TextProperty{
-					Analyzer: util.StrPtr(string(GermanStemming)),
-				},
-			},
+			Fields: analyzerFields(nil),
+		},
+		"sentences": types.TextProperty{
+			PositionIncrementGap: util.IntPtr(SentenceGap),
+			Fields:               analyzerFields(util.IntPtr(SentenceGap)),
 		},
 
-		"ref":      &types.TextProperty{Index: util.FalsePtr()},
+		"ref":      types.NewKeywordProperty(),
 		"workCode": types.NewKeywordProperty(),
 		"type":     types.NewKeywordProperty(),
+		"kind":     types.NewKeywordProperty(),
 		"ordinal":  types.NewIntegerNumberProperty(),
 
-		"pages":        &types.TextProperty{Index: util.FalsePtr()},
-		"pageByIndex":  &types.ObjectProperty{Enabled: util.FalsePtr()},
-		"lineByIndex":  &types.ObjectProperty{Enabled: util.FalsePtr()},
-		"wordIndexMap": &types.ObjectProperty{Enabled: util.FalsePtr()},
-		"fnRefs":       &types.TextProperty{Index: util.FalsePtr()},
-		"summaryRef":   &types.TextProperty{Index: util.FalsePtr()},
+		"pages":           types.NewIntegerNumberProperty(),
+		"pageByIndex":     &types.ObjectProperty{Enabled: util.FalsePtr()},
+		"lineByIndex":     &types.ObjectProperty{Enabled: util.FalsePtr()},
+		"origPageByIndex": &types.ObjectProperty{Enabled: util.FalsePtr()},
+		"origPages":       types.NewKeywordProperty(),
+		"wordIndexMap":    &types.ObjectProperty{Enabled: util.FalsePtr()},
+		"fnRefs":          &types.TextProperty{Index: util.FalsePtr()},
+		"summaryRef":      &types.TextProperty{Index: util.FalsePtr()},
+		"persons":         types.NewKeywordProperty(),
+		"languages":       types.NewKeywordProperty(),
+		// nested, so that a search can be restricted to the passages of a language
+		"foreignTexts": &types.NestedProperty{
+			Properties: map[string]types.Property{
+				"lang": types.NewKeywordProperty(),
+				"text": types.TextProperty{Fields: analyzerFields(nil)},
+			},
+		},
+	},
+}
+
+func analyzerFields(positionIncrementGap *int) map[string]types.Property {
+	fields := map[string]types.Property{}
+	for _, a := range []Analyzer{NoStemming, GermanStemming, FoldedNoStemming, FoldedGermanStemming, CaseSensitive} {
+		fields[string(a)] = &types.TextProperty{
+			Analyzer:             util.StrPtr(string(a)),
+			PositionIncrementGap: positionIncrementGap,
+		}
+	}
+	return fields
+}
+
+// structs for the alignment of the paragraphs of two works, e.g. of two editions of the same work
+type Alignment struct {
+	WorkCode      string        `json:"workCode"`
+	OtherWorkCode string        `json:"otherWorkCode"`
+	Version       string        `json:"version"` // the versions of the volumes of both works, the alignment is outdated if one of them changes
+	Pairs         []AlignedPair `json:"pairs"`
+}
+
+// AlignedPair is a paragraph of a work and the matching paragraph of the other work, in the reading order of both works; one of the ordinals is nil if a paragraph has no match
+type AlignedPair struct {
+	Ordinal      *int32  `json:"ordinal"`
+	OtherOrdinal *int32  `json:"otherOrdinal"`
+	Similarity   float64 `json:"similarity"`
+}
+
+var AlignmentMapping = &types.TypeMapping{
+	Properties: map[string]types.Property{
+		"workCode":      types.NewKeywordProperty(),
+		"otherWorkCode": types.NewKeywordProperty(),
+		"version":       &types.KeywordProperty{Index: util.FalsePtr()},
+		"pairs":         &types.ObjectProperty{Enabled: util.FalsePtr()},
 	},
 }
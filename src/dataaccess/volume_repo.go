@@ -14,6 +14,7 @@ import (
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/result"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
+	"github.com/frhorschig/kant-search-backend/common/util"
 	"github.com/frhorschig/kant-search-backend/dataaccess/model"
 	"github.com/rs/zerolog/log"
 )
@@ -22,6 +23,7 @@ type VolumeRepo interface {
 	Insert(ctx context.Context, data *model.Volume) error
 	GetAll(ctx context.Context) ([]model.Volume, error)
 	GetByVolumeNumber(ctx context.Context, volNum int32) (*model.Volume, error)
+	GetByWorkCode(ctx context.Context, workCode string) (*model.Volume, error)
 	Delete(ctx context.Context, volNr int32) error
 }
 
@@ -49,6 +51,10 @@ func createVolumeIndex(es *elasticsearch.TypedClient, name string) error {
 		return err
 	}
 	if ok {
+		warnOnOutdatedMapping(es, name, func(properties map[string]types.Property) bool {
+			works, ok := properties["works"].(*types.ObjectProperty)
+			return ok && works.Properties["code"] != nil
+		})
 		return nil
 	}
 
@@ -88,6 +94,8 @@ func (rec *volumeRepoImpl) GetAll(ctx context.Context) ([]model.Volume, error) {
 		AllowPartialSearchResults(false).
 		Request(&search.Request{
 			Query: &types.Query{MatchAll: &types.MatchAllQuery{}},
+			// without a size only the first 10 volumes would be returned
+			Size: util.IntPtr(resultsSize),
 			Sort: []types.SortCombinations{
 				types.SortOptions{
 					SortOptions: map[string]types.FieldSort{
@@ -137,6 +145,32 @@ func (rec *volumeRepoImpl) GetByVolumeNumber(ctx context.Context, volNum int32)
 	return &vol, nil
 }
 
+// GetByWorkCode returns the volume that contains the work with the given code, or nil if there is no such volume
+func (rec *volumeRepoImpl) GetByWorkCode(ctx context.Context, workCode string) (*model.Volume, error) {
+	res, err := rec.dbClient.Search().Index(rec.indexName).
+		AllowPartialSearchResults(false).
+		Request(&search.Request{
+			Query: createTermQuery("works.code", workCode),
+		}).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	numOfHits := len(res.Hits.Hits)
+	if numOfHits == 0 {
+		return nil, nil
+	}
+	if numOfHits > 1 {
+		return nil, fmt.Errorf("more than one volume with work code %s found", workCode)
+	}
+
+	var vol model.Volume
+	err = json.Unmarshal(res.Hits.Hits[0].Source_, &vol)
+	if err != nil {
+		return nil, err
+	}
+	return &vol, nil
+}
+
 func (rec *volumeRepoImpl) Delete(ctx context.Context, volNr int32) error {
 	res, err := rec.dbClient.DeleteByQuery(rec.indexName).Request(&deletebyquery.Request{
 		Query: createTermQuery("volumeNumber", volNr),
@@ -5,6 +5,7 @@ package dataaccess
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -101,6 +102,20 @@ func TestVolumeRepo(t *testing.T) {
 	assert.NotNil(t, singleRes)
 	assert.Equal(t, vol2.Title, singleRes.Title)
 
+	// WHEN Get by work code
+	singleRes, err = repo.GetByWorkCode(ctx, "KPV")
+	// THEN
+	assert.Nil(t, err)
+	assert.NotNil(t, singleRes)
+	assert.Equal(t, vol2.VolumeNumber, singleRes.VolumeNumber)
+	assert.Equal(t, vol2.Works[0].Sections, singleRes.Works[0].Sections)
+
+	// WHEN Get by unknown work code
+	singleRes, err = repo.GetByWorkCode(ctx, "GMS")
+	// THEN
+	assert.Nil(t, err)
+	assert.Nil(t, singleRes)
+
 	// WHEN Delete 2ns
 	err = repo.Delete(ctx, vol2.VolumeNumber)
 	// THEN
@@ -117,6 +132,36 @@ func TestVolumeRepo(t *testing.T) {
 	assert.Nil(t, singleRes)
 }
 
+func TestVolumeRepoGetAllVolumes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	repo := NewVolumeRepo(dbClient)
+	// GIVEN more volumes than the default size of a search
+	volNrs := []int32{}
+	for nr := int32(1); nr <= 12; nr++ {
+		err := repo.Insert(ctx, &model.Volume{VolumeNumber: nr, Title: fmt.Sprintf("volume %d", nr), Works: []model.Work{}})
+		assert.Nil(t, err)
+		refreshVolumes(t)
+		volNrs = append(volNrs, nr)
+	}
+
+	// WHEN
+	res, err := repo.GetAll(ctx)
+	// THEN
+	assert.Nil(t, err)
+	resNrs := []int32{}
+	for _, vol := range res {
+		resNrs = append(resNrs, vol.VolumeNumber)
+	}
+	assert.Equal(t, volNrs, resNrs)
+
+	for _, nr := range volNrs {
+		err = repo.Delete(ctx, nr)
+		assert.Nil(t, err)
+	}
+	refreshVolumes(t)
+}
+
 func refreshVolumes(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
@@ -5,17 +5,26 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/healthstatus"
+	apialignment "github.com/frhorschig/kant-search-backend/api/alignment"
+	apiexport "github.com/frhorschig/kant-search-backend/api/export"
+	apiimage "github.com/frhorschig/kant-search-backend/api/image"
 	apiread "github.com/frhorschig/kant-search-backend/api/read"
 	apisearch "github.com/frhorschig/kant-search-backend/api/search"
+	apistats "github.com/frhorschig/kant-search-backend/api/stats"
 	apiupload "github.com/frhorschig/kant-search-backend/api/upload"
+	corealignment "github.com/frhorschig/kant-search-backend/core/alignment"
+	coreexport "github.com/frhorschig/kant-search-backend/core/export"
+	coreimage "github.com/frhorschig/kant-search-backend/core/image"
 	coreread "github.com/frhorschig/kant-search-backend/core/read"
 	coresearch "github.com/frhorschig/kant-search-backend/core/search"
+	corestats "github.com/frhorschig/kant-search-backend/core/stats"
 	coreupload "github.com/frhorschig/kant-search-backend/core/upload"
 	db "github.com/frhorschig/kant-search-backend/dataaccess"
 	"github.com/labstack/echo/v4"
@@ -65,6 +74,13 @@ func readIntConfig(name string) int {
 	return int(num)
 }
 
+func readOptionalIntConfig(name string, defaultValue int) int {
+	if strings.TrimSpace(os.Getenv(name)) == "" {
+		return defaultValue
+	}
+	return readIntConfig(name)
+}
+
 func initEchoServer() *echo.Echo {
 	e := echo.New()
 	if os.Getenv("KSGO_DISABLE_SSL") != "true" {
@@ -73,24 +89,57 @@ func initEchoServer() *echo.Echo {
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: strings.Split(os.Getenv("KSGO_ALLOW_ORIGINS"), ","),
-		AllowMethods: []string{echo.GET, echo.POST},
-		AllowHeaders: []string{"*"},
+		AllowOrigins:  strings.Split(os.Getenv("KSGO_ALLOW_ORIGINS"), ","),
+		AllowMethods:  []string{echo.GET, echo.POST},
+		AllowHeaders:  []string{"*"},
+		ExposeHeaders: []string{apiread.NextCursorHeader, apiread.ETagHeader, echo.HeaderLocation},
+	}))
+	// brotli is not supported, neither echo nor the standard library provide an encoder for it
+	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
+		Skipper: func(ctx echo.Context) bool {
+			// epub files and most images are already compressed
+			return strings.HasSuffix(ctx.Path(), "/export/epub") || strings.HasPrefix(ctx.Path(), "/api/v1/images/")
+		},
+		MinLength: 1024,
 	}))
 	return e
 }
 
-func registerHandlers(e *echo.Echo, uploadHandler apiupload.UploadHandler, readHandler apiread.ReadHandler, searchHandler apisearch.SearchHandler) {
+func registerHandlers(e *echo.Echo, uploadHandler apiupload.UploadHandler, readHandler apiread.ReadHandler, searchHandler apisearch.SearchHandler, exportHandler apiexport.ExportHandler, statsHandler apistats.StatsHandler, imageHandler apiimage.ImageHandler, alignmentHandler apialignment.AlignmentHandler) {
 	e.GET("/api/v1/health", func(c echo.Context) error {
 		return c.String(http.StatusOK, "UP")
 	})
 	e.POST("/api/v1/upload", func(ctx echo.Context) error {
 		return uploadHandler.PostVolume(ctx)
 	})
+	e.GET("/api/v1/upload/jobs/:id", func(ctx echo.Context) error {
+		return uploadHandler.ReadJob(ctx)
+	})
+	e.POST("/api/v1/upload/images/:name", func(ctx echo.Context) error {
+		return imageHandler.PostImage(ctx)
+	})
+	e.GET(("/api/v1/images/:name"), func(ctx echo.Context) error {
+		return imageHandler.ReadImage(ctx)
+	})
 
 	e.GET(("/api/v1/volumes"), func(ctx echo.Context) error {
 		return readHandler.ReadVolumes(ctx)
 	})
+	e.GET(("/api/v1/volumes/:volumeNumber"), func(ctx echo.Context) error {
+		return readHandler.ReadVolume(ctx)
+	})
+	e.GET(("/api/v1/volumes/:volumeNumber/pages/:page"), func(ctx echo.Context) error {
+		return readHandler.ReadPage(ctx)
+	})
+	e.GET(("/api/v1/persons"), func(ctx echo.Context) error {
+		return readHandler.ReadPersons(ctx)
+	})
+	e.GET(("/api/v1/citations/resolve"), func(ctx echo.Context) error {
+		return readHandler.ResolveCitation(ctx)
+	})
+	e.GET(("/api/v1/works/:workCode"), func(ctx echo.Context) error {
+		return readHandler.ReadWork(ctx)
+	})
 	e.GET(("/api/v1/works/:workCode/footnotes"), func(ctx echo.Context) error {
 		return readHandler.ReadFootnotes(ctx)
 	})
@@ -103,28 +152,83 @@ func registerHandlers(e *echo.Echo, uploadHandler apiupload.UploadHandler, readH
 	e.GET(("/api/v1/works/:workCode/summaries"), func(ctx echo.Context) error {
 		return readHandler.ReadSummaries(ctx)
 	})
+	e.GET(("/api/v1/works/:workCode/text"), func(ctx echo.Context) error {
+		return readHandler.ReadWorkText(ctx)
+	})
+	e.GET(("/api/v1/works/:workCode/navigation/:ordinal"), func(ctx echo.Context) error {
+		return readHandler.ReadNavigation(ctx)
+	})
+
+	e.GET(("/api/v1/volumes/:volumeNumber/export/epub"), func(ctx echo.Context) error {
+		return exportHandler.ExportVolumeEpub(ctx)
+	})
+	e.GET(("/api/v1/works/:workCode/export/epub"), func(ctx echo.Context) error {
+		return exportHandler.ExportWorkEpub(ctx)
+	})
+	e.GET(("/api/v1/volumes/:volumeNumber/export/tei"), func(ctx echo.Context) error {
+		return exportHandler.ExportVolumeTei(ctx)
+	})
+	e.GET(("/api/v1/works/:workCode/export/tei"), func(ctx echo.Context) error {
+		return exportHandler.ExportWorkTei(ctx)
+	})
+
+	e.GET(("/api/v1/volumes/:volumeNumber/stats"), func(ctx echo.Context) error {
+		return statsHandler.ReadVolumeStats(ctx)
+	})
+	e.GET(("/api/v1/works/:workCode/stats"), func(ctx echo.Context) error {
+		return statsHandler.ReadWorkStats(ctx)
+	})
+
+	e.GET(("/api/v1/works/:workCode/alignment"), func(ctx echo.Context) error {
+		return alignmentHandler.ReadAlignment(ctx)
+	})
 
 	e.POST(("/api/v1/search"), func(ctx echo.Context) error {
 		return searchHandler.Search(ctx)
 	})
+	e.POST(("/api/v1/search/batch"), func(ctx echo.Context) error {
+		return searchHandler.SearchBatch(ctx)
+	})
 }
 
 func main() {
 	es := initEsConnection()
 
 	volumeRepo := db.NewVolumeRepo(es)
-	contentRepo := db.NewContentRepo(es)
+	contentRepo := db.NewContentRepo(es, db.FoldingConfig{
+		UseIcu:        os.Getenv("KSGO_FOLDING_ICU") == "true",
+		ExpandUmlauts: os.Getenv("KSGO_FOLDING_EXPAND_UMLAUTS") == "true",
+	})
+
+	alignmentRepo := db.NewAlignmentRepo(es)
+	imageRepo := db.NewImageRepo(filepath.Join(os.Getenv("KSGO_CONFIG_PATH"), "images"))
 
-	uploadProcessor := coreupload.NewUploadProcessor(volumeRepo, contentRepo, os.Getenv("KSGO_CONFIG_PATH"))
+	uploadProcessor := coreupload.NewUploadProcessor(volumeRepo, contentRepo, imageRepo, os.Getenv("KSGO_CONFIG_PATH"))
+	jobManager := coreupload.NewJobManager(uploadProcessor,
+		readOptionalIntConfig("KSGO_UPLOAD_WORKERS", 1),
+		readOptionalIntConfig("KSGO_UPLOAD_QUEUE_SIZE", 10),
+	)
 	readProcessor := coreread.NewReadProcessor(volumeRepo, contentRepo)
-	searchProcessor := coresearch.NewSearchProcessor(contentRepo)
+	searchProcessor := coresearch.NewSearchProcessor(contentRepo, coresearch.QueryLimits{
+		MaxTokens:  readOptionalIntConfig("KSGO_MAX_SEARCH_TOKENS", 100),
+		MaxDepth:   readOptionalIntConfig("KSGO_MAX_SEARCH_DEPTH", 10),
+		MaxPhrases: readOptionalIntConfig("KSGO_MAX_SEARCH_PHRASES", 20),
+	})
+	exportProcessor := coreexport.NewExportProcessor(volumeRepo, readProcessor)
+	statsProcessor := corestats.NewStatsProcessor(volumeRepo, contentRepo)
+	imageProcessor := coreimage.NewImageProcessor(imageRepo)
+	alignmentProcessor := corealignment.NewAlignmentProcessor(volumeRepo, contentRepo, alignmentRepo)
 
-	uploadHandler := apiupload.NewUploadHandler(uploadProcessor)
+	uploadHandler := apiupload.NewUploadHandler(uploadProcessor, jobManager)
 	readHandler := apiread.NewReadHandler(readProcessor)
 	searchHandler := apisearch.NewSearchHandler(searchProcessor)
+	exportHandler := apiexport.NewExportHandler(exportProcessor)
+	statsHandler := apistats.NewStatsHandler(statsProcessor)
+	imageHandler := apiimage.NewImageHandler(imageProcessor)
+	alignmentHandler := apialignment.NewAlignmentHandler(alignmentProcessor)
 
 	e := initEchoServer()
-	registerHandlers(e, uploadHandler, readHandler, searchHandler)
+	registerHandlers(e, uploadHandler, readHandler, searchHandler, exportHandler, statsHandler, imageHandler, alignmentHandler)
 	if os.Getenv("KSGO_DISABLE_SSL") == "true" {
 		e.Logger.Fatal(e.Start(":5000"))
 	} else {